@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+
+	rediscache "github.com/yohnnn/booking_service/internal/cache/redis"
+	"github.com/yohnnn/booking_service/internal/config"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/postgres"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	email := flag.String("email", "", "email of the user to update")
+	role := flag.String("role", string(models.UserRoleAdmin), "role to assign (user or admin)")
+	flag.Parse()
+
+	if *email == "" {
+		fmt.Fprintln(os.Stderr, "usage: admin -email user@example.com [-role admin]")
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	if err := run(logger, cfg, *email, models.UserRole(*role)); err != nil {
+		logger.Error("failed to set role", "error", err, "email", *email)
+		os.Exit(1)
+	}
+}
+
+func run(logger *slog.Logger, cfg *config.Config, email string, role models.UserRole) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, cfg.Postgres.DSN())
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr()})
+	defer func() { _ = redisClient.Close() }()
+
+	authService := service.NewAuthService(
+		logger,
+		postgres.NewUserRepo(pool),
+		postgres.NewRefreshTokenRepo(pool),
+		rediscache.NewTokenDenylist(redisClient),
+		tx.NewManager(pool),
+		cfg.JWT.SecretKey,
+		cfg.JWT.TokenTTL,
+		cfg.JWT.RefreshTTL,
+	)
+
+	user, err := authService.SetRole(ctx, email, role)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf("user %s not found", email)
+		}
+		return err
+	}
+
+	logger.InfoContext(ctx, "role updated", "user_id", user.ID, "email", user.Email, "role", user.Role)
+
+	return nil
+}
@@ -70,11 +70,11 @@ func handleMessage(_ context.Context, msg kafka.Message) error {
 	}
 
 	log.Printf(
-		"Notify user=%s booking=%s concert=%s seat=%d",
+		"Notify user=%s bookings=%v concert=%s seats=%v",
 		evt.UserID,
-		evt.BookingID,
+		evt.BookingIDs,
 		evt.ConcertID,
-		evt.Seat,
+		evt.Seats,
 	)
 
 	time.Sleep(200 * time.Millisecond)
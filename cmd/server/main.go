@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/yohnnn/booking_service/internal/app"
 	"github.com/yohnnn/booking_service/internal/config"
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -19,17 +20,21 @@ import (
 	"github.com/yohnnn/booking_service/internal/event"
 	"github.com/yohnnn/booking_service/internal/handler"
 	v1 "github.com/yohnnn/booking_service/internal/handler/v1"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/payment"
 	"github.com/yohnnn/booking_service/internal/repository/postgres"
 	"github.com/yohnnn/booking_service/internal/repository/tx"
 	"github.com/yohnnn/booking_service/internal/service"
+	"github.com/yohnnn/booking_service/internal/worker"
 )
 
 type App struct {
-	logger *slog.Logger
-	server *http.Server
-	pool   *pgxpool.Pool
-	redis  *redis.Client
-	kafka  *event.KafkaProducer
+	logger  *slog.Logger
+	server  *http.Server
+	pool    *pgxpool.Pool
+	redis   *redis.Client
+	kafka   *event.KafkaProducer
+	workers []worker.Worker
 }
 
 func NewApp(ctx context.Context, logger *slog.Logger, cfg *config.Config) (*App, error) {
@@ -50,25 +55,223 @@ func NewApp(ctx context.Context, logger *slog.Logger, cfg *config.Config) (*App,
 	}
 	logger.InfoContext(ctx, "Connected to Redis")
 
-	kafkaProducer := event.NewKafkaProducer(cfg.Kafka.Brokers, cfg.Kafka.Topic)
+	kafkaProducer := event.NewKafkaProducer(cfg.Kafka.Brokers, map[string]string{
+		event.TypeBookingCreated:   cfg.Kafka.Topic,
+		event.TypeBookingCancelled: cfg.Kafka.CancelledTopic,
+		event.TypeConcertCancelled: cfg.Kafka.ConcertTopic,
+		event.TypeWaitlistOffered:  cfg.Kafka.WaitlistTopic,
+	})
 
 	validate := validator.New()
 	txManager := tx.NewManager(pool)
 	cache := rediscache.NewConcertCache(redisClient, 5*time.Minute)
+	idempotencyCache := rediscache.NewIdempotencyCache(redisClient, cfg.Idempotency.TTL, cfg.Idempotency.LockTTL)
+	tokenDenylist := rediscache.NewTokenDenylist(redisClient)
+	seatMapCache := rediscache.NewSeatMapCache(redisClient, cfg.Booking.SeatMapTTL)
+	waitingRoomCache := rediscache.NewWaitingRoomCache(redisClient)
 
 	userRepo := postgres.NewUserRepo(pool)
 	concertRepo := postgres.NewConcertRepo(pool)
+	venueRepo := postgres.NewVenueRepo(pool)
+	ticketTierRepo := postgres.NewTicketTierRepo(pool)
+	presaleRepo := postgres.NewPresaleRepo(pool)
 	bookingRepo := postgres.NewBookingRepo(pool)
+	outboxRepo := postgres.NewOutboxRepo(pool)
+	refreshTokenRepo := postgres.NewRefreshTokenRepo(pool)
+	cancellationRepo := postgres.NewCancellationRepo(pool)
+	refundRepo := postgres.NewRefundRepo(pool)
+	waitlistRepo := postgres.NewWaitlistRepo(pool)
+	paymentRepo := postgres.NewPaymentRepo(pool)
+	ledgerRepo := postgres.NewLedgerRepo(pool)
+	promoCodeRepo := postgres.NewPromoCodeRepo(pool)
 
-	authService := service.NewAuthService(logger, userRepo, cfg.JWT.SecretKey, cfg.JWT.TokenTTL)
-	concertService := service.NewConcertService(logger, concertRepo, cache)
-	bookingService := service.NewBookingService(logger, bookingRepo, concertRepo, cache, txManager, kafkaProducer)
+	paymentProvider := payment.NewFakeProvider(
+		logger,
+		cfg.Payment.WebhookURL,
+		cfg.Payment.WebhookSecret,
+		cfg.Payment.FakeOutcome,
+		cfg.Payment.FakeDelay,
+	)
+
+	authService := service.NewAuthService(
+		logger,
+		userRepo,
+		refreshTokenRepo,
+		tokenDenylist,
+		txManager,
+		cfg.JWT.SecretKey,
+		cfg.JWT.TokenTTL,
+		cfg.JWT.RefreshTTL,
+	)
+	concertService := service.NewConcertService(
+		logger,
+		concertRepo,
+		venueRepo,
+		ticketTierRepo,
+		presaleRepo,
+		cache,
+		seatMapCache,
+		txManager,
+		cfg.Concert.AccessCodeSecret,
+	)
+	venueService := service.NewVenueService(logger, venueRepo, txManager)
+	bookingService := service.NewBookingService(
+		logger,
+		bookingRepo,
+		concertRepo,
+		ticketTierRepo,
+		presaleRepo,
+		waitlistRepo,
+		promoCodeRepo,
+		outboxRepo,
+		ledgerRepo,
+		cache,
+		seatMapCache,
+		waitingRoomCache,
+		txManager,
+		cfg.Booking.HoldTTL,
+		cfg.Booking.OfferTTL,
+		models.FeePolicy{
+			ServiceFeePercent: cfg.Fee.ServiceFeePercent,
+			VATPercent:        cfg.Fee.VATPercent,
+			VATBase:           models.VATBase(cfg.Fee.VATBase),
+		},
+		cfg.Concert.AccessCodeSecret,
+	)
+	waitlistService := service.NewWaitlistService(logger, concertRepo, waitlistRepo)
+	promoCodeService := service.NewPromoCodeService(logger, concertRepo, promoCodeRepo)
+	paymentService := service.NewPaymentService(
+		logger,
+		bookingRepo,
+		paymentRepo,
+		refundRepo,
+		ledgerRepo,
+		bookingService,
+		paymentProvider,
+		txManager,
+	)
+	waitingRoomService := service.NewWaitingRoomService(
+		logger,
+		concertRepo,
+		waitingRoomCache,
+		cfg.Queue.AdmitInterval,
+		cfg.Queue.AdmissionTTL,
+	)
+	cancellationService := service.NewCancellationService(
+		logger,
+		concertRepo,
+		bookingRepo,
+		cancellationRepo,
+		refundRepo,
+		ledgerRepo,
+		promoCodeRepo,
+		waitlistRepo,
+		outboxRepo,
+		cache,
+		seatMapCache,
+		waitingRoomCache,
+		txManager,
+	)
+	refundService := service.NewRefundService(
+		logger,
+		bookingRepo,
+		concertRepo,
+		refundRepo,
+		paymentRepo,
+		ledgerRepo,
+		bookingService,
+		paymentProvider,
+		txManager,
+		models.RefundPolicy{
+			FullWindow:     cfg.Refund.FullWindow,
+			PartialWindow:  cfg.Refund.PartialWindow,
+			PartialPercent: cfg.Refund.PartialPercent,
+		},
+		cfg.Refund.ClaimTimeout,
+	)
+	ledgerService := service.NewLedgerService(logger, ledgerRepo)
+	outboxService := service.NewOutboxService(
+		logger,
+		outboxRepo,
+		kafkaProducer,
+		cfg.Outbox.RetryBase,
+		cfg.Outbox.RetryMax,
+		cfg.Outbox.ClaimTimeout,
+	)
+
+	workers := []worker.Worker{
+		worker.NewBatchWorker(
+			logger,
+			"hold_reaper",
+			bookingService.ReleaseExpiredHolds,
+			cfg.Booking.ReaperInterval,
+			cfg.Booking.ReaperBatchSize,
+		),
+		worker.NewBatchWorker(
+			logger,
+			"outbox_relay",
+			outboxService.RelayPending,
+			cfg.Outbox.RelayInterval,
+			cfg.Outbox.BatchSize,
+		),
+		worker.NewPeriodicWorker(
+			logger,
+			"waiting_room_admitter",
+			waitingRoomService.AdmitWaiting,
+			cfg.Queue.AdmitInterval,
+		),
+		worker.NewBatchWorker(
+			logger,
+			"concert_completer",
+			concertService.CompletePast,
+			cfg.Concert.CompleterInterval,
+			cfg.Concert.CompleterBatchSize,
+		),
+		worker.NewBatchWorker(
+			logger,
+			"concert_canceller",
+			cancellationService.ProcessPending,
+			cfg.Concert.CancellationInterval,
+			cfg.Concert.CancellationBatchSize,
+		),
+		worker.NewBatchWorker(
+			logger,
+			"refund_processor",
+			refundService.ProcessPending,
+			cfg.Refund.ProcessInterval,
+			cfg.Refund.BatchSize,
+		),
+	}
 
 	authHandler := v1.NewAuthHandler(logger, validate, authService)
-	concertHandler := v1.NewConcertHandler(logger, concertService)
+	concertHandler := v1.NewConcertHandler(logger, validate, concertService)
+	venueHandler := v1.NewVenueHandler(logger, validate, venueService)
 	bookingHandler := v1.NewBookingHandler(logger, validate, bookingService)
+	waitingRoomHandler := v1.NewWaitingRoomHandler(logger, validate, waitingRoomService)
+	cancellationHandler := v1.NewCancellationHandler(logger, validate, cancellationService)
+	waitlistHandler := v1.NewWaitlistHandler(logger, waitlistService)
+	paymentHandler := v1.NewPaymentHandler(logger, paymentService)
+	refundHandler := v1.NewRefundHandler(logger, refundService)
+	ledgerHandler := v1.NewLedgerHandler(logger, ledgerService)
+	promoCodeHandler := v1.NewPromoCodeHandler(logger, validate, promoCodeService)
 
-	router := handler.NewRouter(logger, authService, authHandler, concertHandler, bookingHandler)
+	router := handler.NewRouter(
+		logger,
+		authService,
+		idempotencyCache,
+		cfg.Idempotency.LockTTL,
+		authHandler,
+		concertHandler,
+		venueHandler,
+		bookingHandler,
+		waitingRoomHandler,
+		cancellationHandler,
+		waitlistHandler,
+		paymentHandler,
+		refundHandler,
+		ledgerHandler,
+		promoCodeHandler,
+	)
 
 	server := &http.Server{
 		Addr:              fmt.Sprintf(":%d", cfg.Port),
@@ -77,15 +280,28 @@ func NewApp(ctx context.Context, logger *slog.Logger, cfg *config.Config) (*App,
 	}
 
 	return &App{
-		logger: logger,
-		server: server,
-		pool:   pool,
-		redis:  redisClient,
-		kafka:  kafkaProducer,
+		logger:  logger,
+		server:  server,
+		pool:    pool,
+		redis:   redisClient,
+		kafka:   kafkaProducer,
+		workers: workers,
 	}, nil
 }
 
 func (a *App) Run() error {
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var wg sync.WaitGroup
+	for _, w := range a.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Run(workerCtx)
+		}()
+	}
+
 	go func() {
 		a.logger.Info("Server starting", "address", a.server.Addr)
 		if err := a.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -102,6 +318,9 @@ func (a *App) Run() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	stopWorkers()
+	wg.Wait()
+
 	if err := a.server.Shutdown(ctx); err != nil {
 		return err
 	}
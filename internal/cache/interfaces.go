@@ -2,12 +2,59 @@ package cache
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/yohnnn/booking_service/internal/models"
 )
 
 type ConcertCacheRepository interface {
-	Get(ctx context.Context) ([]models.Concert, error)
-	Set(ctx context.Context, concerts []models.Concert) error
+	Get(ctx context.Context, query string) (models.ConcertPage, bool, error)
+	Set(ctx context.Context, query string, page *models.ConcertPage) error
 	Delete(ctx context.Context) error
 }
+
+type IdempotencyRecord struct {
+	RequestHash string `json:"request_hash"`
+	Completed   bool   `json:"completed"`
+	StatusCode  int    `json:"status_code"`
+	Body        []byte `json:"body"`
+}
+
+type IdempotencyCacheRepository interface {
+	Reserve(ctx context.Context, key, requestHash string) (IdempotencyRecord, bool, error)
+	Extend(ctx context.Context, key string) error
+	Save(ctx context.Context, key string, record *IdempotencyRecord) error
+	Delete(ctx context.Context, key string) error
+}
+
+type TokenDenylistRepository interface {
+	Revoke(ctx context.Context, tokenID string, ttl time.Duration) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+type SeatMapRepository interface {
+	Get(ctx context.Context, concertID uuid.UUID) (models.SeatMap, bool, error)
+	Version(ctx context.Context, concertID uuid.UUID) (int64, error)
+	Set(ctx context.Context, concertID uuid.UUID, seats models.SeatMap, version int64) (bool, error)
+	SetStates(ctx context.Context, concertID uuid.UUID, seats []int, state models.SeatState) error
+	Delete(ctx context.Context, concertID uuid.UUID) error
+}
+
+type WaitingRoomRepository interface {
+	Open(ctx context.Context, room models.WaitingRoom) error
+	Close(ctx context.Context, concertID uuid.UUID) (bool, error)
+	IsActive(ctx context.Context, concertID uuid.UUID) (bool, error)
+	Active(ctx context.Context) ([]models.WaitingRoom, error)
+	Join(
+		ctx context.Context,
+		concertID, userID uuid.UUID,
+		token string,
+		now time.Time,
+	) (models.QueueTicket, bool, error)
+	Status(ctx context.Context, concertID, userID uuid.UUID, now time.Time) (models.QueueTicket, bool, error)
+	Admit(ctx context.Context, concertID uuid.UUID, count int, expiresAt, now time.Time) (int, error)
+	IsAdmitted(ctx context.Context, concertID, userID uuid.UUID, token string, now time.Time) (bool, error)
+	Consume(ctx context.Context, concertID, userID uuid.UUID, token string) error
+}
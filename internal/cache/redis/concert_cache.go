@@ -2,8 +2,11 @@ package rediscache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -11,6 +14,8 @@ import (
 	"github.com/yohnnn/booking_service/internal/models"
 )
 
+const concertsVersionKey = "concerts:version"
+
 type ConcertCache struct {
 	client *redis.Client
 	ttl    time.Duration
@@ -23,32 +28,53 @@ func NewConcertCache(client *redis.Client, ttl time.Duration) *ConcertCache {
 	}
 }
 
-func (c *ConcertCache) Get(ctx context.Context) ([]models.Concert, error) {
-	val, err := c.client.Get(ctx, "concerts:all").Result()
+func (c *ConcertCache) Get(ctx context.Context, query string) (models.ConcertPage, bool, error) {
+	key, err := c.key(ctx, query)
+	if err != nil {
+		return models.ConcertPage{}, false, err
+	}
+
+	val, err := c.client.Get(ctx, key).Bytes()
 	if errors.Is(err, redis.Nil) {
-		return nil, nil
+		return models.ConcertPage{}, false, nil
 	}
 	if err != nil {
-		return nil, err
+		return models.ConcertPage{}, false, err
 	}
 
-	var concerts []models.Concert
-	if err := json.Unmarshal([]byte(val), &concerts); err != nil {
-		return nil, err
+	var page models.ConcertPage
+	if err := json.Unmarshal(val, &page); err != nil {
+		return models.ConcertPage{}, false, err
 	}
 
-	return concerts, nil
+	return page, true, nil
 }
 
-func (c *ConcertCache) Set(ctx context.Context, concerts []models.Concert) error {
-	data, err := json.Marshal(concerts)
+func (c *ConcertCache) Set(ctx context.Context, query string, page *models.ConcertPage) error {
+	key, err := c.key(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	return c.client.Set(ctx, "concerts:all", data, c.ttl).Err()
+	data, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, key, data, c.ttl).Err()
 }
 
 func (c *ConcertCache) Delete(ctx context.Context) error {
-	return c.client.Del(ctx, "concerts:all").Err()
+	return c.client.Incr(ctx, concertsVersionKey).Err()
+}
+
+func (c *ConcertCache) key(ctx context.Context, query string) (string, error) {
+	version, err := c.client.Get(ctx, concertsVersionKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(query))
+
+	return fmt.Sprintf("concerts:v%d:%s", version, hex.EncodeToString(sum[:])), nil
 }
@@ -0,0 +1,87 @@
+package rediscache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/yohnnn/booking_service/internal/cache"
+)
+
+const maxReserveAttempts = 3
+
+type IdempotencyCache struct {
+	client  *redis.Client
+	ttl     time.Duration
+	lockTTL time.Duration
+}
+
+func NewIdempotencyCache(client *redis.Client, ttl, lockTTL time.Duration) *IdempotencyCache {
+	return &IdempotencyCache{
+		client:  client,
+		ttl:     ttl,
+		lockTTL: lockTTL,
+	}
+}
+
+func (c *IdempotencyCache) Reserve(
+	ctx context.Context,
+	key, requestHash string,
+) (cache.IdempotencyRecord, bool, error) {
+	data, err := json.Marshal(cache.IdempotencyRecord{RequestHash: requestHash})
+	if err != nil {
+		return cache.IdempotencyRecord{}, false, err
+	}
+
+	for range maxReserveAttempts {
+		reserved, err := c.client.SetNX(ctx, idempotencyKey(key), data, c.lockTTL).Result()
+		if err != nil {
+			return cache.IdempotencyRecord{}, false, err
+		}
+		if reserved {
+			return cache.IdempotencyRecord{}, true, nil
+		}
+
+		val, err := c.client.Get(ctx, idempotencyKey(key)).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return cache.IdempotencyRecord{}, false, err
+		}
+
+		var record cache.IdempotencyRecord
+		if err := json.Unmarshal(val, &record); err != nil {
+			return cache.IdempotencyRecord{}, false, err
+		}
+
+		return record, false, nil
+	}
+
+	return cache.IdempotencyRecord{}, false, fmt.Errorf("idempotency key %q is contended", key)
+}
+
+func (c *IdempotencyCache) Extend(ctx context.Context, key string) error {
+	return c.client.Expire(ctx, idempotencyKey(key), c.lockTTL).Err()
+}
+
+func (c *IdempotencyCache) Save(ctx context.Context, key string, record *cache.IdempotencyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, idempotencyKey(key), data, c.ttl).Err()
+}
+
+func (c *IdempotencyCache) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, idempotencyKey(key)).Err()
+}
+
+func idempotencyKey(key string) string {
+	return "idempotency:" + key
+}
@@ -0,0 +1,122 @@
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/yohnnn/booking_service/internal/models"
+)
+
+const seatMapVersionTTL = 24 * time.Hour
+
+var setSeatMapScript = redis.NewScript(`
+local version = tonumber(redis.call('GET', KEYS[2]) or '0')
+if version ~= tonumber(ARGV[1]) then
+	return 0
+end
+if redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3], 'NX') then
+	return 1
+end
+return 0
+`)
+
+var setSeatStatesScript = redis.NewScript(`
+redis.call('INCR', KEYS[2])
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+for i = 3, #ARGV do
+	redis.call('BITFIELD', KEYS[1], 'SET', 'u2', '#' .. ARGV[i], ARGV[1])
+end
+return 1
+`)
+
+var deleteSeatMapScript = redis.NewScript(`
+redis.call('INCR', KEYS[2])
+redis.call('PEXPIRE', KEYS[2], ARGV[1])
+return redis.call('DEL', KEYS[1])
+`)
+
+type SeatMapCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewSeatMapCache(client *redis.Client, ttl time.Duration) *SeatMapCache {
+	return &SeatMapCache{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (c *SeatMapCache) Get(ctx context.Context, concertID uuid.UUID) (models.SeatMap, bool, error) {
+	val, err := c.client.Get(ctx, seatMapKey(concertID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return models.SeatMap(val), true, nil
+}
+
+func (c *SeatMapCache) Version(ctx context.Context, concertID uuid.UUID) (int64, error) {
+	version, err := c.client.Get(ctx, seatMapVersionKey(concertID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (c *SeatMapCache) Set(
+	ctx context.Context,
+	concertID uuid.UUID,
+	seats models.SeatMap,
+	version int64,
+) (bool, error) {
+	keys := []string{seatMapKey(concertID), seatMapVersionKey(concertID)}
+	stored, err := setSeatMapScript.Run(ctx, c.client, keys, version, []byte(seats), c.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return stored == 1, nil
+}
+
+func (c *SeatMapCache) SetStates(
+	ctx context.Context,
+	concertID uuid.UUID,
+	seats []int,
+	state models.SeatState,
+) error {
+	if len(seats) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(seats)+2)
+	args = append(args, int(state), seatMapVersionTTL.Milliseconds())
+	for _, seat := range seats {
+		args = append(args, strconv.Itoa(seat))
+	}
+
+	keys := []string{seatMapKey(concertID), seatMapVersionKey(concertID)}
+	return setSeatStatesScript.Run(ctx, c.client, keys, args...).Err()
+}
+
+func (c *SeatMapCache) Delete(ctx context.Context, concertID uuid.UUID) error {
+	keys := []string{seatMapKey(concertID), seatMapVersionKey(concertID)}
+	return deleteSeatMapScript.Run(ctx, c.client, keys, seatMapVersionTTL.Milliseconds()).Err()
+}
+
+func seatMapKey(concertID uuid.UUID) string {
+	return "seatmap:" + concertID.String()
+}
+
+func seatMapVersionKey(concertID uuid.UUID) string {
+	return "seatmap:version:" + concertID.String()
+}
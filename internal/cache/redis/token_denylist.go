@@ -0,0 +1,32 @@
+package rediscache
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type TokenDenylist struct {
+	client *redis.Client
+}
+
+func NewTokenDenylist(client *redis.Client) *TokenDenylist {
+	return &TokenDenylist{client: client}
+}
+
+func (d *TokenDenylist) Revoke(ctx context.Context, tokenID string, ttl time.Duration) error {
+	return d.client.Set(ctx, denylistKey(tokenID), 1, ttl).Err()
+}
+
+func (d *TokenDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	n, err := d.client.Exists(ctx, denylistKey(tokenID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func denylistKey(tokenID string) string {
+	return "denylist:jti:" + tokenID
+}
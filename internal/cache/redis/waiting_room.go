@@ -0,0 +1,284 @@
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/yohnnn/booking_service/internal/models"
+)
+
+const waitingRoomActiveKey = "waitingroom:active"
+
+var joinQueueScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 then
+	return false
+end
+local token = redis.call('HGET', KEYS[2], ARGV[2])
+if token then
+	local rank = redis.call('ZRANK', KEYS[4], token)
+	if rank then
+		return {token, rank + 1, 0}
+	end
+	local expires = redis.call('ZSCORE', KEYS[5], token)
+	if expires and tonumber(expires) > tonumber(ARGV[4]) then
+		return {token, 0, tonumber(expires)}
+	end
+	redis.call('HDEL', KEYS[3], token)
+	redis.call('ZREM', KEYS[5], token)
+end
+token = ARGV[3]
+redis.call('ZADD', KEYS[4], redis.call('INCR', KEYS[6]), token)
+redis.call('HSET', KEYS[2], ARGV[2], token)
+redis.call('HSET', KEYS[3], token, ARGV[2])
+return {token, redis.call('ZRANK', KEYS[4], token) + 1, 0}
+`)
+
+var queueStatusScript = redis.NewScript(`
+local token = redis.call('HGET', KEYS[1], ARGV[1])
+if not token then
+	return false
+end
+local rank = redis.call('ZRANK', KEYS[2], token)
+if rank then
+	return {token, rank + 1, 0}
+end
+local expires = redis.call('ZSCORE', KEYS[3], token)
+if expires and tonumber(expires) > tonumber(ARGV[2]) then
+	return {token, 0, tonumber(expires)}
+end
+return false
+`)
+
+var admitQueueScript = redis.NewScript(`
+local expired = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[3])
+for _, token in ipairs(expired) do
+	local user = redis.call('HGET', KEYS[3], token)
+	if user and redis.call('HGET', KEYS[4], user) == token then
+		redis.call('HDEL', KEYS[4], user)
+	end
+	redis.call('HDEL', KEYS[3], token)
+end
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', ARGV[3])
+local popped = redis.call('ZPOPMIN', KEYS[1], ARGV[1])
+for i = 1, #popped, 2 do
+	redis.call('ZADD', KEYS[2], ARGV[2], popped[i])
+end
+return #popped / 2
+`)
+
+var consumeQueueTokenScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], ARGV[1]) == ARGV[2] then
+	redis.call('HDEL', KEYS[1], ARGV[1])
+end
+redis.call('HDEL', KEYS[2], ARGV[2])
+redis.call('ZREM', KEYS[3], ARGV[2])
+return 1
+`)
+
+type WaitingRoomCache struct {
+	client *redis.Client
+}
+
+func NewWaitingRoomCache(client *redis.Client) *WaitingRoomCache {
+	return &WaitingRoomCache{client: client}
+}
+
+func (c *WaitingRoomCache) Open(ctx context.Context, room models.WaitingRoom) error {
+	return c.client.HSet(ctx, waitingRoomActiveKey, room.ConcertID.String(), room.AdmitRate).Err()
+}
+
+func (c *WaitingRoomCache) Close(ctx context.Context, concertID uuid.UUID) (bool, error) {
+	var removed *redis.IntCmd
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		removed = pipe.HDel(ctx, waitingRoomActiveKey, concertID.String())
+		pipe.Del(
+			ctx,
+			waitingRoomKey(concertID, "users"),
+			waitingRoomKey(concertID, "tokens"),
+			waitingRoomKey(concertID, "waiting"),
+			waitingRoomKey(concertID, "admitted"),
+			waitingRoomKey(concertID, "seq"),
+		)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return removed.Val() > 0, nil
+}
+
+func (c *WaitingRoomCache) IsActive(ctx context.Context, concertID uuid.UUID) (bool, error) {
+	return c.client.HExists(ctx, waitingRoomActiveKey, concertID.String()).Result()
+}
+
+func (c *WaitingRoomCache) Active(ctx context.Context) ([]models.WaitingRoom, error) {
+	vals, err := c.client.HGetAll(ctx, waitingRoomActiveKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	rooms := make([]models.WaitingRoom, 0, len(vals))
+	for id, rate := range vals {
+		concertID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid waiting room id %q: %w", id, err)
+		}
+		admitRate, err := strconv.Atoi(rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid admit rate for %s: %w", id, err)
+		}
+		rooms = append(rooms, models.WaitingRoom{ConcertID: concertID, AdmitRate: admitRate})
+	}
+	return rooms, nil
+}
+
+func (c *WaitingRoomCache) Join(
+	ctx context.Context,
+	concertID, userID uuid.UUID,
+	token string,
+	now time.Time,
+) (models.QueueTicket, bool, error) {
+	res, err := joinQueueScript.Run(
+		ctx,
+		c.client,
+		[]string{
+			waitingRoomActiveKey,
+			waitingRoomKey(concertID, "users"),
+			waitingRoomKey(concertID, "tokens"),
+			waitingRoomKey(concertID, "waiting"),
+			waitingRoomKey(concertID, "admitted"),
+			waitingRoomKey(concertID, "seq"),
+		},
+		concertID.String(), userID.String(), token, now.UnixMilli(),
+	).Slice()
+	if errors.Is(err, redis.Nil) {
+		return models.QueueTicket{}, false, nil
+	}
+	if err != nil {
+		return models.QueueTicket{}, false, err
+	}
+
+	ticket, err := parseQueueTicket(concertID, res)
+	if err != nil {
+		return models.QueueTicket{}, false, err
+	}
+	return ticket, true, nil
+}
+
+func (c *WaitingRoomCache) Status(
+	ctx context.Context,
+	concertID, userID uuid.UUID,
+	now time.Time,
+) (models.QueueTicket, bool, error) {
+	res, err := queueStatusScript.Run(
+		ctx,
+		c.client,
+		[]string{
+			waitingRoomKey(concertID, "users"),
+			waitingRoomKey(concertID, "waiting"),
+			waitingRoomKey(concertID, "admitted"),
+		},
+		userID.String(), now.UnixMilli(),
+	).Slice()
+	if errors.Is(err, redis.Nil) {
+		return models.QueueTicket{}, false, nil
+	}
+	if err != nil {
+		return models.QueueTicket{}, false, err
+	}
+
+	ticket, err := parseQueueTicket(concertID, res)
+	if err != nil {
+		return models.QueueTicket{}, false, err
+	}
+	return ticket, true, nil
+}
+
+func (c *WaitingRoomCache) Admit(
+	ctx context.Context,
+	concertID uuid.UUID,
+	count int,
+	expiresAt, now time.Time,
+) (int, error) {
+	return admitQueueScript.Run(
+		ctx,
+		c.client,
+		[]string{
+			waitingRoomKey(concertID, "waiting"),
+			waitingRoomKey(concertID, "admitted"),
+			waitingRoomKey(concertID, "tokens"),
+			waitingRoomKey(concertID, "users"),
+		},
+		count, expiresAt.UnixMilli(), now.UnixMilli(),
+	).Int()
+}
+
+func (c *WaitingRoomCache) IsAdmitted(
+	ctx context.Context,
+	concertID, userID uuid.UUID,
+	token string,
+	now time.Time,
+) (bool, error) {
+	var (
+		owner     *redis.StringCmd
+		expiresAt *redis.FloatCmd
+	)
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		owner = pipe.HGet(ctx, waitingRoomKey(concertID, "tokens"), token)
+		expiresAt = pipe.ZScore(ctx, waitingRoomKey(concertID, "admitted"), token)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return owner.Val() == userID.String() && int64(expiresAt.Val()) > now.UnixMilli(), nil
+}
+
+func (c *WaitingRoomCache) Consume(ctx context.Context, concertID, userID uuid.UUID, token string) error {
+	return consumeQueueTokenScript.Run(
+		ctx,
+		c.client,
+		[]string{
+			waitingRoomKey(concertID, "users"),
+			waitingRoomKey(concertID, "tokens"),
+			waitingRoomKey(concertID, "admitted"),
+		},
+		userID.String(), token,
+	).Err()
+}
+
+func parseQueueTicket(concertID uuid.UUID, res []any) (models.QueueTicket, error) {
+	if len(res) != 3 {
+		return models.QueueTicket{}, fmt.Errorf("unexpected waiting room reply: %v", res)
+	}
+
+	token, _ := res[0].(string)
+	position, _ := res[1].(int64)
+	expiresAt, _ := res[2].(int64)
+
+	ticket := models.QueueTicket{
+		ConcertID: concertID,
+		Token:     token,
+		Position:  position,
+	}
+	if expiresAt > 0 {
+		t := time.UnixMilli(expiresAt).UTC()
+		ticket.Admitted = true
+		ticket.ExpiresAt = &t
+	}
+	return ticket, nil
+}
+
+func waitingRoomKey(concertID uuid.UUID, suffix string) string {
+	return "waitingroom:" + concertID.String() + ":" + suffix
+}
@@ -11,17 +11,76 @@ import (
 )
 
 type Config struct {
-	Env      string `env:"APP_ENV"   envDefault:"local"`
-	Port     int    `env:"HTTP_PORT" envDefault:"8080"`
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	Kafka    KafkaConfig
-	JWT      JWTConfig
+	Env         string `env:"APP_ENV"   envDefault:"local"`
+	Port        int    `env:"HTTP_PORT" envDefault:"8080"`
+	Postgres    PostgresConfig
+	Redis       RedisConfig
+	Kafka       KafkaConfig
+	JWT         JWTConfig
+	Booking     BookingConfig
+	Idempotency IdempotencyConfig
+	Outbox      OutboxConfig
+	Queue       QueueConfig
+	Concert     ConcertConfig
+	Payment     PaymentConfig
+	Refund      RefundConfig
+	Fee         FeeConfig
 }
 
 type JWTConfig struct {
-	SecretKey string        `env:"JWT_SECRET"`
-	TokenTTL  time.Duration `env:"JWT_TTL"    envDefault:"24h"`
+	SecretKey  string        `env:"JWT_SECRET"`
+	TokenTTL   time.Duration `env:"JWT_TTL"         envDefault:"15m"`
+	RefreshTTL time.Duration `env:"JWT_REFRESH_TTL" envDefault:"720h"`
+}
+
+type BookingConfig struct {
+	HoldTTL         time.Duration `env:"BOOKING_HOLD_TTL"           envDefault:"15m"`
+	ReaperInterval  time.Duration `env:"BOOKING_REAPER_INTERVAL"    envDefault:"30s"`
+	ReaperBatchSize int           `env:"BOOKING_REAPER_BATCH_SIZE"  envDefault:"100"`
+	SeatMapTTL      time.Duration `env:"BOOKING_SEAT_MAP_TTL"       envDefault:"10m"`
+	OfferTTL        time.Duration `env:"BOOKING_WAITLIST_OFFER_TTL" envDefault:"30m"`
+}
+
+type IdempotencyConfig struct {
+	TTL     time.Duration `env:"IDEMPOTENCY_TTL"      envDefault:"24h"`
+	LockTTL time.Duration `env:"IDEMPOTENCY_LOCK_TTL" envDefault:"30s"`
+}
+
+type OutboxConfig struct {
+	RelayInterval time.Duration `env:"OUTBOX_RELAY_INTERVAL" envDefault:"1s"`
+	BatchSize     int           `env:"OUTBOX_BATCH_SIZE"     envDefault:"100"`
+	RetryBase     time.Duration `env:"OUTBOX_RETRY_BASE"     envDefault:"1s"`
+	RetryMax      time.Duration `env:"OUTBOX_RETRY_MAX"      envDefault:"5m"`
+	ClaimTimeout  time.Duration `env:"OUTBOX_CLAIM_TIMEOUT"  envDefault:"1m"`
+}
+
+type QueueConfig struct {
+	AdmitInterval time.Duration `env:"QUEUE_ADMIT_INTERVAL" envDefault:"5s"`
+	AdmissionTTL  time.Duration `env:"QUEUE_ADMISSION_TTL"  envDefault:"10m"`
+}
+
+type ConcertConfig struct {
+	CompleterInterval     time.Duration `env:"CONCERT_COMPLETER_INTERVAL"      envDefault:"1m"`
+	CompleterBatchSize    int           `env:"CONCERT_COMPLETER_BATCH_SIZE"    envDefault:"100"`
+	CancellationInterval  time.Duration `env:"CONCERT_CANCELLATION_INTERVAL"   envDefault:"5s"`
+	CancellationBatchSize int           `env:"CONCERT_CANCELLATION_BATCH_SIZE" envDefault:"500"`
+	AccessCodeSecret      string        `env:"CONCERT_ACCESS_CODE_SECRET"`
+}
+
+type PaymentConfig struct {
+	WebhookURL    string        `env:"PAYMENT_WEBHOOK_URL"    envDefault:"http://localhost:8080/api/payments/webhook"`
+	WebhookSecret string        `env:"PAYMENT_WEBHOOK_SECRET"`
+	FakeOutcome   string        `env:"PAYMENT_FAKE_OUTCOME"   envDefault:"succeed"`
+	FakeDelay     time.Duration `env:"PAYMENT_FAKE_DELAY"     envDefault:"2s"`
+}
+
+type RefundConfig struct {
+	FullWindow      time.Duration `env:"REFUND_FULL_WINDOW"      envDefault:"168h"`
+	PartialWindow   time.Duration `env:"REFUND_PARTIAL_WINDOW"   envDefault:"48h"`
+	PartialPercent  int64         `env:"REFUND_PARTIAL_PERCENT"  envDefault:"50"`
+	ProcessInterval time.Duration `env:"REFUND_PROCESS_INTERVAL" envDefault:"10s"`
+	BatchSize       int           `env:"REFUND_BATCH_SIZE"       envDefault:"50"`
+	ClaimTimeout    time.Duration `env:"REFUND_CLAIM_TIMEOUT"    envDefault:"5m"`
 }
 
 type PostgresConfig struct {
@@ -49,8 +108,17 @@ func (r *RedisConfig) Addr() string {
 }
 
 type KafkaConfig struct {
-	Brokers []string `env:"KAFKA_BROKERS" envDefault:"localhost:29092"`
-	Topic   string   `env:"KAFKA_TOPIC"   envDefault:"bookings.created"`
+	Brokers        []string `env:"KAFKA_BROKERS"         envDefault:"localhost:29092"`
+	Topic          string   `env:"KAFKA_TOPIC"           envDefault:"bookings.created"`
+	CancelledTopic string   `env:"KAFKA_CANCELLED_TOPIC" envDefault:"bookings.cancelled"`
+	ConcertTopic   string   `env:"KAFKA_CONCERT_TOPIC"   envDefault:"concerts.cancelled"`
+	WaitlistTopic  string   `env:"KAFKA_WAITLIST_TOPIC"  envDefault:"waitlist.offers"`
+}
+
+type FeeConfig struct {
+	ServiceFeePercent int64  `env:"FEE_SERVICE_PERCENT" envDefault:"10"`
+	VATPercent        int64  `env:"FEE_VAT_PERCENT"     envDefault:"20"`
+	VATBase           string `env:"FEE_VAT_BASE"        envDefault:"fee"`
 }
 
 func Load() (*Config, error) {
@@ -65,5 +133,45 @@ func Load() (*Config, error) {
 		return nil, errors.New("JWT_SECRET is required")
 	}
 
+	if cfg.Concert.AccessCodeSecret == "" {
+		return nil, errors.New("CONCERT_ACCESS_CODE_SECRET is required")
+	}
+
+	if cfg.Payment.WebhookSecret == "" {
+		return nil, errors.New("PAYMENT_WEBHOOK_SECRET is required")
+	}
+
+	if cfg.Payment.FakeOutcome != "succeed" && cfg.Payment.FakeOutcome != "decline" {
+		return nil, fmt.Errorf("PAYMENT_FAKE_OUTCOME must be succeed or decline, got %q", cfg.Payment.FakeOutcome)
+	}
+
+	if cfg.Refund.PartialWindow > cfg.Refund.FullWindow {
+		return nil, errors.New("REFUND_PARTIAL_WINDOW cannot exceed REFUND_FULL_WINDOW")
+	}
+
+	if cfg.Refund.PartialPercent < 0 || cfg.Refund.PartialPercent > 100 {
+		return nil, errors.New("REFUND_PARTIAL_PERCENT must be between 0 and 100")
+	}
+
+	if cfg.Outbox.ClaimTimeout <= 0 {
+		return nil, errors.New("OUTBOX_CLAIM_TIMEOUT must be positive")
+	}
+
+	if cfg.Idempotency.LockTTL <= 0 {
+		return nil, errors.New("IDEMPOTENCY_LOCK_TTL must be positive")
+	}
+
+	if cfg.Fee.ServiceFeePercent < 0 || cfg.Fee.ServiceFeePercent > 100 {
+		return nil, errors.New("FEE_SERVICE_PERCENT must be between 0 and 100")
+	}
+
+	if cfg.Fee.VATPercent < 0 || cfg.Fee.VATPercent > 100 {
+		return nil, errors.New("FEE_VAT_PERCENT must be between 0 and 100")
+	}
+
+	if cfg.Fee.VATBase != "fee" && cfg.Fee.VATBase != "total" {
+		return nil, errors.New("FEE_VAT_BASE must be fee or total")
+	}
+
 	return cfg, nil
 }
@@ -1,5 +1,13 @@
 package dto
 
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type RegisterRequest struct {
 	Email    string `json:"email"    validate:"required,email"`
 	Password string `json:"password" validate:"required"`
@@ -10,7 +18,112 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 type CreateBookingRequest struct {
-	ConcertID string `json:"concert_id" validate:"required,uuid"`
-	Seat      int    `json:"seat"       validate:"required,min=1"`
+	ConcertID  string      `json:"concert_id"  validate:"required,uuid"`
+	TierID     *uuid.UUID  `json:"tier_id"`
+	Seat       int         `json:"seat"        validate:"excluded_with=Seats,omitempty,min=1"`
+	Seats      []int       `json:"seats"       validate:"omitempty,min=1,max=10,unique,dive,min=1"`
+	SeatIDs    []uuid.UUID `json:"seat_ids"    validate:"excluded_with=Seat Seats,omitempty,min=1,max=10,unique"`
+	Quantity   int         `json:"quantity"    validate:"excluded_with=Seat Seats SeatIDs,omitempty,min=1,max=10"`
+	AccessCode string      `json:"access_code" validate:"omitempty,max=64"`
+	PromoCode  string      `json:"promo_code"  validate:"omitempty,max=32"`
+}
+
+type ConcertRequest struct {
+	Name         string     `json:"name"        validate:"required"`
+	VenueID      uuid.UUID  `json:"venue_id"    validate:"required"`
+	Date         time.Time  `json:"date"        validate:"required"`
+	Price        int64      `json:"price"       validate:"required,gt=0"`
+	Currency     string     `json:"currency"    validate:"required,iso4217"`
+	TotalSeats   int        `json:"total_seats" validate:"required,min=1"`
+	SaleStartsAt *time.Time `json:"sale_starts_at"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at"`
+}
+
+type PatchConcertRequest struct {
+	Name         *string      `json:"name"        validate:"omitempty,min=1"`
+	VenueID      *uuid.UUID   `json:"venue_id"`
+	Date         *time.Time   `json:"date"`
+	Price        *int64       `json:"price"       validate:"omitempty,gt=0"`
+	Currency     *string      `json:"currency"    validate:"omitempty,iso4217"`
+	TotalSeats   *int         `json:"total_seats" validate:"omitempty,min=1"`
+	SaleStartsAt NullableTime `json:"sale_starts_at"`
+	SaleEndsAt   NullableTime `json:"sale_ends_at"`
+}
+
+type NullableTime struct {
+	Set   bool
+	Value *time.Time
+}
+
+func (t *NullableTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		t.Value = nil
+		return nil
+	}
+	return json.Unmarshal(data, &t.Value)
+}
+
+type VenueRequest struct {
+	Name     string `json:"name"     validate:"required"`
+	Address  string `json:"address"`
+	Timezone string `json:"timezone"`
+}
+
+type VenueSectionRequest struct {
+	Name string            `json:"name" validate:"required"`
+	Rows []VenueRowRequest `json:"rows" validate:"required,min=1,max=200,dive"`
+}
+
+type VenueRowRequest struct {
+	Label string `json:"label" validate:"required"`
+	Seats int    `json:"seats" validate:"required,min=1,max=500"`
+}
+
+type TicketTierRequest struct {
+	Name       string      `json:"name"        validate:"required"`
+	Price      int64       `json:"price"       validate:"required,gt=0"`
+	Quota      int         `json:"quota"       validate:"required,min=1"`
+	SectionIDs []uuid.UUID `json:"section_ids" validate:"required,min=1,unique"`
+}
+
+type WaitingRoomRequest struct {
+	AdmitRate int `json:"admit_rate" validate:"required,min=1"`
+}
+
+type ConcertStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=DRAFT ON_SALE SOLD_OUT CANCELLED COMPLETED"`
+}
+
+type CancelConcertRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type PresaleRequest struct {
+	Name       string      `json:"name"        validate:"required"`
+	StartsAt   time.Time   `json:"starts_at"   validate:"required"`
+	EndsAt     time.Time   `json:"ends_at"     validate:"required,gtfield=StartsAt"`
+	AccessCode string      `json:"access_code" validate:"required_without=UserIDs,omitempty,min=4,max=64"`
+	UserIDs    []uuid.UUID `json:"user_ids"    validate:"required_without=AccessCode,omitempty,max=10000,unique"`
+}
+
+type PromoCodeRequest struct {
+	Code           string     `json:"code"              validate:"required,min=3,max=32"`
+	ConcertID      *uuid.UUID `json:"concert_id"`
+	DiscountType   string     `json:"discount_type"     validate:"required,oneof=PERCENT FIXED"`
+	Value          int64      `json:"value"             validate:"required,gt=0"`
+	Currency       string     `json:"currency"          validate:"required_if=DiscountType FIXED,omitempty,iso4217"`
+	MaxUses        *int       `json:"max_uses"          validate:"omitempty,min=1"`
+	MaxUsesPerUser *int       `json:"max_uses_per_user" validate:"omitempty,min=1"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
 }
@@ -1,9 +1,54 @@
 package event
 
+import "time"
+
+const (
+	TypeBookingCreated   = "booking.created"
+	TypeBookingCancelled = "booking.cancelled"
+	TypeConcertCancelled = "concert.cancelled"
+	TypeWaitlistOffered  = "waitlist.offered"
+)
+
 type BookingCreatedEvent struct {
-	BookingID string  `json:"booking_id"`
-	UserID    string  `json:"user_id"`
-	ConcertID string  `json:"concert_id"`
-	Seat      int     `json:"seat"`
-	Amount    float64 `json:"amount"`
+	BookingID  string   `json:"booking_id"`
+	BookingIDs []string `json:"booking_ids"`
+	UserID     string   `json:"user_id"`
+	ConcertID  string   `json:"concert_id"`
+	Seat       int      `json:"seat"`
+	Seats      []int    `json:"seats"`
+	Amount     int64    `json:"amount"`
+	Currency   string   `json:"currency"`
+	FaceValue  int64    `json:"face_value"`
+	Discount   int64    `json:"discount,omitempty"`
+	ServiceFee int64    `json:"service_fee"`
+	VAT        int64    `json:"vat"`
+	PromoCode  string   `json:"promo_code,omitempty"`
+}
+
+type BookingCancelledEvent struct {
+	BookingID string `json:"booking_id"`
+	UserID    string `json:"user_id"`
+	ConcertID string `json:"concert_id"`
+	Seat      int    `json:"seat"`
+}
+
+type ConcertCancelledEvent struct {
+	ConcertID    string   `json:"concert_id"`
+	UserID       string   `json:"user_id"`
+	Reason       string   `json:"reason"`
+	BookingIDs   []string `json:"booking_ids"`
+	Seats        []int    `json:"seats"`
+	RefundAmount int64    `json:"refund_amount"`
+	Currency     string   `json:"currency"`
+}
+
+type WaitlistOfferedEvent struct {
+	EntryID   string    `json:"entry_id"`
+	BookingID string    `json:"booking_id"`
+	UserID    string    `json:"user_id"`
+	ConcertID string    `json:"concert_id"`
+	Seat      int       `json:"seat"`
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
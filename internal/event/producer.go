@@ -2,7 +2,6 @@ package event
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -10,35 +9,37 @@ import (
 )
 
 type EventProducer interface {
-	SendBookingCreated(ctx context.Context, event BookingCreatedEvent) error
+	Publish(ctx context.Context, eventType, key string, payload []byte) error
 	Close() error
 }
 
 type KafkaProducer struct {
 	writer *kafka.Writer
+	topics map[string]string
 }
 
-func NewKafkaProducer(brokers []string, topic string) *KafkaProducer {
+func NewKafkaProducer(brokers []string, topics map[string]string) *KafkaProducer {
 	return &KafkaProducer{
 		writer: &kafka.Writer{
 			Addr:         kafka.TCP(brokers...),
-			Topic:        topic,
 			Balancer:     &kafka.LeastBytes{},
 			RequiredAcks: kafka.RequireOne,
 			WriteTimeout: 5 * time.Second,
 			ReadTimeout:  5 * time.Second,
 		},
+		topics: topics,
 	}
 }
 
-func (p *KafkaProducer) SendBookingCreated(ctx context.Context, event BookingCreatedEvent) error {
-	payload, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("marshal event: %w", err)
+func (p *KafkaProducer) Publish(ctx context.Context, eventType, key string, payload []byte) error {
+	topic, ok := p.topics[eventType]
+	if !ok {
+		return fmt.Errorf("no topic configured for event type %q", eventType)
 	}
 
 	msg := kafka.Message{
-		Key:   []byte(event.BookingID),
+		Topic: topic,
+		Key:   []byte(key),
 		Value: payload,
 		Time:  time.Now(),
 	}
@@ -6,13 +6,46 @@ import (
 )
 
 const (
-	ErrCodeInvalidFormat    = "INVALID_FORMAT"
-	ErrCodeInternal         = "INTERNAL_ERROR"
-	ErrCodeNotFound         = "NOT_FOUND"
-	ErrCodeValidationFailed = "VALIDATION_FAILED"
-	ErrCodeUnauthorized     = "UNAUTHORIZED"
-	ErrCodeAlreadyExists    = "ALREADY_EXISTS"
-	ErrCodeNoSeats          = "NO_SEATS"
+	ErrCodeInvalidFormat          = "INVALID_FORMAT"
+	ErrCodeInternal               = "INTERNAL_ERROR"
+	ErrCodeNotFound               = "NOT_FOUND"
+	ErrCodeValidationFailed       = "VALIDATION_FAILED"
+	ErrCodeUnauthorized           = "UNAUTHORIZED"
+	ErrCodeAlreadyExists          = "ALREADY_EXISTS"
+	ErrCodeNoSeats                = "NO_SEATS"
+	ErrCodeForbidden              = "FORBIDDEN"
+	ErrCodeAlreadyCancelled       = "ALREADY_CANCELLED"
+	ErrCodeBookingChanged         = "BOOKING_CHANGED"
+	ErrCodeNotPending             = "NOT_PENDING"
+	ErrCodeHoldExpired            = "HOLD_EXPIRED"
+	ErrCodeInvalidSeat            = "INVALID_SEAT"
+	ErrCodeConcertPassed          = "CONCERT_PASSED"
+	ErrCodeSeatsBelowBooked       = "SEATS_BELOW_BOOKED"
+	ErrCodeConcertHasBookings     = "CONCERT_HAS_BOOKINGS"
+	ErrCodeIdempotencyKeyReused   = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeRequestInProgress      = "REQUEST_IN_PROGRESS"
+	ErrCodeRequestTooLarge        = "REQUEST_TOO_LARGE"
+	ErrCodeInvalidCursor          = "INVALID_CURSOR"
+	ErrCodeInvalidTier            = "INVALID_TIER"
+	ErrCodeQueueNotActive         = "QUEUE_NOT_ACTIVE"
+	ErrCodeQueueAdmissionRequired = "QUEUE_ADMISSION_REQUIRED"
+	ErrCodeSaleNotStarted         = "SALE_NOT_STARTED"
+	ErrCodeSaleEnded              = "SALE_ENDED"
+	ErrCodePresaleAccessRequired  = "PRESALE_ACCESS_REQUIRED"
+	ErrCodeInvalidAccessCode      = "INVALID_ACCESS_CODE"
+	ErrCodeConcertNotOnSale       = "CONCERT_NOT_ON_SALE"
+	ErrCodeConcertPublished       = "CONCERT_PUBLISHED"
+	ErrCodeInvalidTransition      = "INVALID_STATUS_TRANSITION"
+	ErrCodeWaitlistNotOpen        = "WAITLIST_NOT_OPEN"
+	ErrCodePaymentProvider        = "PAYMENT_PROVIDER_ERROR"
+	ErrCodeInvalidWebhook         = "INVALID_WEBHOOK"
+	ErrCodeNotRefundable          = "NOT_REFUNDABLE"
+	ErrCodeRefundRequired         = "REFUND_REQUIRED"
+	ErrCodeRefundWindowClosed     = "REFUND_WINDOW_CLOSED"
+	ErrCodeRefundNotFailed        = "REFUND_NOT_FAILED"
+	ErrCodePromoNotApplicable     = "PROMO_CODE_NOT_APPLICABLE"
+	ErrCodePromoExhausted         = "PROMO_CODE_EXHAUSTED"
+	ErrCodePromoUserLimit         = "PROMO_CODE_USER_LIMIT"
 )
 
 type ErrorResponse struct {
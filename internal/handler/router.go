@@ -2,36 +2,69 @@ package handler
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/yohnnn/booking_service/internal/cache"
 	v1 "github.com/yohnnn/booking_service/internal/handler/v1"
 	mw "github.com/yohnnn/booking_service/internal/middleware"
+	"github.com/yohnnn/booking_service/internal/models"
 	"github.com/yohnnn/booking_service/internal/service"
 )
 
 type Router struct {
-	logger         *slog.Logger
-	authService    service.Auth
-	authHandler    *v1.AuthHandler
-	concertHandler *v1.ConcertHandler
-	bookingHandler *v1.BookingHandler
+	logger              *slog.Logger
+	authService         service.Auth
+	idempotencyCache    cache.IdempotencyCacheRepository
+	idempotencyLockTTL  time.Duration
+	authHandler         *v1.AuthHandler
+	concertHandler      *v1.ConcertHandler
+	venueHandler        *v1.VenueHandler
+	bookingHandler      *v1.BookingHandler
+	waitingRoomHandler  *v1.WaitingRoomHandler
+	cancellationHandler *v1.CancellationHandler
+	waitlistHandler     *v1.WaitlistHandler
+	paymentHandler      *v1.PaymentHandler
+	refundHandler       *v1.RefundHandler
+	ledgerHandler       *v1.LedgerHandler
+	promoCodeHandler    *v1.PromoCodeHandler
 }
 
 func NewRouter(
 	logger *slog.Logger,
 	authService service.Auth,
+	idempotencyCache cache.IdempotencyCacheRepository,
+	idempotencyLockTTL time.Duration,
 	authHandler *v1.AuthHandler,
 	concertHandler *v1.ConcertHandler,
+	venueHandler *v1.VenueHandler,
 	bookingHandler *v1.BookingHandler,
+	waitingRoomHandler *v1.WaitingRoomHandler,
+	cancellationHandler *v1.CancellationHandler,
+	waitlistHandler *v1.WaitlistHandler,
+	paymentHandler *v1.PaymentHandler,
+	refundHandler *v1.RefundHandler,
+	ledgerHandler *v1.LedgerHandler,
+	promoCodeHandler *v1.PromoCodeHandler,
 ) *Router {
 	return &Router{
-		logger:         logger,
-		authService:    authService,
-		authHandler:    authHandler,
-		concertHandler: concertHandler,
-		bookingHandler: bookingHandler,
+		logger:              logger,
+		authService:         authService,
+		idempotencyCache:    idempotencyCache,
+		idempotencyLockTTL:  idempotencyLockTTL,
+		authHandler:         authHandler,
+		concertHandler:      concertHandler,
+		venueHandler:        venueHandler,
+		bookingHandler:      bookingHandler,
+		waitingRoomHandler:  waitingRoomHandler,
+		cancellationHandler: cancellationHandler,
+		waitlistHandler:     waitlistHandler,
+		paymentHandler:      paymentHandler,
+		refundHandler:       refundHandler,
+		ledgerHandler:       ledgerHandler,
+		promoCodeHandler:    promoCodeHandler,
 	}
 }
 
@@ -47,15 +80,61 @@ func (r *Router) InitRoutes() *chi.Mux {
 		mr.Group(func(ar chi.Router) {
 			ar.Post("/auth/register", r.authHandler.Register)
 			ar.Post("/auth/login", r.authHandler.Login)
+			ar.Post("/auth/refresh", r.authHandler.Refresh)
+			ar.With(mw.Auth(r.logger, r.authService)).Post("/auth/logout", r.authHandler.Logout)
 		})
 
-		mr.Get("/concerts", r.concertHandler.GetAll)
-		mr.Get("/concerts/{id}", r.concertHandler.GetByID)
+		mr.With(mw.OptionalAuth(r.logger, r.authService)).Get("/concerts", r.concertHandler.List)
+		mr.With(mw.OptionalAuth(r.logger, r.authService)).Get("/concerts/{id}", r.concertHandler.GetByID)
+		mr.With(mw.OptionalAuth(r.logger, r.authService)).Get("/concerts/{id}/tiers", r.concertHandler.GetTiers)
+		mr.With(mw.OptionalAuth(r.logger, r.authService)).Get("/concerts/{id}/seats", r.bookingHandler.GetSeatMap)
+		mr.Get("/venues", r.venueHandler.GetAll)
+		mr.Get("/venues/{id}", r.venueHandler.GetByID)
+		mr.Post("/payments/webhook", r.paymentHandler.Webhook)
+
+		mr.Group(func(adm chi.Router) {
+			adm.Use(mw.Auth(r.logger, r.authService))
+			adm.Use(mw.RequireRole(models.UserRoleAdmin))
+			adm.Post("/concerts", r.concertHandler.Create)
+			adm.Put("/concerts/{id}", r.concertHandler.Update)
+			adm.Patch("/concerts/{id}", r.concertHandler.Patch)
+			adm.Delete("/concerts/{id}", r.concertHandler.Delete)
+			adm.Post("/concerts/{id}/status", r.concertHandler.ChangeStatus)
+			adm.Post("/concerts/{id}/cancel", r.cancellationHandler.Cancel)
+			adm.Get("/concerts/{id}/cancellation", r.cancellationHandler.Get)
+			adm.Get("/concerts/{id}/refunds", r.refundHandler.GetConcertRefunds)
+			adm.Get("/concerts/{id}/ledger", r.ledgerHandler.ConcertReport)
+			adm.Post("/concerts/{id}/tiers", r.concertHandler.AddTier)
+			adm.Get("/concerts/{id}/presales", r.concertHandler.GetPresales)
+			adm.Post("/concerts/{id}/presales", r.concertHandler.AddPresale)
+			adm.Put("/concerts/{id}/queue", r.waitingRoomHandler.Open)
+			adm.Delete("/concerts/{id}/queue", r.waitingRoomHandler.Close)
+			adm.Post("/venues", r.venueHandler.Create)
+			adm.Post("/venues/{id}/sections", r.venueHandler.AddSection)
+			adm.Post("/refunds/{id}/retry", r.refundHandler.Retry)
+			adm.Get("/ledger/check", r.ledgerHandler.Check)
+			adm.Post("/promo-codes", r.promoCodeHandler.Create)
+			adm.Get("/promo-codes", r.promoCodeHandler.GetAll)
+			adm.Get("/promo-codes/{id}", r.promoCodeHandler.GetByID)
+			adm.Delete("/promo-codes/{id}", r.promoCodeHandler.Deactivate)
+		})
 
 		mr.Group(func(pr chi.Router) {
-			pr.Use(mw.Auth(r.authService))
-			pr.Post("/bookings", r.bookingHandler.Create)
+			pr.Use(mw.Auth(r.logger, r.authService))
+			pr.With(mw.Idempotency(r.logger, r.idempotencyCache, r.idempotencyLockTTL)).
+				Post("/bookings", r.bookingHandler.Create)
 			pr.Get("/bookings", r.bookingHandler.GetUserBookings)
+			pr.Delete("/bookings/{id}", r.bookingHandler.Cancel)
+			pr.Post("/bookings/{id}/payment", r.paymentHandler.Create)
+			pr.Get("/payments/{id}", r.paymentHandler.Get)
+			pr.Post("/bookings/{id}/refund", r.refundHandler.Create)
+			pr.Get("/refunds", r.refundHandler.GetUserRefunds)
+			pr.Get("/refunds/{id}", r.refundHandler.Get)
+			pr.Post("/concerts/{id}/queue/join", r.waitingRoomHandler.Join)
+			pr.Get("/concerts/{id}/queue/me", r.waitingRoomHandler.Status)
+			pr.Post("/concerts/{id}/waitlist", r.waitlistHandler.Join)
+			pr.Get("/concerts/{id}/waitlist/me", r.waitlistHandler.Get)
+			pr.Delete("/concerts/{id}/waitlist/me", r.waitlistHandler.Leave)
 		})
 	})
 
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/yohnnn/booking_service/internal/dto"
 	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/middleware"
 	"github.com/yohnnn/booking_service/internal/models"
 	"github.com/yohnnn/booking_service/internal/service"
 )
@@ -82,7 +84,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.service.Login(r.Context(), input.Email, input.Password)
+	tokens, err := h.service.Login(r.Context(), input.Email, input.Password)
 	if err != nil {
 		if errors.Is(err, models.ErrNotFound) || errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
 			h.logger.Warn("invalid credentials", "email", input.Email)
@@ -104,7 +106,68 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.WriteJSONResponse(w, http.StatusOK, map[string]string{
-		"token": token,
-	})
+	response.WriteJSONResponse(w, http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var input dto.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	tokens, err := h.service.Refresh(r.Context(), input.RefreshToken)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidToken) || errors.Is(err, models.ErrTokenReused) {
+			h.logger.Warn("refresh rejected", "error", err)
+			response.WriteErrorResponse(w, http.StatusUnauthorized, response.ErrCodeUnauthorized, err.Error())
+			return
+		}
+		h.logger.Error("failed to refresh tokens", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(middleware.ClaimsKey).(*models.TokenClaims)
+	if !ok {
+		h.logger.Error("token claims not found in context")
+		response.WriteErrorResponse(w, http.StatusUnauthorized, response.ErrCodeUnauthorized, "unauthorized")
+		return
+	}
+
+	var input dto.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), claims, input.RefreshToken); err != nil {
+		h.logger.Error("failed to logout", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
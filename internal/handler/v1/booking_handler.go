@@ -1,11 +1,13 @@
 package v1
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 
@@ -16,6 +18,8 @@ import (
 	"github.com/yohnnn/booking_service/internal/service"
 )
 
+const QueueTokenHeader = "X-Queue-Token"
+
 type BookingHandler struct {
 	logger    *slog.Logger
 	validator *validator.Validate
@@ -58,9 +62,72 @@ func (h *BookingHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	booking, err := h.service.CreateBooking(r.Context(), userID, concertID, input.Seat)
+	seats := input.Seats
+	if len(seats) == 0 && input.Seat > 0 {
+		seats = []int{input.Seat}
+	}
+
+	bookings, err := h.service.CreateBookings(r.Context(), userID, models.BookingRequest{
+		ConcertID:  concertID,
+		TierID:     input.TierID,
+		Seats:      seats,
+		SeatIDs:    input.SeatIDs,
+		Quantity:   input.Quantity,
+		QueueToken: r.Header.Get(QueueTokenHeader),
+		AccessCode: input.AccessCode,
+		PromoCode:  input.PromoCode,
+	})
 	if err != nil {
 		switch {
+		case errors.Is(err, models.ErrNotFound):
+			h.logger.Warn("concert not found", "concert_id", concertID)
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+		case errors.Is(err, models.ErrInvalidSeat):
+			h.logger.Warn("seat out of range", "concert_id", concertID, "seats", seats)
+			response.WriteErrorResponse(
+				w,
+				http.StatusBadRequest,
+				response.ErrCodeInvalidSeat,
+				"seat number is out of range for this concert",
+			)
+		case errors.Is(err, models.ErrInvalidTier), errors.Is(err, models.ErrSeatNotInTier):
+			h.logger.Warn("invalid ticket tier", "concert_id", concertID, "error", err)
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidTier, err.Error())
+		case errors.Is(err, models.ErrNotAdmitted):
+			h.logger.Warn("booking without waiting room admission", "concert_id", concertID)
+			response.WriteErrorResponse(
+				w,
+				http.StatusForbidden,
+				response.ErrCodeQueueAdmissionRequired,
+				"valid waiting room admission token is required",
+			)
+		case errors.Is(err, models.ErrSaleNotStarted):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeSaleNotStarted, err.Error())
+		case errors.Is(err, models.ErrSaleEnded):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeSaleEnded, err.Error())
+		case errors.Is(err, models.ErrPresaleAccessRequired):
+			response.WriteErrorResponse(w, http.StatusForbidden, response.ErrCodePresaleAccessRequired, err.Error())
+		case errors.Is(err, models.ErrInvalidAccessCode):
+			h.logger.Warn("invalid presale access code", "concert_id", concertID, "user_id", userID)
+			response.WriteErrorResponse(w, http.StatusForbidden, response.ErrCodeInvalidAccessCode, err.Error())
+		case errors.Is(err, models.ErrPromoCodeNotApplicable):
+			h.logger.Warn("promo code not applicable", "concert_id", concertID, "error", err)
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodePromoNotApplicable, err.Error())
+		case errors.Is(err, models.ErrPromoCodeExhausted):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodePromoExhausted, err.Error())
+		case errors.Is(err, models.ErrPromoCodeUserLimit):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodePromoUserLimit, err.Error())
+		case errors.Is(err, models.ErrConcertNotOnSale):
+			h.logger.Warn("concert not on sale", "concert_id", concertID, "error", err)
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeConcertNotOnSale, err.Error())
+		case errors.Is(err, models.ErrConcertPassed):
+			h.logger.Warn("concert already passed", "concert_id", concertID)
+			response.WriteErrorResponse(
+				w,
+				http.StatusConflict,
+				response.ErrCodeConcertPassed,
+				"concert has already taken place",
+			)
 		case errors.Is(err, models.ErrNoSeats):
 			h.logger.Warn("no seats available", "concert_id", concertID)
 			response.WriteErrorResponse(
@@ -70,12 +137,12 @@ func (h *BookingHandler) Create(w http.ResponseWriter, r *http.Request) {
 				"no seats available for this concert",
 			)
 		case errors.Is(err, models.ErrAlreadyExists):
-			h.logger.Warn("booking already exists", "concert_id", concertID, "seat", input.Seat)
+			h.logger.Warn("booking already exists", "concert_id", concertID, "seats", seats)
 			response.WriteErrorResponse(
 				w,
 				http.StatusConflict,
 				response.ErrCodeAlreadyExists,
-				"this seat is already booked",
+				"one of the requested seats is already booked",
 			)
 		default:
 			h.logger.Error("failed to create booking", "error", err)
@@ -89,8 +156,14 @@ func (h *BookingHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.WriteJSONResponse(w, http.StatusCreated, booking)
+	if input.Seat > 0 || (len(input.Seats) == 0 && len(input.SeatIDs) == 0 && input.Quantity == 0) {
+		response.WriteJSONResponse(w, http.StatusCreated, bookings[0])
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, bookings)
 }
+
 func (h *BookingHandler) GetUserBookings(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value(middleware.UserIDKey).(uuid.UUID)
 	if !ok {
@@ -113,3 +186,120 @@ func (h *BookingHandler) GetUserBookings(w http.ResponseWriter, r *http.Request)
 
 	response.WriteJSONResponse(w, http.StatusOK, bookings)
 }
+
+func (h *BookingHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Error("user id not found in context")
+		response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal error")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	bookingID, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid booking id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid booking id")
+		return
+	}
+
+	booking, err := h.service.CancelBooking(r.Context(), userID, bookingID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			h.logger.Warn("booking not found", "booking_id", bookingID)
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "booking not found")
+		case errors.Is(err, models.ErrForbidden):
+			h.logger.Warn("booking belongs to another user", "booking_id", bookingID, "user_id", userID)
+			response.WriteErrorResponse(
+				w,
+				http.StatusForbidden,
+				response.ErrCodeForbidden,
+				"booking belongs to another user",
+			)
+		case errors.Is(err, models.ErrBookingCancelled):
+			h.logger.Warn("booking already cancelled", "booking_id", bookingID)
+			response.WriteErrorResponse(
+				w,
+				http.StatusConflict,
+				response.ErrCodeAlreadyCancelled,
+				"booking is already cancelled",
+			)
+		case errors.Is(err, models.ErrBookingChanged):
+			h.logger.Warn("booking changed during cancellation", "booking_id", bookingID)
+			response.WriteErrorResponse(
+				w,
+				http.StatusConflict,
+				response.ErrCodeBookingChanged,
+				"booking status changed, reload it and try again",
+			)
+		case errors.Is(err, models.ErrRefundRequired):
+			h.logger.Warn("confirmed booking cannot be cancelled", "booking_id", bookingID)
+			response.WriteErrorResponse(
+				w,
+				http.StatusConflict,
+				response.ErrCodeRefundRequired,
+				"booking is paid, request a refund via POST /api/bookings/{id}/refund",
+			)
+		default:
+			h.logger.Error("failed to cancel booking", "error", err)
+			response.WriteErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				response.ErrCodeInternal,
+				"internal server error",
+			)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, booking)
+}
+
+func (h *BookingHandler) GetSeatMap(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	concertID, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "ranges" && format != "bitmap" {
+		response.WriteErrorResponse(
+			w,
+			http.StatusBadRequest,
+			response.ErrCodeInvalidFormat,
+			"format must be ranges or bitmap",
+		)
+		return
+	}
+
+	seatMap, err := h.service.GetSeatMap(r.Context(), concertID, isAdmin(r))
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			h.logger.Warn("concert not found", "concert_id", concertID)
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+			return
+		}
+		h.logger.Error("failed to get seat map", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+		return
+	}
+
+	if format == "bitmap" {
+		seatMap.Bitmap = base64.StdEncoding.EncodeToString(seatMap.Seats)
+	} else {
+		seatMap.Free = seatMap.Seats.Ranges(seatMap.TotalSeats, models.SeatFree)
+		seatMap.Held = seatMap.Seats.Ranges(seatMap.TotalSeats, models.SeatHeld)
+		seatMap.Sold = seatMap.Seats.Ranges(seatMap.TotalSeats, models.SeatSold)
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, seatMap)
+}
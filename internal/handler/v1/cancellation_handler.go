@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/dto"
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+type CancellationHandler struct {
+	logger    *slog.Logger
+	validator *validator.Validate
+	service   service.ConcertCancellation
+}
+
+func NewCancellationHandler(
+	logger *slog.Logger,
+	validator *validator.Validate,
+	service service.ConcertCancellation,
+) *CancellationHandler {
+	return &CancellationHandler{
+		logger:    logger,
+		validator: validator,
+		service:   service,
+	}
+}
+
+func (h *CancellationHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	concertID, ok := h.concertID(w, r)
+	if !ok {
+		return
+	}
+
+	var input dto.CancelConcertRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	cancellation, err := h.service.Cancel(r.Context(), concertID, input.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+		case errors.Is(err, models.ErrInvalidStatusTransition):
+			h.logger.Warn("concert cannot be cancelled", "concert_id", concertID, "error", err)
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeInvalidTransition, err.Error())
+		default:
+			h.writeInternalError(w, "failed to cancel concert", err)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusAccepted, cancellation)
+}
+
+func (h *CancellationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	concertID, ok := h.concertID(w, r)
+	if !ok {
+		return
+	}
+
+	cancellation, err := h.service.Get(r.Context(), concertID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert is not cancelled")
+			return
+		}
+		h.writeInternalError(w, "failed to get concert cancellation", err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, cancellation)
+}
+
+func (h *CancellationHandler) concertID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *CancellationHandler) writeInternalError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+	response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal server error")
+}
@@ -1,39 +1,74 @@
 package v1
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 
+	"github.com/yohnnn/booking_service/internal/dto"
 	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/middleware"
 	"github.com/yohnnn/booking_service/internal/models"
 	"github.com/yohnnn/booking_service/internal/service"
 )
 
 type ConcertHandler struct {
-	logger  *slog.Logger
-	service service.Concert
+	logger    *slog.Logger
+	validator *validator.Validate
+	service   service.Concert
 }
 
-func NewConcertHandler(logger *slog.Logger, service service.Concert) *ConcertHandler {
+func NewConcertHandler(logger *slog.Logger, validator *validator.Validate, service service.Concert) *ConcertHandler {
 	return &ConcertHandler{
-		logger:  logger,
-		service: service,
+		logger:    logger,
+		validator: validator,
+		service:   service,
 	}
 }
 
-func (h *ConcertHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	concerts, err := h.service.GetAll(r.Context())
+func (h *ConcertHandler) List(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseConcertFilter(r.URL.Query())
 	if err != nil {
-		h.logger.Error("failed to get concerts", "error", err)
-		response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, err.Error())
+		h.logger.Warn("invalid concert filter", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, err.Error())
 		return
 	}
+	filter.ShowDrafts = isAdmin(r)
 
-	response.WriteJSONResponse(w, http.StatusOK, concerts)
+	page, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidFilter):
+			h.logger.Warn("invalid concert filter", "error", err)
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		case errors.Is(err, models.ErrInvalidCursor):
+			h.logger.Warn("invalid cursor", "error", err)
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidCursor, err.Error())
+		case errors.Is(err, models.ErrForbidden):
+			response.WriteErrorResponse(w, http.StatusForbidden, response.ErrCodeForbidden, "draft concerts are hidden")
+		default:
+			h.logger.Error("failed to get concerts", "error", err)
+			response.WriteErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				response.ErrCodeInternal,
+				"internal server error",
+			)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, page)
 }
 
 func (h *ConcertHandler) GetByID(w http.ResponseWriter, r *http.Request) {
@@ -62,5 +97,472 @@ func (h *ConcertHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if concert.Status == models.ConcertStatusDraft && !isAdmin(r) {
+		response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, concert)
+}
+
+func (h *ConcertHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.ConcertRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	concert, err := h.service.Create(r.Context(), models.Concert{
+		Name:         input.Name,
+		VenueID:      input.VenueID,
+		Date:         input.Date,
+		Price:        models.NewMoney(input.Price, input.Currency),
+		TotalSeats:   input.TotalSeats,
+		SaleStartsAt: input.SaleStartsAt,
+		SaleEndsAt:   input.SaleEndsAt,
+	})
+	if err != nil {
+		h.writeMutationError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, concert)
+}
+
+func (h *ConcertHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	var input dto.ConcertRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	concert, err := h.service.Update(r.Context(), id, models.Concert{
+		Name:         input.Name,
+		VenueID:      input.VenueID,
+		Date:         input.Date,
+		Price:        models.NewMoney(input.Price, input.Currency),
+		TotalSeats:   input.TotalSeats,
+		SaleStartsAt: input.SaleStartsAt,
+		SaleEndsAt:   input.SaleEndsAt,
+	})
+	if err != nil {
+		h.writeMutationError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, concert)
+}
+
+func (h *ConcertHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	var input dto.PatchConcertRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	concert, err := h.service.Patch(r.Context(), id, models.ConcertPatch{
+		Name:              input.Name,
+		VenueID:           input.VenueID,
+		Date:              input.Date,
+		Price:             input.Price,
+		Currency:          input.Currency,
+		TotalSeats:        input.TotalSeats,
+		SaleStartsAt:      input.SaleStartsAt.Value,
+		SaleEndsAt:        input.SaleEndsAt.Value,
+		ClearSaleStartsAt: input.SaleStartsAt.Set && input.SaleStartsAt.Value == nil,
+		ClearSaleEndsAt:   input.SaleEndsAt.Set && input.SaleEndsAt.Value == nil,
+	})
+	if err != nil {
+		h.writeMutationError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, concert)
+}
+
+func (h *ConcertHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		h.writeMutationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ConcertHandler) GetTiers(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	tiers, err := h.service.GetTiers(r.Context(), id, isAdmin(r))
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			h.logger.Warn("concert not found", "id", id)
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+			return
+		}
+		h.logger.Error("failed to get ticket tiers", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, tiers)
+}
+
+func (h *ConcertHandler) AddTier(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	var input dto.TicketTierRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	tier, err := h.service.AddTier(r.Context(), id, models.TicketTier{
+		Name:       input.Name,
+		Price:      models.Money{Amount: input.Price},
+		Quota:      input.Quota,
+		SectionIDs: input.SectionIDs,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+		case errors.Is(err, models.ErrInvalidTier), errors.Is(err, models.ErrTierQuotaExceeded):
+			h.logger.Warn("invalid ticket tier", "error", err)
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidTier, err.Error())
+		case errors.Is(err, models.ErrAlreadyExists), errors.Is(err, models.ErrTierSectionInUse):
+			h.logger.Warn("ticket tier conflict", "error", err)
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeAlreadyExists, err.Error())
+		default:
+			h.logger.Error("failed to create ticket tier", "error", err)
+			response.WriteErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				response.ErrCodeInternal,
+				"internal server error",
+			)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, tier)
+}
+
+func (h *ConcertHandler) GetPresales(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	presales, err := h.service.GetPresales(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			h.logger.Warn("concert not found", "id", id)
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+			return
+		}
+		h.logger.Error("failed to get presales", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, presales)
+}
+
+func (h *ConcertHandler) AddPresale(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	var input dto.PresaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	presale, err := h.service.AddPresale(r.Context(), id, models.Presale{
+		Name:     input.Name,
+		StartsAt: input.StartsAt,
+		EndsAt:   input.EndsAt,
+		UserIDs:  input.UserIDs,
+	}, input.AccessCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+		case errors.Is(err, models.ErrInvalidPresale):
+			h.logger.Warn("invalid presale", "error", err)
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		case errors.Is(err, models.ErrAlreadyExists):
+			h.logger.Warn("presale already exists", "error", err)
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeAlreadyExists, err.Error())
+		default:
+			h.logger.Error("failed to create presale", "error", err)
+			response.WriteErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				response.ErrCodeInternal,
+				"internal server error",
+			)
+		}
+		return
+	}
+
+	if presale.UserIDs == nil {
+		presale.UserIDs = []uuid.UUID{}
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, presale)
+}
+
+func (h *ConcertHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	var input dto.ConcertStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	concert, err := h.service.ChangeStatus(r.Context(), id, models.ConcertStatus(input.Status))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+		case errors.Is(err, models.ErrInvalidConcertStatus):
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		case errors.Is(err, models.ErrInvalidStatusTransition):
+			h.logger.Warn("invalid concert status transition", "error", err)
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeInvalidTransition, err.Error())
+		default:
+			h.logger.Error("failed to change concert status", "error", err)
+			response.WriteErrorResponse(
+				w,
+				http.StatusInternalServerError,
+				response.ErrCodeInternal,
+				"internal server error",
+			)
+		}
+		return
+	}
+
 	response.WriteJSONResponse(w, http.StatusOK, concert)
 }
+
+func (h *ConcertHandler) writeMutationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+	case errors.Is(err, models.ErrInvalidConcert),
+		errors.Is(err, models.ErrInvalidCurrency),
+		errors.Is(err, models.ErrConcertInPast),
+		errors.Is(err, models.ErrInvalidSaleWindow),
+		errors.Is(err, models.ErrVenueNotFound),
+		errors.Is(err, models.ErrVenueCapacityExceeded):
+		h.logger.Warn("invalid concert", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+	case errors.Is(err, models.ErrSeatsBelowBooked):
+		h.logger.Warn("total seats below booked seats", "error", err)
+		response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeSeatsBelowBooked, err.Error())
+	case errors.Is(err, models.ErrConcertHasBookings):
+		h.logger.Warn("concert has bookings", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusConflict,
+			response.ErrCodeConcertHasBookings,
+			"concert with bookings cannot be deleted or moved to another venue",
+		)
+	case errors.Is(err, models.ErrTierQuotaExceeded):
+		h.logger.Warn("total seats below tier quotas", "error", err)
+		response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeInvalidTier, err.Error())
+	case errors.Is(err, models.ErrConcertPublished):
+		h.logger.Warn("concert is already on sale", "error", err)
+		response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeConcertPublished, err.Error())
+	default:
+		h.logger.Error("failed to modify concert", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+	}
+}
+
+func parseConcertFilter(query url.Values) (models.ConcertFilter, error) {
+	filter := models.ConcertFilter{
+		Search:   query.Get("q"),
+		Currency: query.Get("currency"),
+		Sort:     models.ConcertSort(query.Get("sort")),
+		Cursor:   query.Get("cursor"),
+	}
+
+	if v := query.Get("date_from"); v != "" {
+		date, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid date_from: %w", err)
+		}
+		filter.DateFrom = &date
+	}
+
+	if v := query.Get("date_to"); v != "" {
+		date, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid date_to: %w", err)
+		}
+		filter.DateTo = &date
+	}
+
+	if v := query.Get("venue_id"); v != "" {
+		venueID, err := uuid.Parse(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid venue_id: %w", err)
+		}
+		filter.VenueID = &venueID
+	}
+
+	if v := query.Get("price_min"); v != "" {
+		price, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid price_min: %w", err)
+		}
+		filter.PriceMin = &price
+	}
+
+	if v := query.Get("price_max"); v != "" {
+		price, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid price_max: %w", err)
+		}
+		filter.PriceMax = &price
+	}
+
+	if v := query.Get("available"); v != "" {
+		available, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid available: %w", err)
+		}
+		filter.OnlyAvailable = available
+	}
+
+	if v := query.Get("status"); v != "" {
+		for status := range strings.SplitSeq(v, ",") {
+			filter.Statuses = append(filter.Statuses, models.ConcertStatus(strings.ToUpper(strings.TrimSpace(status))))
+		}
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid limit: %w", err)
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+func isAdmin(r *http.Request) bool {
+	role, ok := r.Context().Value(middleware.RoleKey).(models.UserRole)
+	return ok && role == models.UserRoleAdmin
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+type LedgerHandler struct {
+	logger  *slog.Logger
+	service service.Ledger
+}
+
+func NewLedgerHandler(logger *slog.Logger, service service.Ledger) *LedgerHandler {
+	return &LedgerHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (h *LedgerHandler) ConcertReport(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	concertID, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return
+	}
+
+	report, err := h.service.ConcertReport(r.Context(), concertID)
+	if err != nil {
+		h.writeInternalError(w, "failed to build ledger report", err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, report)
+}
+
+func (h *LedgerHandler) Check(w http.ResponseWriter, r *http.Request) {
+	check, err := h.service.Check(r.Context())
+	if err != nil {
+		h.writeInternalError(w, "failed to check ledger", err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, check)
+}
+
+func (h *LedgerHandler) writeInternalError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+	response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal server error")
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/middleware"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+const maxWebhookSize = 64 << 10
+
+type PaymentHandler struct {
+	logger  *slog.Logger
+	service service.Payment
+}
+
+func NewPaymentHandler(logger *slog.Logger, service service.Payment) *PaymentHandler {
+	return &PaymentHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (h *PaymentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, bookingID, ok := h.params(w, r, "booking")
+	if !ok {
+		return
+	}
+
+	intent, err := h.service.CreateIntent(r.Context(), userID, bookingID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "booking not found")
+		case errors.Is(err, models.ErrForbidden):
+			response.WriteErrorResponse(
+				w,
+				http.StatusForbidden,
+				response.ErrCodeForbidden,
+				"booking belongs to another user",
+			)
+		case errors.Is(err, models.ErrNotPending):
+			response.WriteErrorResponse(
+				w,
+				http.StatusConflict,
+				response.ErrCodeNotPending,
+				"only pending bookings can be paid",
+			)
+		case errors.Is(err, models.ErrHoldExpired):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeHoldExpired, "booking hold has expired")
+		case errors.Is(err, models.ErrAlreadyExists):
+			response.WriteErrorResponse(
+				w,
+				http.StatusConflict,
+				response.ErrCodeAlreadyExists,
+				"payment for this booking is already in progress",
+			)
+		case errors.Is(err, models.ErrPaymentProvider):
+			h.logger.Error("payment provider failed", "booking_id", bookingID, "error", err)
+			response.WriteErrorResponse(
+				w,
+				http.StatusBadGateway,
+				response.ErrCodePaymentProvider,
+				"payment provider error",
+			)
+		default:
+			h.writeInternalError(w, "failed to create payment intent", err)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, intent)
+}
+
+func (h *PaymentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, intentID, ok := h.params(w, r, "payment")
+	if !ok {
+		return
+	}
+
+	intent, err := h.service.GetIntent(r.Context(), userID, intentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound), errors.Is(err, models.ErrForbidden):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "payment not found")
+		default:
+			h.writeInternalError(w, "failed to get payment intent", err)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, intent)
+}
+
+func (h *PaymentHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		h.logger.Warn("failed to read payment webhook", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid request body")
+		return
+	}
+
+	if err := h.service.HandleWebhook(r.Context(), payload, r.Header); err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidWebhook):
+			h.logger.Warn("rejected payment webhook", "error", err)
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidWebhook, err.Error())
+		case errors.Is(err, models.ErrNotFound):
+			h.logger.Warn("payment webhook for unknown intent", "error", err)
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "payment not found")
+		default:
+			h.writeInternalError(w, "failed to handle payment webhook", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *PaymentHandler) params(w http.ResponseWriter, r *http.Request, kind string) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Error("user id not found in context")
+		response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal error")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid "+kind+" id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid "+kind+" id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, id, true
+}
+
+func (h *PaymentHandler) writeInternalError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+	response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal server error")
+}
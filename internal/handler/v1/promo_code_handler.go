@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/dto"
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+type PromoCodeHandler struct {
+	logger    *slog.Logger
+	validator *validator.Validate
+	service   service.PromoCode
+}
+
+func NewPromoCodeHandler(
+	logger *slog.Logger,
+	validator *validator.Validate,
+	service service.PromoCode,
+) *PromoCodeHandler {
+	return &PromoCodeHandler{
+		logger:    logger,
+		validator: validator,
+		service:   service,
+	}
+}
+
+func (h *PromoCodeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.PromoCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	promo, err := h.service.Create(r.Context(), models.PromoCode{
+		Code:           input.Code,
+		ConcertID:      input.ConcertID,
+		DiscountType:   models.PromoDiscountType(input.DiscountType),
+		Value:          input.Value,
+		Currency:       input.Currency,
+		MaxUses:        input.MaxUses,
+		MaxUsesPerUser: input.MaxUsesPerUser,
+		StartsAt:       input.StartsAt,
+		EndsAt:         input.EndsAt,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, promo)
+}
+
+func (h *PromoCodeHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	promos, err := h.service.GetAll(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, promos)
+}
+
+func (h *PromoCodeHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	promo, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, promo)
+}
+
+func (h *PromoCodeHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Deactivate(r.Context(), id); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PromoCodeHandler) parseID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid promo code id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid promo code id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *PromoCodeHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "promo code or concert not found")
+	case errors.Is(err, models.ErrInvalidPromoCode):
+		h.logger.Warn("invalid promo code", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+	case errors.Is(err, models.ErrAlreadyExists):
+		h.logger.Warn("promo code already exists", "error", err)
+		response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeAlreadyExists, "promo code already exists")
+	default:
+		h.logger.Error("promo code request failed", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+	}
+}
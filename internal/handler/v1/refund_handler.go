@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/middleware"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+type RefundHandler struct {
+	logger  *slog.Logger
+	service service.Refund
+}
+
+func NewRefundHandler(logger *slog.Logger, service service.Refund) *RefundHandler {
+	return &RefundHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (h *RefundHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := h.pathID(w, r, "booking")
+	if !ok {
+		return
+	}
+
+	var (
+		refund *models.Refund
+		err    error
+	)
+	if isAdmin(r) {
+		refund, err = h.service.Issue(r.Context(), bookingID)
+	} else {
+		refund, err = h.service.Request(r.Context(), userID, bookingID)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "booking not found")
+		case errors.Is(err, models.ErrForbidden):
+			response.WriteErrorResponse(
+				w,
+				http.StatusForbidden,
+				response.ErrCodeForbidden,
+				"booking belongs to another user",
+			)
+		case errors.Is(err, models.ErrNotRefundable):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeNotRefundable, err.Error())
+		case errors.Is(err, models.ErrRefundWindowClosed):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeRefundWindowClosed, err.Error())
+		case errors.Is(err, models.ErrConcertPassed):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeConcertPassed, err.Error())
+		case errors.Is(err, models.ErrAlreadyExists):
+			response.WriteErrorResponse(
+				w,
+				http.StatusConflict,
+				response.ErrCodeAlreadyExists,
+				"booking has already been refunded",
+			)
+		default:
+			h.writeInternalError(w, "failed to request refund", err)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, refund)
+}
+
+func (h *RefundHandler) GetUserRefunds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	refunds, err := h.service.GetUserRefunds(r.Context(), userID)
+	if err != nil {
+		h.writeInternalError(w, "failed to get user refunds", err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, refunds)
+}
+
+func (h *RefundHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	refundID, ok := h.pathID(w, r, "refund")
+	if !ok {
+		return
+	}
+
+	refund, err := h.service.Get(r.Context(), userID, refundID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound), errors.Is(err, models.ErrForbidden):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "refund not found")
+		default:
+			h.writeInternalError(w, "failed to get refund", err)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, refund)
+}
+
+func (h *RefundHandler) GetConcertRefunds(w http.ResponseWriter, r *http.Request) {
+	concertID, ok := h.pathID(w, r, "concert")
+	if !ok {
+		return
+	}
+
+	refunds, err := h.service.GetConcertRefunds(r.Context(), concertID)
+	if err != nil {
+		h.writeInternalError(w, "failed to get concert refunds", err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, refunds)
+}
+
+func (h *RefundHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	refundID, ok := h.pathID(w, r, "refund")
+	if !ok {
+		return
+	}
+
+	refund, err := h.service.Retry(r.Context(), refundID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "refund not found")
+		case errors.Is(err, models.ErrRefundNotFailed):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeRefundNotFailed, err.Error())
+		default:
+			h.writeInternalError(w, "failed to retry refund", err)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, refund)
+}
+
+func (h *RefundHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Error("user id not found in context")
+		response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal error")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *RefundHandler) pathID(w http.ResponseWriter, r *http.Request, kind string) (uuid.UUID, bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid "+kind+" id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid "+kind+" id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *RefundHandler) writeInternalError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+	response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal server error")
+}
@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/dto"
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+type VenueHandler struct {
+	logger    *slog.Logger
+	validator *validator.Validate
+	service   service.Venue
+}
+
+func NewVenueHandler(logger *slog.Logger, validator *validator.Validate, service service.Venue) *VenueHandler {
+	return &VenueHandler{
+		logger:    logger,
+		validator: validator,
+		service:   service,
+	}
+}
+
+func (h *VenueHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	venues, err := h.service.GetAll(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get venues", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, venues)
+}
+
+func (h *VenueHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid venue id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid venue id")
+		return
+	}
+
+	venue, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, venue)
+}
+
+func (h *VenueHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.VenueRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	venue, err := h.service.Create(r.Context(), models.Venue{
+		Name:     input.Name,
+		Address:  input.Address,
+		Timezone: input.Timezone,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, venue)
+}
+
+func (h *VenueHandler) AddSection(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid venue id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid venue id")
+		return
+	}
+
+	var input dto.VenueSectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	rows := make([]models.VenueRowSpec, 0, len(input.Rows))
+	for _, row := range input.Rows {
+		rows = append(rows, models.VenueRowSpec{Label: row.Label, Seats: row.Seats})
+	}
+
+	section, err := h.service.AddSection(r.Context(), id, input.Name, rows)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, section)
+}
+
+func (h *VenueHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "venue not found")
+	case errors.Is(err, models.ErrInvalidVenue):
+		h.logger.Warn("invalid venue", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+	case errors.Is(err, models.ErrAlreadyExists):
+		h.logger.Warn("venue already exists", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusConflict,
+			response.ErrCodeAlreadyExists,
+			"venue, section or row with this name already exists",
+		)
+	default:
+		h.logger.Error("venue request failed", "error", err)
+		response.WriteErrorResponse(
+			w,
+			http.StatusInternalServerError,
+			response.ErrCodeInternal,
+			"internal server error",
+		)
+	}
+}
@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/dto"
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/middleware"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+type WaitingRoomHandler struct {
+	logger    *slog.Logger
+	validator *validator.Validate
+	service   service.WaitingRoom
+}
+
+func NewWaitingRoomHandler(
+	logger *slog.Logger,
+	validator *validator.Validate,
+	service service.WaitingRoom,
+) *WaitingRoomHandler {
+	return &WaitingRoomHandler{
+		logger:    logger,
+		validator: validator,
+		service:   service,
+	}
+}
+
+func (h *WaitingRoomHandler) Open(w http.ResponseWriter, r *http.Request) {
+	concertID, ok := h.concertID(w, r)
+	if !ok {
+		return
+	}
+
+	var input dto.WaitingRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Warn("failed to decode request body", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid input body")
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		h.logger.Warn("validation failed", "error", err)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		return
+	}
+
+	room, err := h.service.Open(r.Context(), concertID, input.AdmitRate)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+		case errors.Is(err, models.ErrInvalidWaitingRoom):
+			response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeValidationFailed, err.Error())
+		default:
+			h.writeInternalError(w, "failed to open waiting room", err)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, room)
+}
+
+func (h *WaitingRoomHandler) Close(w http.ResponseWriter, r *http.Request) {
+	concertID, ok := h.concertID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Close(r.Context(), concertID); err != nil {
+		if errors.Is(err, models.ErrQueueNotActive) {
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "waiting room is not active")
+			return
+		}
+		h.writeInternalError(w, "failed to close waiting room", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WaitingRoomHandler) Join(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	concertID, ok := h.concertID(w, r)
+	if !ok {
+		return
+	}
+
+	ticket, err := h.service.Join(r.Context(), userID, concertID)
+	if err != nil {
+		if errors.Is(err, models.ErrQueueNotActive) {
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeQueueNotActive, err.Error())
+			return
+		}
+		h.writeInternalError(w, "failed to join waiting room", err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, ticket)
+}
+
+func (h *WaitingRoomHandler) Status(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	concertID, ok := h.concertID(w, r)
+	if !ok {
+		return
+	}
+
+	ticket, err := h.service.Status(r.Context(), userID, concertID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "queue ticket not found")
+			return
+		}
+		h.writeInternalError(w, "failed to get waiting room status", err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, ticket)
+}
+
+func (h *WaitingRoomHandler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Error("user id not found in context")
+		response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal error")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *WaitingRoomHandler) concertID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *WaitingRoomHandler) writeInternalError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+	response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal server error")
+}
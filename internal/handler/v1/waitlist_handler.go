@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/middleware"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service"
+)
+
+type WaitlistHandler struct {
+	logger  *slog.Logger
+	service service.Waitlist
+}
+
+func NewWaitlistHandler(logger *slog.Logger, service service.Waitlist) *WaitlistHandler {
+	return &WaitlistHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (h *WaitlistHandler) Join(w http.ResponseWriter, r *http.Request) {
+	userID, concertID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := h.service.Join(r.Context(), userID, concertID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "concert not found")
+		case errors.Is(err, models.ErrWaitlistNotOpen):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeWaitlistNotOpen, err.Error())
+		case errors.Is(err, models.ErrAlreadyExists):
+			response.WriteErrorResponse(w, http.StatusConflict, response.ErrCodeAlreadyExists, err.Error())
+		default:
+			h.writeInternalError(w, "failed to join waitlist", err)
+		}
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusCreated, entry)
+}
+
+func (h *WaitlistHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, concertID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	entry, err := h.service.Get(r.Context(), userID, concertID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "not on the waitlist")
+			return
+		}
+		h.writeInternalError(w, "failed to get waitlist entry", err)
+		return
+	}
+
+	response.WriteJSONResponse(w, http.StatusOK, entry)
+}
+
+func (h *WaitlistHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	userID, concertID, ok := h.params(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Leave(r.Context(), userID, concertID); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			response.WriteErrorResponse(w, http.StatusNotFound, response.ErrCodeNotFound, "not waiting on the waitlist")
+			return
+		}
+		h.writeInternalError(w, "failed to leave waitlist", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WaitlistHandler) params(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(uuid.UUID)
+	if !ok {
+		h.logger.Error("user id not found in context")
+		response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal error")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	idStr := chi.URLParam(r, "id")
+	concertID, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("invalid concert id", "error", err, "id", idStr)
+		response.WriteErrorResponse(w, http.StatusBadRequest, response.ErrCodeInvalidFormat, "invalid concert id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, concertID, true
+}
+
+func (h *WaitlistHandler) writeInternalError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+	response.WriteErrorResponse(w, http.StatusInternalServerError, response.ErrCodeInternal, "internal server error")
+}
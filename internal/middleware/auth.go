@@ -2,18 +2,26 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/models"
 	"github.com/yohnnn/booking_service/internal/service"
 )
 
 type contextKey string
 
-const UserIDKey contextKey = "user_id"
+const (
+	UserIDKey contextKey = "user_id"
+	RoleKey   contextKey = "role"
+	ClaimsKey contextKey = "claims"
+)
 
-func Auth(authService service.Auth) func(next http.Handler) http.Handler {
+func Auth(logger *slog.Logger, authService service.Auth) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -39,14 +47,72 @@ func Auth(authService service.Auth) func(next http.Handler) http.Handler {
 			}
 
 			token := headerParts[1]
-			userID, err := authService.ParseToken(token)
-			if err != nil {
+			claims, err := authService.ValidateToken(r.Context(), token)
+			switch {
+			case errors.Is(err, models.ErrTokenRevoked):
+				response.WriteErrorResponse(
+					w,
+					http.StatusUnauthorized,
+					response.ErrCodeUnauthorized,
+					"token has been revoked",
+				)
+				return
+			case errors.Is(err, models.ErrInvalidAccessToken):
 				response.WriteErrorResponse(w, http.StatusUnauthorized, response.ErrCodeUnauthorized, "invalid token")
 				return
+			case err != nil:
+				logger.ErrorContext(r.Context(), "failed to validate token", "error", err)
+				response.WriteErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					response.ErrCodeInternal,
+					"internal error",
+				)
+				return
 			}
 
-			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
+			ctx = context.WithValue(ctx, RoleKey, claims.Role)
+			ctx = context.WithValue(ctx, ClaimsKey, claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+func OptionalAuth(logger *slog.Logger, authService service.Auth) func(next http.Handler) http.Handler {
+	auth := Auth(logger, authService)
+	return func(next http.Handler) http.Handler {
+		authenticated := auth(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
+
+func RequireRole(roles ...models.UserRole) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := r.Context().Value(RoleKey).(models.UserRole)
+			if !ok {
+				response.WriteErrorResponse(
+					w,
+					http.StatusUnauthorized,
+					response.ErrCodeUnauthorized,
+					"missing authentication",
+				)
+				return
+			}
+
+			if !slices.Contains(roles, role) {
+				response.WriteErrorResponse(w, http.StatusForbidden, response.ErrCodeForbidden, "insufficient role")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
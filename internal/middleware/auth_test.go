@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestAuth(t *testing.T) {
+	claims := &models.TokenClaims{
+		UserID:    uuid.New(),
+		Role:      models.UserRoleUser,
+		ID:        uuid.NewString(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	type mockBehavior func(auth *mocks.MockAuth)
+
+	tests := []struct {
+		name          string
+		authorization string
+		mockBehavior  mockBehavior
+		wantStatus    int
+		wantBody      string
+		wantCalled    bool
+	}{
+		{
+			name:          "valid token",
+			authorization: "Bearer token",
+			mockBehavior: func(auth *mocks.MockAuth) {
+				auth.EXPECT().
+					ValidateToken(gomock.Any(), "token").
+					Return(claims, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantCalled: true,
+		},
+		{
+			name:         "missing header",
+			mockBehavior: func(_ *mocks.MockAuth) {},
+			wantStatus:   http.StatusUnauthorized,
+			wantBody:     "missing authorization header",
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer token",
+			mockBehavior: func(auth *mocks.MockAuth) {
+				auth.EXPECT().
+					ValidateToken(gomock.Any(), "token").
+					Return(nil, fmt.Errorf("%w: token is expired", models.ErrInvalidAccessToken))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   "invalid token",
+		},
+		{
+			name:          "revoked token",
+			authorization: "Bearer token",
+			mockBehavior: func(auth *mocks.MockAuth) {
+				auth.EXPECT().
+					ValidateToken(gomock.Any(), "token").
+					Return(nil, models.ErrTokenRevoked)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   "token has been revoked",
+		},
+		{
+			name:          "denylist unavailable",
+			authorization: "Bearer token",
+			mockBehavior: func(auth *mocks.MockAuth) {
+				auth.EXPECT().
+					ValidateToken(gomock.Any(), "token").
+					Return(nil, assert.AnError)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   response.ErrCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auth := mocks.NewMockAuth(ctrl)
+			tt.mockBehavior(auth)
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				assert.Equal(t, claims.UserID, r.Context().Value(UserIDKey))
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/bookings", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			Auth(testLogger(), auth)(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantCalled, called)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/cache"
+	"github.com/yohnnn/booking_service/internal/handler/response"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotencyRequestBody = 1 << 20
+)
+
+type recordingWriter struct {
+	http.ResponseWriter
+
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+func Idempotency(
+	logger *slog.Logger,
+	store cache.IdempotencyCacheRepository,
+	lockTTL time.Duration,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+			if idempotencyKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(idempotencyKey) > maxIdempotencyKeyLength {
+				response.WriteErrorResponse(
+					w,
+					http.StatusBadRequest,
+					response.ErrCodeInvalidFormat,
+					"idempotency key is too long",
+				)
+				return
+			}
+
+			userID, ok := r.Context().Value(UserIDKey).(uuid.UUID)
+			if !ok {
+				logger.Error("user id not found in context")
+				response.WriteErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					response.ErrCodeInternal,
+					"internal error",
+				)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotencyRequestBody+1))
+			if err != nil {
+				logger.Warn("failed to read request body", "error", err)
+				response.WriteErrorResponse(
+					w,
+					http.StatusBadRequest,
+					response.ErrCodeInvalidFormat,
+					"invalid input body",
+				)
+				return
+			}
+			if len(body) > maxIdempotencyRequestBody {
+				response.WriteErrorResponse(
+					w,
+					http.StatusRequestEntityTooLarge,
+					response.ErrCodeRequestTooLarge,
+					"request body is too large",
+				)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			hash := sha256.New()
+			hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+			hash.Write(body)
+			requestHash := hex.EncodeToString(hash.Sum(nil))
+
+			key := userID.String() + ":" + idempotencyKey
+
+			record, reserved, err := store.Reserve(r.Context(), key, requestHash)
+			if err != nil {
+				logger.Error("failed to reserve idempotency key", "error", err)
+				response.WriteErrorResponse(
+					w,
+					http.StatusInternalServerError,
+					response.ErrCodeInternal,
+					"internal server error",
+				)
+				return
+			}
+
+			if !reserved {
+				replay(w, record, requestHash)
+				return
+			}
+
+			ctx := context.WithoutCancel(r.Context())
+
+			release := holdLock(ctx, logger, store, key, lockTTL)
+			defer release()
+			defer func() {
+				if p := recover(); p != nil {
+					release()
+					if err := store.Delete(ctx, key); err != nil {
+						logger.Error("failed to release idempotency key", "error", err)
+					}
+					panic(p)
+				}
+			}()
+
+			recorder := &recordingWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			release()
+
+			if recorder.statusCode >= http.StatusInternalServerError {
+				if err := store.Delete(ctx, key); err != nil {
+					logger.Error("failed to release idempotency key", "error", err)
+				}
+				return
+			}
+
+			if err := store.Save(ctx, key, &cache.IdempotencyRecord{
+				RequestHash: requestHash,
+				Completed:   true,
+				StatusCode:  recorder.statusCode,
+				Body:        recorder.body.Bytes(),
+			}); err != nil {
+				logger.Error("failed to save idempotent response", "error", err)
+			}
+		})
+	}
+}
+
+func holdLock(
+	ctx context.Context,
+	logger *slog.Logger,
+	store cache.IdempotencyCacheRepository,
+	key string,
+	lockTTL time.Duration,
+) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(lockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := store.Extend(ctx, key); err != nil && ctx.Err() == nil {
+					logger.Error("failed to extend idempotency lock", "error", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func replay(w http.ResponseWriter, record cache.IdempotencyRecord, requestHash string) {
+	if record.RequestHash != requestHash {
+		response.WriteErrorResponse(
+			w,
+			http.StatusUnprocessableEntity,
+			response.ErrCodeIdempotencyKeyReused,
+			"idempotency key was already used with a different request",
+		)
+		return
+	}
+
+	if !record.Completed {
+		response.WriteErrorResponse(
+			w,
+			http.StatusConflict,
+			response.ErrCodeRequestInProgress,
+			"request with this idempotency key is still in progress",
+		)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
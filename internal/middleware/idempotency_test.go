@@ -0,0 +1,264 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/cache"
+	"github.com/yohnnn/booking_service/internal/handler/response"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func TestIdempotency(t *testing.T) {
+	userID := uuid.New()
+	key := userID.String() + ":order-1"
+	created := `{"id":"b1"}`
+
+	type mockBehavior func(store *mocks.MockIdempotencyCacheRepository)
+
+	tests := []struct {
+		name           string
+		idempotencyKey string
+		body           string
+		lockTTL        time.Duration
+		handler        http.HandlerFunc
+		mockBehavior   mockBehavior
+		wantStatus     int
+		wantBody       string
+		wantCalled     bool
+		wantReplayed   bool
+		wantPanic      bool
+	}{
+		{
+			name:           "first request stores response",
+			idempotencyKey: "order-1",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = io.WriteString(w, created)
+			},
+			mockBehavior: func(store *mocks.MockIdempotencyCacheRepository) {
+				store.EXPECT().
+					Reserve(gomock.Any(), key, gomock.Any()).
+					Return(cache.IdempotencyRecord{}, true, nil)
+				store.EXPECT().
+					Save(gomock.Any(), key, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, record *cache.IdempotencyRecord) error {
+						assert.True(t, record.Completed)
+						assert.Equal(t, http.StatusCreated, record.StatusCode)
+						assert.JSONEq(t, created, string(record.Body))
+						return nil
+					})
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   created,
+			wantCalled: true,
+		},
+		{
+			name:           "completed request is replayed",
+			idempotencyKey: "order-1",
+			mockBehavior: func(store *mocks.MockIdempotencyCacheRepository) {
+				store.EXPECT().
+					Reserve(gomock.Any(), key, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, hash string) (cache.IdempotencyRecord, bool, error) {
+						return cache.IdempotencyRecord{
+							RequestHash: hash,
+							Completed:   true,
+							StatusCode:  http.StatusCreated,
+							Body:        []byte(created),
+						}, false, nil
+					})
+			},
+			wantStatus:   http.StatusCreated,
+			wantBody:     created,
+			wantReplayed: true,
+		},
+		{
+			name:           "request still in flight",
+			idempotencyKey: "order-1",
+			mockBehavior: func(store *mocks.MockIdempotencyCacheRepository) {
+				store.EXPECT().
+					Reserve(gomock.Any(), key, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, hash string) (cache.IdempotencyRecord, bool, error) {
+						return cache.IdempotencyRecord{RequestHash: hash}, false, nil
+					})
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   response.ErrCodeRequestInProgress,
+		},
+		{
+			name:           "same key with a different body",
+			idempotencyKey: "order-1",
+			mockBehavior: func(store *mocks.MockIdempotencyCacheRepository) {
+				store.EXPECT().
+					Reserve(gomock.Any(), key, gomock.Any()).
+					Return(cache.IdempotencyRecord{RequestHash: "other", Completed: true}, false, nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   response.ErrCodeIdempotencyKeyReused,
+		},
+		{
+			name:           "handler error releases key",
+			idempotencyKey: "order-1",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			mockBehavior: func(store *mocks.MockIdempotencyCacheRepository) {
+				store.EXPECT().
+					Reserve(gomock.Any(), key, gomock.Any()).
+					Return(cache.IdempotencyRecord{}, true, nil)
+				store.EXPECT().
+					Delete(gomock.Any(), key).
+					Return(nil)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCalled: true,
+		},
+		{
+			name:           "handler panic releases key",
+			idempotencyKey: "order-1",
+			handler: func(_ http.ResponseWriter, _ *http.Request) {
+				panic("boom")
+			},
+			mockBehavior: func(store *mocks.MockIdempotencyCacheRepository) {
+				store.EXPECT().
+					Reserve(gomock.Any(), key, gomock.Any()).
+					Return(cache.IdempotencyRecord{}, true, nil)
+				store.EXPECT().
+					Delete(gomock.Any(), key).
+					Return(nil)
+			},
+			wantCalled: true,
+			wantPanic:  true,
+		},
+		{
+			name:           "oversized body rejected before reserving",
+			idempotencyKey: "order-1",
+			body:           `{"seat":1,"pad":"` + strings.Repeat("x", maxIdempotencyRequestBody) + `"}`,
+			mockBehavior:   func(_ *mocks.MockIdempotencyCacheRepository) {},
+			wantStatus:     http.StatusRequestEntityTooLarge,
+			wantBody:       response.ErrCodeRequestTooLarge,
+		},
+		{
+			name:           "response saved after client disconnects",
+			idempotencyKey: "order-1",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				r.Context().Value(cancelKey{}).(context.CancelFunc)()
+				w.WriteHeader(http.StatusCreated)
+			},
+			mockBehavior: func(store *mocks.MockIdempotencyCacheRepository) {
+				store.EXPECT().
+					Reserve(gomock.Any(), key, gomock.Any()).
+					Return(cache.IdempotencyRecord{}, true, nil)
+				store.EXPECT().
+					Save(gomock.Any(), key, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _ string, _ *cache.IdempotencyRecord) error {
+						assert.NoError(t, ctx.Err())
+						return nil
+					})
+			},
+			wantStatus: http.StatusCreated,
+			wantCalled: true,
+		},
+		{
+			name:           "lock extended while handler runs",
+			idempotencyKey: "order-1",
+			lockTTL:        30 * time.Millisecond,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				time.Sleep(60 * time.Millisecond)
+				w.WriteHeader(http.StatusCreated)
+			},
+			mockBehavior: func(store *mocks.MockIdempotencyCacheRepository) {
+				store.EXPECT().
+					Reserve(gomock.Any(), key, gomock.Any()).
+					Return(cache.IdempotencyRecord{}, true, nil)
+				store.EXPECT().
+					Extend(gomock.Any(), key).
+					Return(nil).
+					MinTimes(1)
+				store.EXPECT().
+					Save(gomock.Any(), key, gomock.Any()).
+					Return(nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantCalled: true,
+		},
+		{
+			name: "no key skips the store",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			},
+			mockBehavior: func(_ *mocks.MockIdempotencyCacheRepository) {},
+			wantStatus:   http.StatusCreated,
+			wantCalled:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mocks.NewMockIdempotencyCacheRepository(ctrl)
+			tt.mockBehavior(store)
+
+			lockTTL := tt.lockTTL
+			if lockTTL == 0 {
+				lockTTL = time.Minute
+			}
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				tt.handler(w, r)
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx = context.WithValue(ctx, UserIDKey, userID)
+			ctx = context.WithValue(ctx, cancelKey{}, context.CancelFunc(cancel))
+
+			body := tt.body
+			if body == "" {
+				body = `{"seat":1}`
+			}
+			req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/api/bookings", strings.NewReader(body))
+			if tt.idempotencyKey != "" {
+				req.Header.Set(IdempotencyKeyHeader, tt.idempotencyKey)
+			}
+			rec := httptest.NewRecorder()
+
+			handler := Idempotency(testLogger(), store, lockTTL)(next)
+			if tt.wantPanic {
+				assert.Panics(t, func() { handler.ServeHTTP(rec, req) })
+				assert.True(t, called)
+				return
+			}
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantCalled, called)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+			if tt.wantReplayed {
+				assert.Equal(t, "true", rec.Header().Get(IdempotentReplayedHeader))
+			}
+		})
+	}
+}
+
+type cancelKey struct{}
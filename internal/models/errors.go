@@ -3,7 +3,74 @@ package models
 import "errors"
 
 var (
-	ErrNotFound      = errors.New("not found")
-	ErrAlreadyExists = errors.New("already exists")
-	ErrNoSeats       = errors.New("no seats available")
+	ErrNotFound         = errors.New("not found")
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrNoSeats          = errors.New("no seats available")
+	ErrSeatsOverflow    = errors.New("released seats exceed inventory")
+	ErrForbidden        = errors.New("forbidden")
+	ErrBookingCancelled = errors.New("booking already cancelled")
+	ErrBookingChanged   = errors.New("booking status changed concurrently")
+	ErrNotPending       = errors.New("booking is not pending")
+	ErrHoldExpired      = errors.New("booking hold expired")
+	ErrInvalidSeat      = errors.New("seat is out of concert range")
+	ErrConcertPassed    = errors.New("concert has already taken place")
+	ErrInvalidRole      = errors.New("invalid role")
+
+	ErrInvalidConcert     = errors.New("invalid concert data")
+	ErrConcertInPast      = errors.New("concert date must be in the future")
+	ErrSeatsBelowBooked   = errors.New("total seats cannot be lower than booked seats or a booked seat number")
+	ErrConcertHasBookings = errors.New("concert has bookings")
+
+	ErrInvalidFilter = errors.New("invalid concert filter")
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	ErrInvalidToken       = errors.New("invalid or expired refresh token")
+	ErrTokenReused        = errors.New("refresh token reuse detected")
+	ErrTokenRevoked       = errors.New("token has been revoked")
+	ErrInvalidAccessToken = errors.New("invalid access token")
+
+	ErrInvalidVenue          = errors.New("invalid venue data")
+	ErrVenueNotFound         = errors.New("venue not found")
+	ErrVenueCapacityExceeded = errors.New("total seats exceed venue capacity")
+
+	ErrInvalidTier       = errors.New("invalid ticket tier")
+	ErrTierQuotaExceeded = errors.New("tier quota exceeds available seats")
+	ErrTierSectionInUse  = errors.New("section is already assigned to another tier")
+	ErrSeatNotInTier     = errors.New("seat does not belong to the requested tier")
+
+	ErrInvalidCurrency  = errors.New("invalid currency code")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+
+	ErrInvalidWaitingRoom = errors.New("invalid waiting room settings")
+	ErrQueueNotActive     = errors.New("waiting room is not active")
+	ErrNotAdmitted        = errors.New("waiting room admission required")
+
+	ErrInvalidSaleWindow     = errors.New("invalid sale window")
+	ErrInvalidPresale        = errors.New("invalid presale")
+	ErrSaleNotStarted        = errors.New("sale has not started yet")
+	ErrSaleEnded             = errors.New("sale has ended")
+	ErrPresaleAccessRequired = errors.New("presale access required")
+	ErrInvalidAccessCode     = errors.New("invalid presale access code")
+
+	ErrInvalidConcertStatus    = errors.New("invalid concert status")
+	ErrInvalidStatusTransition = errors.New("concert status transition is not allowed")
+	ErrConcertNotOnSale        = errors.New("concert is not on sale")
+	ErrConcertPublished        = errors.New("venue and currency can be changed only before the concert is on sale")
+
+	ErrWaitlistNotOpen = errors.New("waitlist is open only for sold out concerts")
+
+	ErrPaymentProvider = errors.New("payment provider error")
+	ErrInvalidWebhook  = errors.New("invalid payment webhook")
+
+	ErrNotRefundable      = errors.New("only confirmed bookings can be refunded")
+	ErrRefundRequired     = errors.New("confirmed booking must be refunded instead of cancelled")
+	ErrRefundWindowClosed = errors.New("refund window has closed")
+	ErrRefundNotFailed    = errors.New("only failed refunds can be retried")
+
+	ErrUnbalancedLedger = errors.New("ledger transaction is unbalanced")
+
+	ErrInvalidPromoCode       = errors.New("invalid promo code")
+	ErrPromoCodeNotApplicable = errors.New("promo code is not applicable")
+	ErrPromoCodeExhausted     = errors.New("promo code usage limit reached")
+	ErrPromoCodeUserLimit     = errors.New("promo code per-user limit reached")
 )
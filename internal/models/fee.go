@@ -0,0 +1,33 @@
+package models
+
+type VATBase string
+
+const (
+	VATBaseFee   VATBase = "fee"
+	VATBaseTotal VATBase = "total"
+)
+
+type FeePolicy struct {
+	ServiceFeePercent int64
+	VATPercent        int64
+	VATBase           VATBase
+}
+
+func (p FeePolicy) Apply(booking *Booking) {
+	currency := booking.FaceValue.Currency
+	if booking.Discount.Currency == "" {
+		booking.Discount.Currency = currency
+	}
+
+	net := booking.FaceValue.Amount - booking.Discount.Amount
+	fee := net * p.ServiceFeePercent / 100
+	base := fee
+	if p.VATBase == VATBaseTotal {
+		base = net + fee
+	}
+	vat := base * p.VATPercent / 100
+
+	booking.ServiceFee = Money{Amount: fee, Currency: currency}
+	booking.VAT = Money{Amount: vat, Currency: currency}
+	booking.Price = Money{Amount: net + fee + vat, Currency: currency}
+}
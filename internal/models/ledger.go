@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LedgerAccount string
+
+const (
+	LedgerAccountCustomer         LedgerAccount = "CUSTOMER"
+	LedgerAccountPaymentProvider  LedgerAccount = "PAYMENT_PROVIDER"
+	LedgerAccountOrganizerRevenue LedgerAccount = "ORGANIZER_REVENUE"
+	LedgerAccountPlatformFees     LedgerAccount = "PLATFORM_FEES"
+	LedgerAccountVATPayable       LedgerAccount = "VAT_PAYABLE"
+	LedgerAccountRefunds          LedgerAccount = "REFUNDS"
+)
+
+type LedgerDirection string
+
+const (
+	LedgerDebit  LedgerDirection = "DEBIT"
+	LedgerCredit LedgerDirection = "CREDIT"
+)
+
+type LedgerTransactionType string
+
+const (
+	LedgerTransactionSale         LedgerTransactionType = "SALE"
+	LedgerTransactionSaleReversal LedgerTransactionType = "SALE_REVERSAL"
+	LedgerTransactionPayment      LedgerTransactionType = "PAYMENT"
+	LedgerTransactionRefund       LedgerTransactionType = "REFUND"
+	LedgerTransactionRefundPayout LedgerTransactionType = "REFUND_PAYOUT"
+)
+
+type LedgerEntry struct {
+	Account   LedgerAccount   `db:"account"   json:"account"`
+	Direction LedgerDirection `db:"direction" json:"direction"`
+	Amount    Money           `db:"amount"    json:"amount"`
+}
+
+type LedgerTransaction struct {
+	ID          uuid.UUID             `db:"id"           json:"id"`
+	Type        LedgerTransactionType `db:"type"         json:"type"`
+	ConcertID   uuid.UUID             `db:"concert_id"   json:"concert_id"`
+	BookingID   *uuid.UUID            `db:"booking_id"   json:"booking_id,omitempty"`
+	ReferenceID *uuid.UUID            `db:"reference_id" json:"reference_id,omitempty"`
+	Entries     []LedgerEntry         `db:"-"            json:"entries"`
+	CreatedAt   time.Time             `db:"created_at"   json:"created_at"`
+}
+
+func (t *LedgerTransaction) Validate() error {
+	if len(t.Entries) < 2 {
+		return fmt.Errorf("%w: %s has %d entries", ErrUnbalancedLedger, t.Type, len(t.Entries))
+	}
+
+	var debit, credit int64
+	currency := t.Entries[0].Amount.Currency
+	for _, e := range t.Entries {
+		if e.Amount.Amount <= 0 {
+			return fmt.Errorf("%w: %s has a non-positive %s entry", ErrUnbalancedLedger, t.Type, e.Account)
+		}
+		if e.Amount.Currency != currency {
+			return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, currency, e.Amount.Currency)
+		}
+		switch e.Direction {
+		case LedgerDebit:
+			debit += e.Amount.Amount
+		case LedgerCredit:
+			credit += e.Amount.Amount
+		default:
+			return fmt.Errorf("%w: unknown direction %q", ErrUnbalancedLedger, e.Direction)
+		}
+	}
+
+	if debit != credit {
+		return fmt.Errorf("%w: %s debits %d, credits %d", ErrUnbalancedLedger, t.Type, debit, credit)
+	}
+	return nil
+}
+
+type LedgerBalance struct {
+	Account  LedgerAccount `db:"account"  json:"account"`
+	Currency string        `db:"currency" json:"currency"`
+	Debit    int64         `db:"debit"    json:"debit"`
+	Credit   int64         `db:"credit"   json:"credit"`
+	Balance  int64         `db:"balance"  json:"balance"`
+}
+
+type LedgerReport struct {
+	ConcertID uuid.UUID       `json:"concert_id"`
+	Balances  []LedgerBalance `json:"balances"`
+	Balanced  bool            `json:"balanced"`
+}
+
+type LedgerTotal struct {
+	Currency string `db:"currency" json:"currency"`
+	Debit    int64  `db:"debit"    json:"debit"`
+	Credit   int64  `db:"credit"   json:"credit"`
+}
+
+type LedgerCheck struct {
+	Totals                 []LedgerTotal `json:"totals"`
+	UnbalancedTransactions []uuid.UUID   `json:"unbalanced_transactions"`
+	Balanced               bool          `json:"balanced"`
+}
@@ -1,27 +1,240 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type UserRole string
+
+const (
+	UserRoleUser  UserRole = "user"
+	UserRoleAdmin UserRole = "admin"
+)
+
+func (r UserRole) Valid() bool {
+	return r == UserRoleUser || r == UserRoleAdmin
+}
+
 type User struct {
 	ID           uuid.UUID `db:"id"            json:"id"`
 	Email        string    `db:"email"         json:"email"`
 	PasswordHash string    `db:"password_hash" json:"-"`
+	Role         UserRole  `db:"role"          json:"role"`
 	CreatedAt    time.Time `db:"created_at"    json:"created_at"`
 }
 
+type TokenClaims struct {
+	UserID    uuid.UUID
+	Role      UserRole
+	ID        string
+	ExpiresAt time.Time
+}
+
+type TokenPair struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	FamilyID  uuid.UUID  `db:"family_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+type Venue struct {
+	ID        uuid.UUID      `db:"id"         json:"id"`
+	Name      string         `db:"name"       json:"name"`
+	Address   string         `db:"address"    json:"address"`
+	Timezone  string         `db:"timezone"   json:"timezone"`
+	Capacity  int            `db:"capacity"   json:"capacity"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+	Sections  []VenueSection `db:"-"          json:"sections,omitempty"`
+}
+
+type VenueSection struct {
+	ID       uuid.UUID  `db:"id"       json:"id"`
+	VenueID  uuid.UUID  `db:"venue_id" json:"venue_id"`
+	Name     string     `db:"name"     json:"name"`
+	Position int        `db:"position" json:"position"`
+	Rows     []VenueRow `db:"-"        json:"rows"`
+}
+
+type VenueRow struct {
+	ID        uuid.UUID   `db:"id"         json:"id"`
+	SectionID uuid.UUID   `db:"section_id" json:"section_id"`
+	Label     string      `db:"label"      json:"label"`
+	Position  int         `db:"position"   json:"position"`
+	Seats     []VenueSeat `db:"-"          json:"seats"`
+}
+
+type VenueRowSpec struct {
+	Label string
+	Seats int
+}
+
+type VenueSeat struct {
+	ID      uuid.UUID `db:"id"       json:"id"`
+	VenueID uuid.UUID `db:"venue_id" json:"-"`
+	RowID   uuid.UUID `db:"row_id"   json:"row_id"`
+	Number  int       `db:"number"   json:"number"`
+	Ordinal int       `db:"ordinal"  json:"ordinal"`
+}
+
+type ConcertStatus string
+
+const (
+	ConcertStatusDraft     ConcertStatus = "DRAFT"
+	ConcertStatusOnSale    ConcertStatus = "ON_SALE"
+	ConcertStatusSoldOut   ConcertStatus = "SOLD_OUT"
+	ConcertStatusCancelled ConcertStatus = "CANCELLED"
+	ConcertStatusCompleted ConcertStatus = "COMPLETED"
+)
+
+var concertStatusTransitions = map[ConcertStatus][]ConcertStatus{
+	ConcertStatusDraft:   {ConcertStatusOnSale, ConcertStatusCancelled},
+	ConcertStatusOnSale:  {ConcertStatusSoldOut, ConcertStatusCancelled, ConcertStatusCompleted},
+	ConcertStatusSoldOut: {ConcertStatusOnSale, ConcertStatusCancelled, ConcertStatusCompleted},
+}
+
+func (s ConcertStatus) Valid() bool {
+	switch s {
+	case ConcertStatusDraft, ConcertStatusOnSale, ConcertStatusSoldOut,
+		ConcertStatusCancelled, ConcertStatusCompleted:
+		return true
+	}
+	return false
+}
+
+func (s ConcertStatus) CanTransitionTo(next ConcertStatus) bool {
+	return slices.Contains(concertStatusTransitions[s], next)
+}
+
 type Concert struct {
-	ID             uuid.UUID `db:"id"              json:"id"`
-	Name           string    `db:"name"            json:"name"`
-	Place          string    `db:"place"           json:"place"`
-	Date           time.Time `db:"date"            json:"date"`
-	Price          float64   `db:"price"           json:"price"`
-	TotalSeats     int       `db:"total_seats"     json:"total_seats"`
-	AvailableSeats int       `db:"available_seats" json:"available_seats"`
-	CreatedAt      time.Time `db:"created_at"      json:"created_at"`
+	ID             uuid.UUID     `db:"id"              json:"id"`
+	Name           string        `db:"name"            json:"name"`
+	VenueID        uuid.UUID     `db:"venue_id"        json:"venue_id"`
+	VenueName      string        `db:"venue_name"      json:"venue_name"`
+	VenueTimezone  string        `db:"venue_timezone"  json:"venue_timezone"`
+	Date           time.Time     `db:"date"            json:"date"`
+	Price          Money         `db:"price"           json:"price"`
+	Status         ConcertStatus `db:"status"          json:"status"`
+	TotalSeats     int           `db:"total_seats"     json:"total_seats"`
+	AvailableSeats int           `db:"available_seats" json:"available_seats"`
+	SaleStartsAt   *time.Time    `db:"sale_starts_at"  json:"sale_starts_at,omitempty"`
+	SaleEndsAt     *time.Time    `db:"sale_ends_at"    json:"sale_ends_at,omitempty"`
+	SaleStatus     SaleStatus    `db:"-"               json:"sale_status,omitempty"`
+	CreatedAt      time.Time     `db:"created_at"      json:"created_at"`
+}
+
+func (c *Concert) Localize() {
+	loc, err := time.LoadLocation(c.VenueTimezone)
+	if err != nil {
+		return
+	}
+	c.Date = c.Date.In(loc)
+	if c.SaleStartsAt != nil {
+		startsAt := c.SaleStartsAt.In(loc)
+		c.SaleStartsAt = &startsAt
+	}
+	if c.SaleEndsAt != nil {
+		endsAt := c.SaleEndsAt.In(loc)
+		c.SaleEndsAt = &endsAt
+	}
+}
+
+type ConcertPatch struct {
+	Name         *string
+	VenueID      *uuid.UUID
+	Date         *time.Time
+	Price        *int64
+	Currency     *string
+	TotalSeats   *int
+	SaleStartsAt *time.Time
+	SaleEndsAt   *time.Time
+
+	ClearSaleStartsAt bool
+	ClearSaleEndsAt   bool
+}
+
+type TicketTier struct {
+	ID         uuid.UUID   `db:"id"          json:"id"`
+	ConcertID  uuid.UUID   `db:"concert_id"  json:"concert_id"`
+	Name       string      `db:"name"        json:"name"`
+	Price      Money       `db:"price"       json:"price"`
+	Quota      int         `db:"quota"       json:"quota"`
+	Available  int         `db:"available"   json:"available"`
+	SectionIDs []uuid.UUID `db:"section_ids" json:"section_ids"`
+	CreatedAt  time.Time   `db:"created_at"  json:"created_at"`
+}
+
+type SeatAssignment struct {
+	SeatID  uuid.UUID `db:"seat_id"`
+	Ordinal int       `db:"ordinal"`
+	TierID  uuid.UUID `db:"tier_id"`
+	Price   Money     `db:"price"`
+}
+
+type SeatCandidate struct {
+	SeatAssignment
+	RowID  uuid.UUID `db:"row_id"`
+	Number int       `db:"number"`
+}
+
+type ConcertSort string
+
+const (
+	ConcertSortDate      ConcertSort = "date"
+	ConcertSortDateDesc  ConcertSort = "-date"
+	ConcertSortPrice     ConcertSort = "price"
+	ConcertSortPriceDesc ConcertSort = "-price"
+	ConcertSortName      ConcertSort = "name"
+	ConcertSortNameDesc  ConcertSort = "-name"
+)
+
+func (s ConcertSort) Valid() bool {
+	switch s {
+	case ConcertSortDate, ConcertSortDateDesc,
+		ConcertSortPrice, ConcertSortPriceDesc,
+		ConcertSortName, ConcertSortNameDesc:
+		return true
+	}
+	return false
+}
+
+type ConcertFilter struct {
+	DateFrom      *time.Time
+	DateTo        *time.Time
+	VenueID       *uuid.UUID
+	PriceMin      *int64
+	PriceMax      *int64
+	Currency      string
+	OnlyAvailable bool
+	Statuses      []ConcertStatus
+	ShowDrafts    bool
+	Search        string
+	Sort          ConcertSort
+	Limit         int
+	Cursor        string
+}
+
+type ConcertCursor struct {
+	Sort  ConcertSort `json:"s"`
+	Value string      `json:"v"`
+	ID    uuid.UUID   `json:"id"`
+}
+
+type ConcertPage struct {
+	Items      []Concert `json:"items"`
+	NextCursor string    `json:"next_cursor,omitempty"`
 }
 
 type BookingStatus string
@@ -33,10 +246,55 @@ const (
 )
 
 type Booking struct {
-	ID         uuid.UUID     `db:"id"          json:"id"`
-	UserID     uuid.UUID     `db:"user_id"     json:"user_id"`
-	ConcertID  uuid.UUID     `db:"concert_id"  json:"concert_id"`
-	SeatNumber int           `db:"seat_number" json:"seat_number"`
-	Status     BookingStatus `db:"status"      json:"status"`
-	CreatedAt  time.Time     `db:"created_at"  json:"created_at"`
+	ID          uuid.UUID     `db:"id"            json:"id"`
+	UserID      uuid.UUID     `db:"user_id"       json:"user_id"`
+	ConcertID   uuid.UUID     `db:"concert_id"    json:"concert_id"`
+	SeatID      uuid.UUID     `db:"seat_id"       json:"seat_id"`
+	SeatNumber  int           `db:"seat_number"   json:"seat_number"`
+	TierID      uuid.UUID     `db:"tier_id"       json:"tier_id"`
+	FaceValue   Money         `db:"face_value"    json:"face_value"`
+	Discount    Money         `db:"discount"      json:"discount"`
+	ServiceFee  Money         `db:"service_fee"   json:"service_fee"`
+	VAT         Money         `db:"vat"           json:"vat"`
+	Price       Money         `db:"price"         json:"price"`
+	PromoCodeID *uuid.UUID    `db:"promo_code_id" json:"promo_code_id,omitempty"`
+	Status      BookingStatus `db:"status"        json:"status"`
+	ExpiresAt   *time.Time    `db:"expires_at"    json:"expires_at,omitempty"`
+	CreatedAt   time.Time     `db:"created_at"    json:"created_at"`
+}
+
+type BookingRequest struct {
+	ConcertID  uuid.UUID
+	TierID     *uuid.UUID
+	Seats      []int
+	SeatIDs    []uuid.UUID
+	Quantity   int
+	QueueToken string
+	AccessCode string
+	PromoCode  string
+}
+
+type WaitingRoom struct {
+	ConcertID uuid.UUID `json:"concert_id"`
+	AdmitRate int       `json:"admit_rate"`
+}
+
+type QueueTicket struct {
+	ConcertID uuid.UUID  `json:"concert_id"`
+	Token     string     `json:"token"`
+	Position  int64      `json:"position"`
+	Admitted  bool       `json:"admitted"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type OutboxMessage struct {
+	ID            uuid.UUID  `db:"id"`
+	EventType     string     `db:"event_type"`
+	EventKey      string     `db:"event_key"`
+	Payload       []byte     `db:"payload"`
+	Attempts      int        `db:"attempts"`
+	LastError     *string    `db:"last_error"`
+	NextAttemptAt time.Time  `db:"next_attempt_at"`
+	SentAt        *time.Time `db:"sent_at"`
+	CreatedAt     time.Time  `db:"created_at"`
 }
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentStatusPending   PaymentStatus = "PENDING"
+	PaymentStatusSucceeded PaymentStatus = "SUCCEEDED"
+	PaymentStatusFailed    PaymentStatus = "FAILED"
+)
+
+func (s PaymentStatus) IsFinal() bool {
+	return s == PaymentStatusSucceeded || s == PaymentStatusFailed
+}
+
+const RefundReasonPaymentAfterRelease = "PAYMENT_AFTER_RELEASE"
+
+type PaymentIntent struct {
+	ID            uuid.UUID     `db:"id"             json:"id"`
+	BookingID     uuid.UUID     `db:"booking_id"     json:"booking_id"`
+	UserID        uuid.UUID     `db:"user_id"        json:"user_id"`
+	Amount        Money         `db:"amount"         json:"amount"`
+	Status        PaymentStatus `db:"status"         json:"status"`
+	ProviderRef   *string       `db:"provider_ref"   json:"provider_ref,omitempty"`
+	FailureReason *string       `db:"failure_reason" json:"failure_reason,omitempty"`
+	CreatedAt     time.Time     `db:"created_at"     json:"created_at"`
+	UpdatedAt     time.Time     `db:"updated_at"     json:"updated_at"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromoDiscountType string
+
+const (
+	PromoDiscountPercent PromoDiscountType = "PERCENT"
+	PromoDiscountFixed   PromoDiscountType = "FIXED"
+)
+
+type PromoCode struct {
+	ID             uuid.UUID         `db:"id"                json:"id"`
+	Code           string            `db:"code"              json:"code"`
+	ConcertID      *uuid.UUID        `db:"concert_id"        json:"concert_id,omitempty"`
+	DiscountType   PromoDiscountType `db:"discount_type"     json:"discount_type"`
+	Value          int64             `db:"value"             json:"value"`
+	Currency       string            `db:"currency"          json:"currency,omitempty"`
+	MaxUses        *int              `db:"max_uses"          json:"max_uses,omitempty"`
+	MaxUsesPerUser *int              `db:"max_uses_per_user" json:"max_uses_per_user,omitempty"`
+	UsedCount      int               `db:"used_count"        json:"used_count"`
+	StartsAt       *time.Time        `db:"starts_at"         json:"starts_at,omitempty"`
+	EndsAt         *time.Time        `db:"ends_at"           json:"ends_at,omitempty"`
+	Active         bool              `db:"active"            json:"active"`
+	CreatedAt      time.Time         `db:"created_at"        json:"created_at"`
+}
+
+func (p *PromoCode) ActiveAt(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || now.Before(*p.EndsAt)
+}
+
+func (p *PromoCode) AppliesTo(concertID uuid.UUID) bool {
+	return p.ConcertID == nil || *p.ConcertID == concertID
+}
+
+func (p *PromoCode) Discount(price Money) (Money, error) {
+	discount := Money{Currency: price.Currency}
+	switch p.DiscountType {
+	case PromoDiscountPercent:
+		discount.Amount = price.Amount * p.Value / 100
+	case PromoDiscountFixed:
+		if p.Currency != price.Currency {
+			return Money{}, fmt.Errorf("%w: code is in %s, ticket is in %s",
+				ErrPromoCodeNotApplicable, p.Currency, price.Currency)
+		}
+		discount.Amount = p.Value
+	default:
+		return Money{}, fmt.Errorf("%w: unknown discount type %q", ErrInvalidPromoCode, p.DiscountType)
+	}
+
+	if discount.Amount >= price.Amount {
+		return Money{}, fmt.Errorf("%w: discount covers the whole ticket price", ErrPromoCodeNotApplicable)
+	}
+	return discount, nil
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefundStatus string
+
+const (
+	RefundStatusPending    RefundStatus = "PENDING"
+	RefundStatusProcessing RefundStatus = "PROCESSING"
+	RefundStatusSucceeded  RefundStatus = "SUCCEEDED"
+	RefundStatusFailed     RefundStatus = "FAILED"
+)
+
+const (
+	RefundReasonConcertCancelled = "CONCERT_CANCELLED"
+	RefundReasonCustomerRequest  = "CUSTOMER_REQUEST"
+	RefundReasonAdminIssued      = "ADMIN_ISSUED"
+)
+
+type Refund struct {
+	ID            uuid.UUID    `db:"id"             json:"id"`
+	BookingID     uuid.UUID    `db:"booking_id"     json:"booking_id"`
+	UserID        uuid.UUID    `db:"user_id"        json:"user_id"`
+	ConcertID     uuid.UUID    `db:"concert_id"     json:"concert_id"`
+	Amount        Money        `db:"amount"         json:"amount"`
+	Reason        string       `db:"reason"         json:"reason"`
+	Status        RefundStatus `db:"status"         json:"status"`
+	ProviderRef   *string      `db:"provider_ref"   json:"provider_ref,omitempty"`
+	FailureReason *string      `db:"failure_reason" json:"failure_reason,omitempty"`
+	CreatedAt     time.Time    `db:"created_at"     json:"created_at"`
+	ProcessedAt   *time.Time   `db:"processed_at"   json:"processed_at,omitempty"`
+}
+
+type RefundPolicy struct {
+	FullWindow     time.Duration
+	PartialWindow  time.Duration
+	PartialPercent int64
+}
+
+func (p RefundPolicy) Amount(price Money, concertDate, now time.Time) (Money, error) {
+	left := concertDate.Sub(now)
+	switch {
+	case left <= 0:
+		return Money{}, ErrConcertPassed
+	case left >= p.FullWindow:
+		return price, nil
+	case left >= p.PartialWindow && p.PartialPercent > 0:
+		return Money{Amount: price.Amount * p.PartialPercent / 100, Currency: price.Currency}, nil
+	default:
+		return Money{}, ErrRefundWindowClosed
+	}
+}
+
+type CancellationStatus string
+
+const (
+	CancellationStatusRunning   CancellationStatus = "RUNNING"
+	CancellationStatusCompleted CancellationStatus = "COMPLETED"
+)
+
+type ConcertCancellation struct {
+	ConcertID         uuid.UUID          `db:"concert_id"         json:"concert_id"`
+	Reason            string             `db:"reason"             json:"reason"`
+	Status            CancellationStatus `db:"status"             json:"status"`
+	BookingsCancelled int                `db:"bookings_cancelled" json:"bookings_cancelled"`
+	RefundsCreated    int                `db:"refunds_created"    json:"refunds_created"`
+	UsersNotified     int                `db:"users_notified"     json:"users_notified"`
+	CreatedAt         time.Time          `db:"created_at"         json:"created_at"`
+	CompletedAt       *time.Time         `db:"completed_at"       json:"completed_at,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SaleStatus string
+
+const (
+	SaleStatusScheduled SaleStatus = "SCHEDULED"
+	SaleStatusPresale   SaleStatus = "PRESALE"
+	SaleStatusOnSale    SaleStatus = "ON_SALE"
+	SaleStatusEnded     SaleStatus = "ENDED"
+)
+
+type Presale struct {
+	ID             uuid.UUID   `db:"id"               json:"id"`
+	ConcertID      uuid.UUID   `db:"concert_id"       json:"concert_id"`
+	Name           string      `db:"name"             json:"name"`
+	StartsAt       time.Time   `db:"starts_at"        json:"starts_at"`
+	EndsAt         time.Time   `db:"ends_at"          json:"ends_at"`
+	AccessCodeHash string      `db:"access_code_hash" json:"-"`
+	UserIDs        []uuid.UUID `db:"user_ids"         json:"user_ids"`
+	CreatedAt      time.Time   `db:"created_at"       json:"created_at"`
+}
+
+func (p *Presale) ActiveAt(now time.Time) bool {
+	return !now.Before(p.StartsAt) && now.Before(p.EndsAt)
+}
+
+func (c *Concert) SaleStatusAt(now time.Time, presales []Presale) SaleStatus {
+	switch c.Status {
+	case ConcertStatusDraft:
+		return SaleStatusScheduled
+	case ConcertStatusCancelled, ConcertStatusCompleted:
+		return SaleStatusEnded
+	}
+	if !now.Before(c.Date) || (c.SaleEndsAt != nil && !now.Before(*c.SaleEndsAt)) {
+		return SaleStatusEnded
+	}
+	if c.SaleStartsAt == nil || !now.Before(*c.SaleStartsAt) {
+		return SaleStatusOnSale
+	}
+	for _, p := range presales {
+		if p.ActiveAt(now) {
+			return SaleStatusPresale
+		}
+	}
+	return SaleStatusScheduled
+}
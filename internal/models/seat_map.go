@@ -0,0 +1,65 @@
+package models
+
+import "github.com/google/uuid"
+
+type SeatState uint8
+
+const (
+	SeatFree SeatState = iota
+	SeatHeld
+	SeatSold
+)
+
+type SeatMap []byte
+
+func NewSeatMap(totalSeats int) SeatMap {
+	return make(SeatMap, ((totalSeats+1)*2+7)/8)
+}
+
+func (m SeatMap) State(ordinal int) SeatState {
+	idx, shift := seatBitPosition(ordinal)
+	if ordinal < 0 || idx >= len(m) {
+		return SeatFree
+	}
+	return SeatState(m[idx]>>shift) & 0b11
+}
+
+func (m SeatMap) Set(ordinal int, state SeatState) {
+	idx, shift := seatBitPosition(ordinal)
+	if ordinal < 0 || idx >= len(m) {
+		return
+	}
+	m[idx] = m[idx]&^(0b11<<shift) | byte(state)<<shift
+}
+
+func (m SeatMap) Ranges(totalSeats int, state SeatState) []SeatRange {
+	ranges := []SeatRange{}
+	for seat := 1; seat <= totalSeats; seat++ {
+		if m.State(seat) != state {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1][1] == seat-1 {
+			ranges[n-1][1] = seat
+			continue
+		}
+		ranges = append(ranges, SeatRange{seat, seat})
+	}
+	return ranges
+}
+
+func seatBitPosition(ordinal int) (int, uint) {
+	offset := ordinal * 2
+	return offset / 8, uint(6 - offset%8)
+}
+
+type SeatRange [2]int
+
+type SeatAvailability struct {
+	ConcertID  uuid.UUID   `json:"concert_id"`
+	TotalSeats int         `json:"total_seats"`
+	Free       []SeatRange `json:"free,omitempty"`
+	Held       []SeatRange `json:"held,omitempty"`
+	Sold       []SeatRange `json:"sold,omitempty"`
+	Bitmap     string      `json:"bitmap,omitempty"`
+	Seats      SeatMap     `json:"-"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting WaitlistStatus = "WAITING"
+	WaitlistStatusOffered WaitlistStatus = "OFFERED"
+	WaitlistStatusClaimed WaitlistStatus = "CLAIMED"
+	WaitlistStatusExpired WaitlistStatus = "EXPIRED"
+	WaitlistStatusLeft    WaitlistStatus = "LEFT"
+)
+
+type WaitlistEntry struct {
+	ID             uuid.UUID      `db:"id"               json:"id"`
+	ConcertID      uuid.UUID      `db:"concert_id"       json:"concert_id"`
+	UserID         uuid.UUID      `db:"user_id"          json:"user_id"`
+	Status         WaitlistStatus `db:"status"           json:"status"`
+	Position       int64          `db:"position"         json:"position,omitempty"`
+	BookingID      *uuid.UUID     `db:"booking_id"       json:"booking_id,omitempty"`
+	OfferExpiresAt *time.Time     `db:"offer_expires_at" json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time      `db:"created_at"       json:"created_at"`
+}
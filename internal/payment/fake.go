@@ -0,0 +1,200 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/models"
+)
+
+const (
+	SignatureHeader = "X-Payment-Signature"
+
+	FakeOutcomeSucceed = "succeed"
+	FakeOutcomeDecline = "decline"
+
+	fakeEventSucceeded = "payment_intent.succeeded"
+	fakeEventFailed    = "payment_intent.failed"
+	fakeDeclineReason  = "card_declined"
+
+	fakeDeliveryAttempts = 3
+	fakeDeliveryTimeout  = 5 * time.Second
+)
+
+type fakeWebhook struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	IntentID      string `json:"intent_id"`
+	Reference     string `json:"reference"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+type FakeProvider struct {
+	logger     *slog.Logger
+	client     *http.Client
+	webhookURL string
+	secret     []byte
+	outcome    string
+	delay      time.Duration
+
+	mu      sync.Mutex
+	refunds map[string]RefundResult
+}
+
+func NewFakeProvider(
+	logger *slog.Logger,
+	webhookURL string,
+	secret string,
+	outcome string,
+	delay time.Duration,
+) *FakeProvider {
+	return &FakeProvider{
+		logger:     logger,
+		client:     &http.Client{Timeout: fakeDeliveryTimeout},
+		webhookURL: webhookURL,
+		secret:     []byte(secret),
+		outcome:    outcome,
+		delay:      delay,
+		refunds:    make(map[string]RefundResult),
+	}
+}
+
+func (p *FakeProvider) CreateIntent(_ context.Context, req IntentRequest) (*Intent, error) {
+	hook := fakeWebhook{
+		ID:        "evt_" + uuid.NewString(),
+		Type:      fakeEventSucceeded,
+		IntentID:  "pi_" + uuid.NewString(),
+		Reference: req.Reference,
+		Amount:    req.Amount.Amount,
+		Currency:  req.Amount.Currency,
+	}
+	if p.outcome == FakeOutcomeDecline {
+		hook.Type = fakeEventFailed
+		hook.FailureReason = fakeDeclineReason
+	}
+
+	payload, err := json.Marshal(hook)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrPaymentProvider, err)
+	}
+
+	time.AfterFunc(p.delay, func() {
+		p.deliver(hook.IntentID, payload)
+	})
+
+	return &Intent{ProviderRef: hook.IntentID}, nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, headers http.Header) (*WebhookEvent, error) {
+	signature, err := hex.DecodeString(headers.Get(SignatureHeader))
+	if err != nil || !hmac.Equal(signature, p.sign(payload)) {
+		return nil, fmt.Errorf("%w: bad signature", models.ErrInvalidWebhook)
+	}
+
+	var hook fakeWebhook
+	if err := json.Unmarshal(payload, &hook); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidWebhook, err)
+	}
+
+	evt := &WebhookEvent{
+		ProviderRef:   hook.IntentID,
+		Reference:     hook.Reference,
+		Amount:        models.Money{Amount: hook.Amount, Currency: hook.Currency},
+		FailureReason: hook.FailureReason,
+	}
+	switch hook.Type {
+	case fakeEventSucceeded:
+		evt.Status = models.PaymentStatusSucceeded
+	case fakeEventFailed:
+		evt.Status = models.PaymentStatusFailed
+	default:
+		return nil, fmt.Errorf("%w: unknown event type %q", models.ErrInvalidWebhook, hook.Type)
+	}
+
+	return evt, nil
+}
+
+func (p *FakeProvider) Refund(_ context.Context, req RefundRequest) (*RefundResult, error) {
+	if req.PaymentRef == "" {
+		return nil, fmt.Errorf("%w: refund %s has no payment reference", models.ErrPaymentProvider, req.Reference)
+	}
+	if req.Amount.Amount <= 0 {
+		return nil, fmt.Errorf("%w: refund %s has no amount", models.ErrPaymentProvider, req.Reference)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result, ok := p.refunds[req.Reference]
+	if !ok {
+		result = RefundResult{ProviderRef: "re_" + uuid.NewString()}
+		p.refunds[req.Reference] = result
+	}
+
+	return &result, nil
+}
+
+func (p *FakeProvider) deliver(intentID string, payload []byte) {
+	signature := hex.EncodeToString(p.sign(payload))
+
+	for attempt := 1; attempt <= fakeDeliveryAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(time.Duration(attempt-1) * time.Second)
+		}
+
+		err := p.post(payload, signature)
+		if err == nil {
+			return
+		}
+
+		p.logger.Warn("failed to deliver payment webhook",
+			"intent_id", intentID,
+			"attempt", attempt,
+			"error", err,
+		)
+	}
+
+	p.logger.Error("payment webhook dropped", "intent_id", intentID)
+}
+
+func (p *FakeProvider) post(payload []byte, signature string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), fakeDeliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *FakeProvider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,41 @@
+package payment
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/yohnnn/booking_service/internal/models"
+)
+
+type IntentRequest struct {
+	Reference string
+	Amount    models.Money
+}
+
+type Intent struct {
+	ProviderRef string
+}
+
+type RefundRequest struct {
+	Reference  string
+	PaymentRef string
+	Amount     models.Money
+}
+
+type RefundResult struct {
+	ProviderRef string
+}
+
+type WebhookEvent struct {
+	ProviderRef   string
+	Reference     string
+	Status        models.PaymentStatus
+	Amount        models.Money
+	FailureReason string
+}
+
+type PaymentProvider interface {
+	CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error)
+	ParseWebhook(payload []byte, headers http.Header) (*WebhookEvent, error)
+	Refund(ctx context.Context, req RefundRequest) (*RefundResult, error)
+}
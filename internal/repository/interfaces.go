@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,15 +13,139 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, role models.UserRole) error
 }
 
 type ConcertRepository interface {
-	GetAll(ctx context.Context) ([]models.Concert, error)
+	List(
+		ctx context.Context,
+		filter models.ConcertFilter,
+		after *models.ConcertCursor,
+		limit int,
+	) ([]models.Concert, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Concert, error)
-	DecrementSeats(ctx context.Context, id uuid.UUID) error
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Concert, error)
+	Create(ctx context.Context, concert *models.Concert) error
+	Update(ctx context.Context, concert *models.Concert) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	HasBookings(ctx context.Context, id uuid.UUID) (bool, error)
+	DecrementSeats(ctx context.Context, id uuid.UUID, count int) error
+	IncrementSeats(ctx context.Context, id uuid.UUID, count int) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.ConcertStatus) error
+	CompletePast(ctx context.Context, limit int) (int, error)
 }
 
 type BookingRepository interface {
 	Create(ctx context.Context, booking *models.Booking) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Booking, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.Booking, error)
+	GetActiveByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.Booking, error)
+	Cancel(ctx context.Context, id uuid.UUID, from models.BookingStatus) error
+	Confirm(ctx context.Context, id uuid.UUID) error
+	ExpireHolds(ctx context.Context, limit int) ([]models.Booking, error)
+	CancelActiveByConcert(ctx context.Context, concertID uuid.UUID, userLimit int) ([]models.Booking, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, msg *models.OutboxMessage) error
+	Claim(ctx context.Context, limit int, leaseUntil time.Time) ([]models.OutboxMessage, error)
+	MarkSent(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string, nextAttemptAt time.Time) error
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+}
+
+type VenueRepository interface {
+	Create(ctx context.Context, venue *models.Venue) error
+	GetAll(ctx context.Context) ([]models.Venue, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Venue, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Venue, error)
+	GetLayout(ctx context.Context, venueID uuid.UUID) ([]models.VenueSection, error)
+	CreateSection(ctx context.Context, section *models.VenueSection) error
+}
+
+type TicketTierRepository interface {
+	Create(ctx context.Context, tier *models.TicketTier) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.TicketTier, error)
+	GetByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.TicketTier, error)
+	CountSectionSeats(ctx context.Context, venueID uuid.UUID, sectionIDs []uuid.UUID) (int, error)
+	ResolveSeats(ctx context.Context, concertID uuid.UUID, seats []int) ([]models.SeatAssignment, error)
+	ResolveSeatIDs(ctx context.Context, concertID uuid.UUID, seatIDs []uuid.UUID) ([]models.SeatAssignment, error)
+	FindSeatCandidates(
+		ctx context.Context,
+		concertID uuid.UUID,
+		tierID *uuid.UUID,
+		limit int,
+	) ([]models.SeatCandidate, error)
+	LockFreeSeats(ctx context.Context, concertID uuid.UUID, seatIDs []uuid.UUID) ([]models.SeatAssignment, error)
+	DeleteByConcertID(ctx context.Context, concertID uuid.UUID) error
+	Decrement(ctx context.Context, id uuid.UUID, count int) error
+	Increment(ctx context.Context, id uuid.UUID, count int) error
+}
+
+type PresaleRepository interface {
+	Create(ctx context.Context, presale *models.Presale) error
+	GetByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.Presale, error)
+}
+
+type CancellationRepository interface {
+	Create(ctx context.Context, cancellation *models.ConcertCancellation) error
+	GetByConcertID(ctx context.Context, concertID uuid.UUID) (*models.ConcertCancellation, error)
+	NextRunning(ctx context.Context) (*models.ConcertCancellation, error)
+	RecordProgress(ctx context.Context, concertID uuid.UUID, bookings, refunds, users int) error
+	Complete(ctx context.Context, concertID uuid.UUID) error
+}
+
+type RefundRepository interface {
+	Create(ctx context.Context, refund *models.Refund) error
+	CreateBatch(ctx context.Context, refunds []models.Refund) ([]models.Refund, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Refund, error)
+	GetByUser(ctx context.Context, userID uuid.UUID) ([]models.Refund, error)
+	GetByConcert(ctx context.Context, concertID uuid.UUID) ([]models.Refund, error)
+	Claim(ctx context.Context, limit int, staleBefore time.Time) ([]models.Refund, error)
+	Finish(ctx context.Context, id uuid.UUID, status models.RefundStatus, providerRef, failureReason *string) error
+	Retry(ctx context.Context, id uuid.UUID) error
+}
+
+type WaitlistRepository interface {
+	Create(ctx context.Context, entry *models.WaitlistEntry) error
+	GetActive(ctx context.Context, concertID, userID uuid.UUID) (*models.WaitlistEntry, error)
+	Leave(ctx context.Context, concertID, userID uuid.UUID) error
+	NextWaiting(ctx context.Context, concertID uuid.UUID, limit int) ([]models.WaitlistEntry, error)
+	MarkOffered(ctx context.Context, id, bookingID uuid.UUID, expiresAt time.Time) error
+	MarkClaimed(ctx context.Context, bookingID uuid.UUID) error
+	ExpireOffers(ctx context.Context, bookingIDs []uuid.UUID) error
+	ExpireWaiting(ctx context.Context, concertID uuid.UUID) error
+}
+
+type PromoCodeRepository interface {
+	Create(ctx context.Context, promo *models.PromoCode) error
+	GetAll(ctx context.Context) ([]models.PromoCode, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PromoCode, error)
+	GetByCodeForUpdate(ctx context.Context, code string) (*models.PromoCode, error)
+	Deactivate(ctx context.Context, id uuid.UUID) error
+	CountRedemptions(ctx context.Context, promoID, userID uuid.UUID) (int, error)
+	Redeem(ctx context.Context, promoID, userID uuid.UUID, bookingIDs []uuid.UUID) error
+	Release(ctx context.Context, bookingIDs []uuid.UUID) error
+}
+
+type PaymentRepository interface {
+	Create(ctx context.Context, intent *models.PaymentIntent) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PaymentIntent, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.PaymentIntent, error)
+	GetActiveByBooking(ctx context.Context, bookingID uuid.UUID) (*models.PaymentIntent, error)
+	SetProviderRef(ctx context.Context, id uuid.UUID, providerRef string) error
+	Finish(ctx context.Context, id uuid.UUID, status models.PaymentStatus, failureReason *string) error
+}
+
+type LedgerRepository interface {
+	Post(ctx context.Context, txs []models.LedgerTransaction) error
+	ConcertBalances(ctx context.Context, concertID uuid.UUID) ([]models.LedgerBalance, error)
+	Totals(ctx context.Context) ([]models.LedgerTotal, error)
+	UnbalancedTransactions(ctx context.Context) ([]uuid.UUID, error)
 }
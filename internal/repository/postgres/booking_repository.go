@@ -2,10 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/yohnnn/booking_service/internal/models"
@@ -22,18 +24,36 @@ func NewBookingRepo(db *pgxpool.Pool) *BookingRepo {
 
 func (r *BookingRepo) Create(ctx context.Context, booking *models.Booking) error {
 	query := `
-		INSERT INTO bookings (user_id, concert_id, seat_number, status)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, created_at
+		INSERT INTO bookings (
+			user_id, concert_id, seat_id, seat_number, tier_id, price, currency,
+			face_value, discount, service_fee, vat, promo_code_id, status, expires_at
+		)
+		SELECT $1, c.id, s.id, s.ordinal, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		FROM concerts c
+		JOIN venue_seats s ON s.venue_id = c.venue_id AND s.ordinal = $3
+		WHERE c.id = $2
+		RETURNING id, seat_id, created_at
 	`
 	err := tx.Executor(ctx, r.db).QueryRow(ctx, query,
 		booking.UserID,
 		booking.ConcertID,
 		booking.SeatNumber,
+		booking.TierID,
+		booking.Price.Amount,
+		booking.Price.Currency,
+		booking.FaceValue.Amount,
+		booking.Discount.Amount,
+		booking.ServiceFee.Amount,
+		booking.VAT.Amount,
+		booking.PromoCodeID,
 		booking.Status,
-	).Scan(&booking.ID, &booking.CreatedAt)
+		booking.ExpiresAt,
+	).Scan(&booking.ID, &booking.SeatID, &booking.CreatedAt)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrInvalidSeat
+		}
 		if IsUnique(err) {
 			return models.ErrAlreadyExists
 		}
@@ -43,9 +63,37 @@ func (r *BookingRepo) Create(ctx context.Context, booking *models.Booking) error
 	return nil
 }
 
+func (r *BookingRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Booking, error) {
+	query := `
+		SELECT id, user_id, concert_id, seat_id, seat_number, tier_id,
+			price AS "price.amount", currency AS "price.currency",
+			face_value AS "face_value.amount", currency AS "face_value.currency",
+			discount AS "discount.amount", currency AS "discount.currency",
+			service_fee AS "service_fee.amount", currency AS "service_fee.currency",
+			vat AS "vat.amount", currency AS "vat.currency", promo_code_id,
+			status, expires_at, created_at
+		FROM bookings
+		WHERE id = $1
+	`
+	var booking models.Booking
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &booking, query, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking by id: %w", err)
+	}
+	return &booking, nil
+}
+
 func (r *BookingRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.Booking, error) {
 	query := `
-		SELECT id, user_id, concert_id, seat_number, status, created_at
+		SELECT id, user_id, concert_id, seat_id, seat_number, tier_id,
+			price AS "price.amount", currency AS "price.currency",
+			face_value AS "face_value.amount", currency AS "face_value.currency",
+			discount AS "discount.amount", currency AS "discount.currency",
+			service_fee AS "service_fee.amount", currency AS "service_fee.currency",
+			vat AS "vat.amount", currency AS "vat.currency", promo_code_id,
+			status, expires_at, created_at
 		FROM bookings
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -56,3 +104,127 @@ func (r *BookingRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]mode
 	}
 	return bookings, nil
 }
+
+func (r *BookingRepo) GetActiveByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.Booking, error) {
+	query := `
+		SELECT seat_number, status
+		FROM bookings
+		WHERE concert_id = $1 AND status <> $2
+	`
+	var bookings []models.Booking
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &bookings, query,
+		concertID,
+		models.BookingStatusCancelled,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get concert bookings: %w", err)
+	}
+	return bookings, nil
+}
+
+func (r *BookingRepo) Cancel(ctx context.Context, id uuid.UUID, from models.BookingStatus) error {
+	query := `
+		UPDATE bookings
+		SET status = $2, expires_at = NULL
+		WHERE id = $1 AND status = $3
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, models.BookingStatusCancelled, from)
+	if err != nil {
+		return fmt.Errorf("failed to cancel booking: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrBookingChanged
+	}
+	return nil
+}
+
+func (r *BookingRepo) Confirm(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE bookings
+		SET status = $2, expires_at = NULL
+		WHERE id = $1 AND status = $3 AND expires_at > NOW()
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query,
+		id,
+		models.BookingStatusConfirmed,
+		models.BookingStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to confirm booking: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrHoldExpired
+	}
+	return nil
+}
+
+func (r *BookingRepo) ExpireHolds(ctx context.Context, limit int) ([]models.Booking, error) {
+	query := `
+		UPDATE bookings
+		SET status = $1, expires_at = NULL
+		WHERE id IN (
+			SELECT id
+			FROM bookings
+			WHERE status = $2 AND expires_at <= NOW()
+			ORDER BY expires_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, concert_id, seat_id, seat_number, tier_id,
+			price AS "price.amount", currency AS "price.currency",
+			face_value AS "face_value.amount", currency AS "face_value.currency",
+			discount AS "discount.amount", currency AS "discount.currency",
+			service_fee AS "service_fee.amount", currency AS "service_fee.currency",
+			vat AS "vat.amount", currency AS "vat.currency", promo_code_id,
+			status, expires_at, created_at
+	`
+	var bookings []models.Booking
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &bookings, query,
+		models.BookingStatusCancelled,
+		models.BookingStatusPending,
+		limit,
+	); err != nil {
+		return nil, fmt.Errorf("failed to expire booking holds: %w", err)
+	}
+	return bookings, nil
+}
+
+func (r *BookingRepo) CancelActiveByConcert(
+	ctx context.Context,
+	concertID uuid.UUID,
+	userLimit int,
+) ([]models.Booking, error) {
+	query := `
+		WITH users AS (
+			SELECT DISTINCT user_id
+			FROM bookings
+			WHERE concert_id = $1 AND status <> $2
+			ORDER BY user_id
+			LIMIT $3
+		)
+		UPDATE bookings b
+		SET status = $2, expires_at = NULL
+		FROM (
+			SELECT id, status
+			FROM bookings
+			WHERE concert_id = $1 AND status <> $2 AND user_id IN (SELECT user_id FROM users)
+			FOR UPDATE
+		) prev
+		WHERE b.id = prev.id
+		RETURNING b.id, b.user_id, b.concert_id, b.seat_id, b.seat_number, b.tier_id,
+			b.price AS "price.amount", b.currency AS "price.currency",
+			b.face_value AS "face_value.amount", b.currency AS "face_value.currency",
+			b.discount AS "discount.amount", b.currency AS "discount.currency",
+			b.service_fee AS "service_fee.amount", b.currency AS "service_fee.currency",
+			b.vat AS "vat.amount", b.currency AS "vat.currency", b.promo_code_id,
+			prev.status, b.expires_at, b.created_at
+	`
+	var bookings []models.Booking
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &bookings, query,
+		concertID,
+		models.BookingStatusCancelled,
+		userLimit,
+	); err != nil {
+		return nil, fmt.Errorf("failed to cancel concert bookings: %w", err)
+	}
+	return bookings, nil
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+type CancellationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCancellationRepo(db *pgxpool.Pool) *CancellationRepo {
+	return &CancellationRepo{db: db}
+}
+
+func (r *CancellationRepo) Create(ctx context.Context, cancellation *models.ConcertCancellation) error {
+	query := `
+		INSERT INTO concert_cancellations (concert_id, reason, status)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`
+	err := tx.Executor(ctx, r.db).QueryRow(ctx, query,
+		cancellation.ConcertID,
+		cancellation.Reason,
+		cancellation.Status,
+	).Scan(&cancellation.CreatedAt)
+	if err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create concert cancellation: %w", err)
+	}
+	return nil
+}
+
+func (r *CancellationRepo) GetByConcertID(
+	ctx context.Context,
+	concertID uuid.UUID,
+) (*models.ConcertCancellation, error) {
+	query := `
+		SELECT concert_id, reason, status, bookings_cancelled, refunds_created, users_notified,
+			created_at, completed_at
+		FROM concert_cancellations
+		WHERE concert_id = $1
+	`
+	var cancellation models.ConcertCancellation
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &cancellation, query, concertID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get concert cancellation: %w", err)
+	}
+	return &cancellation, nil
+}
+
+func (r *CancellationRepo) NextRunning(ctx context.Context) (*models.ConcertCancellation, error) {
+	query := `
+		SELECT concert_id, reason, status, bookings_cancelled, refunds_created, users_notified,
+			created_at, completed_at
+		FROM concert_cancellations
+		WHERE status = $1
+		ORDER BY created_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+	var cancellation models.ConcertCancellation
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &cancellation, query,
+		models.CancellationStatusRunning,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get running concert cancellation: %w", err)
+	}
+	return &cancellation, nil
+}
+
+func (r *CancellationRepo) RecordProgress(
+	ctx context.Context,
+	concertID uuid.UUID,
+	bookings, refunds, users int,
+) error {
+	query := `
+		UPDATE concert_cancellations
+		SET bookings_cancelled = bookings_cancelled + $2,
+			refunds_created = refunds_created + $3,
+			users_notified = users_notified + $4
+		WHERE concert_id = $1
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query, concertID, bookings, refunds, users); err != nil {
+		return fmt.Errorf("failed to record cancellation progress: %w", err)
+	}
+	return nil
+}
+
+func (r *CancellationRepo) Complete(ctx context.Context, concertID uuid.UUID) error {
+	query := `
+		UPDATE concert_cancellations
+		SET status = $2, completed_at = NOW()
+		WHERE concert_id = $1
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query, concertID, models.CancellationStatusCompleted); err != nil {
+		return fmt.Errorf("failed to complete concert cancellation: %w", err)
+	}
+	return nil
+}
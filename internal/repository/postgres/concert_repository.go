@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/google/uuid"
@@ -22,27 +23,123 @@ func NewConcertRepo(db *pgxpool.Pool) *ConcertRepo {
 	return &ConcertRepo{db: db}
 }
 
-func (r *ConcertRepo) GetAll(ctx context.Context) ([]models.Concert, error) {
+var concertSortColumns = map[models.ConcertSort]struct {
+	column string
+	cast   string
+	desc   bool
+}{
+	models.ConcertSortDate:      {column: "c.date", cast: "timestamptz"},
+	models.ConcertSortDateDesc:  {column: "c.date", cast: "timestamptz", desc: true},
+	models.ConcertSortPrice:     {column: "c.price", cast: "bigint"},
+	models.ConcertSortPriceDesc: {column: "c.price", cast: "bigint", desc: true},
+	models.ConcertSortName:      {column: "c.name", cast: "text"},
+	models.ConcertSortNameDesc:  {column: "c.name", cast: "text", desc: true},
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *ConcertRepo) List(
+	ctx context.Context,
+	filter models.ConcertFilter,
+	after *models.ConcertCursor,
+	limit int,
+) ([]models.Concert, error) {
+	sort, ok := concertSortColumns[filter.Sort]
+	if !ok {
+		return nil, models.ErrInvalidFilter
+	}
+
+	var (
+		conditions []string
+		args       []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.DateFrom != nil {
+		conditions = append(conditions, "c.date >= "+arg(*filter.DateFrom))
+	}
+	if filter.DateTo != nil {
+		conditions = append(conditions, "c.date <= "+arg(*filter.DateTo))
+	}
+	if filter.VenueID != nil {
+		conditions = append(conditions, "c.venue_id = "+arg(*filter.VenueID))
+	}
+	if filter.Currency != "" {
+		conditions = append(conditions, "c.currency = "+arg(filter.Currency))
+	}
+	if filter.PriceMin != nil {
+		conditions = append(conditions, "c.price >= "+arg(*filter.PriceMin))
+	}
+	if filter.PriceMax != nil {
+		conditions = append(conditions, "c.price <= "+arg(*filter.PriceMax))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		conditions = append(conditions, "c.status = ANY("+arg(statuses)+")")
+	}
+	if filter.OnlyAvailable {
+		conditions = append(conditions, "c.available_seats > 0")
+	}
+	if filter.Search != "" {
+		conditions = append(conditions, "c.name ILIKE '%' || "+arg(likeEscaper.Replace(filter.Search))+" || '%'")
+	}
+
+	direction, comparison := "ASC", ">"
+	if sort.desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if after != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"(%s, c.id) %s (%s::text::%s, %s)",
+			sort.column, comparison, arg(after.Value), sort.cast, arg(after.ID),
+		))
+	}
+
 	query := `
-		SELECT id, name, place, date, price, total_seats, available_seats, created_at
-		FROM concerts
-		ORDER BY date ASC
+		SELECT c.id, c.name, c.venue_id, v.name AS venue_name, v.timezone AS venue_timezone, c.date,
+			c.price AS "price.amount", c.currency AS "price.currency", c.status,
+			c.total_seats, c.available_seats, c.sale_starts_at, c.sale_ends_at, c.created_at
+		FROM concerts c
+		JOIN venues v ON v.id = c.venue_id
 	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, c.id %s LIMIT %s", sort.column, direction, direction, arg(limit))
+
 	var concerts []models.Concert
 
-	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &concerts, query); err != nil {
-		return nil, fmt.Errorf("failed to get all concerts: %w", err)
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &concerts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list concerts: %w", err)
 	}
 
 	return concerts, nil
 }
 
 func (r *ConcertRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Concert, error) {
+	return r.get(ctx, id, "")
+}
+
+func (r *ConcertRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Concert, error) {
+	return r.get(ctx, id, "FOR UPDATE OF c")
+}
+
+func (r *ConcertRepo) get(ctx context.Context, id uuid.UUID, lock string) (*models.Concert, error) {
 	query := `
-		SELECT id, name, place, date, price, total_seats, available_seats, created_at
-		FROM concerts
-		WHERE id = $1
-	`
+		SELECT c.id, c.name, c.venue_id, v.name AS venue_name, v.timezone AS venue_timezone, c.date,
+			c.price AS "price.amount", c.currency AS "price.currency", c.status,
+			c.total_seats, c.available_seats, c.sale_starts_at, c.sale_ends_at, c.created_at
+		FROM concerts c
+		JOIN venues v ON v.id = c.venue_id
+		WHERE c.id = $1
+	` + lock
 	var concert models.Concert
 
 	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &concert, query, id); err != nil {
@@ -55,18 +152,180 @@ func (r *ConcertRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Concer
 	return &concert, nil
 }
 
-func (r *ConcertRepo) DecrementSeats(ctx context.Context, id uuid.UUID) error {
+func (r *ConcertRepo) Create(ctx context.Context, concert *models.Concert) error {
+	query := `
+		INSERT INTO concerts (
+			name, venue_id, date, price, currency, status, total_seats, available_seats, sale_starts_at, sale_ends_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7, $8, $9)
+		RETURNING id, available_seats, created_at
+	`
+	if err := tx.Executor(ctx, r.db).QueryRow(ctx, query,
+		concert.Name,
+		concert.VenueID,
+		concert.Date,
+		concert.Price.Amount,
+		concert.Price.Currency,
+		concert.Status,
+		concert.TotalSeats,
+		concert.SaleStartsAt,
+		concert.SaleEndsAt,
+	).Scan(&concert.ID, &concert.AvailableSeats, &concert.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create concert: %w", err)
+	}
+	return nil
+}
+
+func (r *ConcertRepo) Update(ctx context.Context, concert *models.Concert) error {
+	query := `
+		UPDATE concerts
+		SET name = $2,
+			venue_id = $3,
+			date = $4,
+			price = $5,
+			currency = $6,
+			available_seats = available_seats + ($7 - total_seats),
+			total_seats = $7,
+			sale_starts_at = $8,
+			sale_ends_at = $9,
+			status = CASE
+				WHEN status NOT IN ('ON_SALE', 'SOLD_OUT') THEN status
+				WHEN available_seats + ($7 - total_seats) = 0 THEN 'SOLD_OUT'
+				ELSE 'ON_SALE'
+			END
+		WHERE id = $1
+		  AND total_seats - available_seats <= $7
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM bookings b
+			WHERE b.concert_id = $1 AND b.seat_number > $7 AND b.status <> $10
+		  )
+		RETURNING available_seats, status, created_at
+	`
+	err := tx.Executor(ctx, r.db).QueryRow(ctx, query,
+		concert.ID,
+		concert.Name,
+		concert.VenueID,
+		concert.Date,
+		concert.Price.Amount,
+		concert.Price.Currency,
+		concert.TotalSeats,
+		concert.SaleStartsAt,
+		concert.SaleEndsAt,
+		models.BookingStatusCancelled,
+	).Scan(&concert.AvailableSeats, &concert.Status, &concert.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrSeatsBelowBooked
+		}
+		return fmt.Errorf("failed to update concert: %w", err)
+	}
+	return nil
+}
+
+func (r *ConcertRepo) HasBookings(ctx context.Context, id uuid.UUID) (bool, error) {
 	query := `
-		UPDATE concerts 
-		SET available_seats = available_seats - 1 
-		WHERE id = $1 AND available_seats > 0
+		SELECT EXISTS (SELECT 1 FROM bookings WHERE concert_id = $1)
+	`
+	var exists bool
+	if err := tx.Executor(ctx, r.db).QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check concert bookings: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *ConcertRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM concerts
+		WHERE id = $1
 	`
 	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return models.ErrConcertHasBookings
+		}
+		return fmt.Errorf("failed to delete concert: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ConcertRepo) DecrementSeats(ctx context.Context, id uuid.UUID, count int) error {
+	query := `
+		UPDATE concerts
+		SET available_seats = available_seats - $2,
+			status = CASE WHEN status = 'ON_SALE' AND available_seats = $2 THEN 'SOLD_OUT' ELSE status END
+		WHERE id = $1 AND status = 'ON_SALE' AND available_seats >= $2
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, count)
 	if err != nil {
 		return fmt.Errorf("failed to decrement seats: %w", err)
 	}
+	if res.RowsAffected() > 0 {
+		return nil
+	}
+
+	var status models.ConcertStatus
+	err = tx.Executor(ctx, r.db).QueryRow(ctx, `SELECT status FROM concerts WHERE id = $1`, id).Scan(&status)
+	if err != nil {
+		return fmt.Errorf("failed to get concert status: %w", err)
+	}
+	if status != models.ConcertStatusOnSale {
+		return fmt.Errorf("%w: %s", models.ErrConcertNotOnSale, status)
+	}
+	return models.ErrNoSeats
+}
+
+func (r *ConcertRepo) IncrementSeats(ctx context.Context, id uuid.UUID, count int) error {
+	query := `
+		UPDATE concerts
+		SET available_seats = available_seats + $2,
+			status = CASE WHEN status = 'SOLD_OUT' THEN 'ON_SALE' ELSE status END
+		WHERE id = $1 AND available_seats + $2 <= total_seats
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, count)
+	if err != nil {
+		return fmt.Errorf("failed to increment seats: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrSeatsOverflow
+	}
+	return nil
+}
+
+func (r *ConcertRepo) UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.ConcertStatus) error {
+	query := `
+		UPDATE concerts
+		SET status = $3
+		WHERE id = $1 AND status = $2
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to update concert status: %w", err)
+	}
 	if res.RowsAffected() == 0 {
-		return models.ErrNoSeats
+		return models.ErrInvalidStatusTransition
 	}
 	return nil
 }
+
+func (r *ConcertRepo) CompletePast(ctx context.Context, limit int) (int, error) {
+	query := `
+		UPDATE concerts
+		SET status = 'COMPLETED'
+		WHERE id IN (
+			SELECT id FROM concerts
+			WHERE status IN ('ON_SALE', 'SOLD_OUT') AND date <= NOW()
+			ORDER BY date
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete past concerts: %w", err)
+	}
+	return int(res.RowsAffected()), nil
+}
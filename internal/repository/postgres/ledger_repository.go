@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+type LedgerRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLedgerRepo(db *pgxpool.Pool) *LedgerRepo {
+	return &LedgerRepo{db: db}
+}
+
+func (r *LedgerRepo) Post(ctx context.Context, txs []models.LedgerTransaction) error {
+	exec := tx.Executor(ctx, r.db)
+
+	for i := range txs {
+		t := &txs[i]
+
+		query := `
+			INSERT INTO ledger_transactions (type, concert_id, booking_id, reference_id)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at
+		`
+		err := exec.QueryRow(ctx, query,
+			t.Type,
+			t.ConcertID,
+			t.BookingID,
+			t.ReferenceID,
+		).Scan(&t.ID, &t.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create ledger transaction: %w", err)
+		}
+
+		accounts := make([]string, len(t.Entries))
+		directions := make([]string, len(t.Entries))
+		amounts := make([]int64, len(t.Entries))
+		currencies := make([]string, len(t.Entries))
+		for j, e := range t.Entries {
+			accounts[j] = string(e.Account)
+			directions[j] = string(e.Direction)
+			amounts[j] = e.Amount.Amount
+			currencies[j] = e.Amount.Currency
+		}
+
+		query = `
+			INSERT INTO ledger_entries (transaction_id, concert_id, account, direction, amount, currency)
+			SELECT $1, $2, acc, dir, amt, cur
+			FROM unnest($3::text[], $4::text[], $5::bigint[], $6::text[]) AS e(acc, dir, amt, cur)
+		`
+		_, err = exec.Exec(ctx, query, t.ID, t.ConcertID, accounts, directions, amounts, currencies)
+		if err != nil {
+			return fmt.Errorf("failed to create ledger entries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *LedgerRepo) ConcertBalances(ctx context.Context, concertID uuid.UUID) ([]models.LedgerBalance, error) {
+	query := `
+		SELECT account, currency,
+			COALESCE(SUM(amount) FILTER (WHERE direction = $2), 0) AS debit,
+			COALESCE(SUM(amount) FILTER (WHERE direction = $3), 0) AS credit,
+			COALESCE(SUM(CASE WHEN direction = $2 THEN amount ELSE -amount END), 0) AS balance
+		FROM ledger_entries
+		WHERE concert_id = $1
+		GROUP BY account, currency
+		ORDER BY account, currency
+	`
+	var balances []models.LedgerBalance
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &balances, query,
+		concertID,
+		models.LedgerDebit,
+		models.LedgerCredit,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get ledger balances: %w", err)
+	}
+	return balances, nil
+}
+
+func (r *LedgerRepo) Totals(ctx context.Context) ([]models.LedgerTotal, error) {
+	query := `
+		SELECT currency,
+			COALESCE(SUM(amount) FILTER (WHERE direction = $1), 0) AS debit,
+			COALESCE(SUM(amount) FILTER (WHERE direction = $2), 0) AS credit
+		FROM ledger_entries
+		GROUP BY currency
+		ORDER BY currency
+	`
+	var totals []models.LedgerTotal
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &totals, query,
+		models.LedgerDebit,
+		models.LedgerCredit,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get ledger totals: %w", err)
+	}
+	return totals, nil
+}
+
+func (r *LedgerRepo) UnbalancedTransactions(ctx context.Context) ([]uuid.UUID, error) {
+	query := `
+		SELECT t.id
+		FROM ledger_transactions t
+		LEFT JOIN ledger_entries e ON e.transaction_id = t.id
+		GROUP BY t.id
+		HAVING COUNT(e.id) < 2
+			OR COUNT(DISTINCT e.currency) > 1
+			OR COALESCE(SUM(CASE WHEN e.direction = $1 THEN e.amount ELSE -e.amount END), 0) <> 0
+		ORDER BY t.id
+	`
+	var ids []uuid.UUID
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &ids, query, models.LedgerDebit); err != nil {
+		return nil, fmt.Errorf("failed to find unbalanced ledger transactions: %w", err)
+	}
+	return ids, nil
+}
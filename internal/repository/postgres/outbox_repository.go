@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+type OutboxRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepo(db *pgxpool.Pool) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+func (r *OutboxRepo) Create(ctx context.Context, msg *models.OutboxMessage) error {
+	query := `
+		INSERT INTO outbox (event_type, event_key, payload)
+		VALUES ($1, $2, $3)
+		RETURNING id, next_attempt_at, created_at
+	`
+	if err := tx.Executor(ctx, r.db).
+		QueryRow(ctx, query, msg.EventType, msg.EventKey, msg.Payload).
+		Scan(&msg.ID, &msg.NextAttemptAt, &msg.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create outbox message: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepo) Claim(ctx context.Context, limit int, leaseUntil time.Time) ([]models.OutboxMessage, error) {
+	query := `
+		UPDATE outbox
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id
+			FROM outbox
+			WHERE sent_at IS NULL AND next_attempt_at <= NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, event_key, payload, attempts, last_error, next_attempt_at, sent_at, created_at
+	`
+	var messages []models.OutboxMessage
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &messages, query, limit, leaseUntil); err != nil {
+		return nil, fmt.Errorf("failed to claim pending outbox messages: %w", err)
+	}
+	slices.SortFunc(messages, func(a, b models.OutboxMessage) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return messages, nil
+}
+
+func (r *OutboxRepo) MarkSent(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE outbox
+		SET sent_at = NOW(), attempts = attempts + 1, last_error = NULL
+		WHERE id = $1
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox message sent: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id uuid.UUID, reason string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, reason, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+const paymentIntentColumns = `
+	id, booking_id, user_id, amount AS "amount.amount", currency AS "amount.currency",
+	status, provider_ref, failure_reason, created_at, updated_at
+`
+
+type PaymentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPaymentRepo(db *pgxpool.Pool) *PaymentRepo {
+	return &PaymentRepo{db: db}
+}
+
+func (r *PaymentRepo) Create(ctx context.Context, intent *models.PaymentIntent) error {
+	query := `
+		INSERT INTO payment_intents (booking_id, user_id, amount, currency, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	err := tx.Executor(ctx, r.db).QueryRow(ctx, query,
+		intent.BookingID,
+		intent.UserID,
+		intent.Amount.Amount,
+		intent.Amount.Currency,
+		intent.Status,
+	).Scan(&intent.ID, &intent.CreatedAt, &intent.UpdatedAt)
+	if err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create payment intent: %w", err)
+	}
+	return nil
+}
+
+func (r *PaymentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.PaymentIntent, error) {
+	query := `SELECT ` + paymentIntentColumns + ` FROM payment_intents WHERE id = $1`
+	return r.get(ctx, query, id)
+}
+
+func (r *PaymentRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.PaymentIntent, error) {
+	query := `SELECT ` + paymentIntentColumns + ` FROM payment_intents WHERE id = $1 FOR UPDATE`
+	return r.get(ctx, query, id)
+}
+
+func (r *PaymentRepo) GetActiveByBooking(ctx context.Context, bookingID uuid.UUID) (*models.PaymentIntent, error) {
+	query := `SELECT ` + paymentIntentColumns + ` FROM payment_intents WHERE booking_id = $1 AND status <> $2`
+	return r.get(ctx, query, bookingID, models.PaymentStatusFailed)
+}
+
+func (r *PaymentRepo) get(ctx context.Context, query string, args ...any) (*models.PaymentIntent, error) {
+	var intent models.PaymentIntent
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &intent, query, args...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+	return &intent, nil
+}
+
+func (r *PaymentRepo) SetProviderRef(ctx context.Context, id uuid.UUID, providerRef string) error {
+	query := `
+		UPDATE payment_intents
+		SET provider_ref = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, providerRef)
+	if err != nil {
+		return fmt.Errorf("failed to set payment provider reference: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PaymentRepo) Finish(
+	ctx context.Context,
+	id uuid.UUID,
+	status models.PaymentStatus,
+	failureReason *string,
+) error {
+	query := `
+		UPDATE payment_intents
+		SET status = $2, failure_reason = $3, updated_at = NOW()
+		WHERE id = $1 AND status = $4
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, status, failureReason, models.PaymentStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to finish payment intent: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
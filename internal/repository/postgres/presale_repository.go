@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+type PresaleRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPresaleRepo(db *pgxpool.Pool) *PresaleRepo {
+	return &PresaleRepo{db: db}
+}
+
+func (r *PresaleRepo) Create(ctx context.Context, presale *models.Presale) error {
+	executor := tx.Executor(ctx, r.db)
+
+	if err := executor.QueryRow(ctx, `
+		INSERT INTO presales (concert_id, name, starts_at, ends_at, access_code_hash)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id, created_at
+	`, presale.ConcertID, presale.Name, presale.StartsAt, presale.EndsAt, presale.AccessCodeHash).
+		Scan(&presale.ID, &presale.CreatedAt); err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create presale: %w", err)
+	}
+
+	if len(presale.UserIDs) == 0 {
+		return nil
+	}
+
+	if _, err := executor.Exec(ctx, `
+		INSERT INTO presale_allowlist (presale_id, user_id)
+		SELECT $1, unnest($2::uuid[])
+	`, presale.ID, presale.UserIDs); err != nil {
+		if IsForeignKeyViolation(err) {
+			return fmt.Errorf("%w: unknown user in allowlist", models.ErrInvalidPresale)
+		}
+		return fmt.Errorf("failed to create presale allowlist: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PresaleRepo) GetByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.Presale, error) {
+	query := `
+		SELECT p.id, p.concert_id, p.name, p.starts_at, p.ends_at,
+			COALESCE(p.access_code_hash, '') AS access_code_hash, p.created_at,
+			COALESCE(array_agg(a.user_id) FILTER (WHERE a.user_id IS NOT NULL), '{}') AS user_ids
+		FROM presales p
+		LEFT JOIN presale_allowlist a ON a.presale_id = p.id
+		WHERE p.concert_id = $1
+		GROUP BY p.id
+		ORDER BY p.starts_at, p.name
+	`
+	var presales []models.Presale
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &presales, query, concertID); err != nil {
+		return nil, fmt.Errorf("failed to get presales: %w", err)
+	}
+	return presales, nil
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+const promoCodeColumns = `
+	id, code, concert_id, discount_type, value, COALESCE(currency, '') AS currency,
+	max_uses, max_uses_per_user, used_count, starts_at, ends_at, active, created_at
+`
+
+type PromoCodeRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPromoCodeRepo(db *pgxpool.Pool) *PromoCodeRepo {
+	return &PromoCodeRepo{db: db}
+}
+
+func (r *PromoCodeRepo) Create(ctx context.Context, promo *models.PromoCode) error {
+	query := `
+		INSERT INTO promo_codes (
+			code, concert_id, discount_type, value, currency, max_uses, max_uses_per_user, starts_at, ends_at
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9)
+		RETURNING id, active, created_at
+	`
+	err := tx.Executor(ctx, r.db).QueryRow(ctx, query,
+		promo.Code,
+		promo.ConcertID,
+		promo.DiscountType,
+		promo.Value,
+		promo.Currency,
+		promo.MaxUses,
+		promo.MaxUsesPerUser,
+		promo.StartsAt,
+		promo.EndsAt,
+	).Scan(&promo.ID, &promo.Active, &promo.CreatedAt)
+	if err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		if IsForeignKeyViolation(err) {
+			return models.ErrNotFound
+		}
+		return fmt.Errorf("failed to create promo code: %w", err)
+	}
+	return nil
+}
+
+func (r *PromoCodeRepo) GetAll(ctx context.Context) ([]models.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes ORDER BY created_at DESC`
+	var promos []models.PromoCode
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &promos, query); err != nil {
+		return nil, fmt.Errorf("failed to get promo codes: %w", err)
+	}
+	return promos, nil
+}
+
+func (r *PromoCodeRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes WHERE id = $1`
+	return r.get(ctx, query, id)
+}
+
+func (r *PromoCodeRepo) GetByCodeForUpdate(ctx context.Context, code string) (*models.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes WHERE code = $1 FOR UPDATE`
+	return r.get(ctx, query, code)
+}
+
+func (r *PromoCodeRepo) get(ctx context.Context, query string, args ...any) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &promo, query, args...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get promo code: %w", err)
+	}
+	return &promo, nil
+}
+
+func (r *PromoCodeRepo) Deactivate(ctx context.Context, id uuid.UUID) error {
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, `UPDATE promo_codes SET active = FALSE WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate promo code: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PromoCodeRepo) CountRedemptions(ctx context.Context, promoID, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM promo_code_redemptions WHERE promo_code_id = $1 AND user_id = $2`
+	var count int
+	if err := tx.Executor(ctx, r.db).QueryRow(ctx, query, promoID, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count promo code redemptions: %w", err)
+	}
+	return count, nil
+}
+
+func (r *PromoCodeRepo) Redeem(ctx context.Context, promoID, userID uuid.UUID, bookingIDs []uuid.UUID) error {
+	executor := tx.Executor(ctx, r.db)
+
+	res, err := executor.Exec(ctx, `
+		UPDATE promo_codes
+		SET used_count = used_count + $2
+		WHERE id = $1 AND (max_uses IS NULL OR used_count + $2 <= max_uses)
+	`, promoID, len(bookingIDs))
+	if err != nil {
+		return fmt.Errorf("failed to count promo code usage: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrPromoCodeExhausted
+	}
+
+	if _, err := executor.Exec(ctx, `
+		INSERT INTO promo_code_redemptions (promo_code_id, user_id, booking_id)
+		SELECT $1, $2, booking_id
+		FROM unnest($3::uuid[]) AS booking_id
+	`, promoID, userID, bookingIDs); err != nil {
+		return fmt.Errorf("failed to record promo code redemption: %w", err)
+	}
+	return nil
+}
+
+func (r *PromoCodeRepo) Release(ctx context.Context, bookingIDs []uuid.UUID) error {
+	query := `
+		WITH released AS (
+			DELETE FROM promo_code_redemptions
+			WHERE booking_id = ANY($1)
+			RETURNING promo_code_id
+		)
+		UPDATE promo_codes p
+		SET used_count = GREATEST(p.used_count - r.uses, 0)
+		FROM (SELECT promo_code_id, COUNT(*) AS uses FROM released GROUP BY promo_code_id) r
+		WHERE p.id = r.promo_code_id
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query, bookingIDs); err != nil {
+		return fmt.Errorf("failed to release promo code redemptions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+type RefreshTokenRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRefreshTokenRepo(db *pgxpool.Pool) *RefreshTokenRepo {
+	return &RefreshTokenRepo{db: db}
+}
+
+func (r *RefreshTokenRepo) Create(ctx context.Context, token *models.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	if err := tx.Executor(ctx, r.db).
+		QueryRow(ctx, query, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepo) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, used_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+	var token models.RefreshToken
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &token, query, tokenHash); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *RefreshTokenRepo) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL
+	`
+	tag, err := tx.Executor(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark refresh token used: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrTokenReused
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepo) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+const refundColumns = `
+	id, booking_id, user_id, concert_id, amount AS "amount.amount", currency AS "amount.currency",
+	reason, status, provider_ref, failure_reason, created_at, processed_at
+`
+
+type RefundRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRefundRepo(db *pgxpool.Pool) *RefundRepo {
+	return &RefundRepo{db: db}
+}
+
+func (r *RefundRepo) Create(ctx context.Context, refund *models.Refund) error {
+	query := `
+		INSERT INTO refunds (booking_id, user_id, concert_id, amount, currency, reason, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	err := tx.Executor(ctx, r.db).QueryRow(ctx, query,
+		refund.BookingID,
+		refund.UserID,
+		refund.ConcertID,
+		refund.Amount.Amount,
+		refund.Amount.Currency,
+		refund.Reason,
+		refund.Status,
+	).Scan(&refund.ID, &refund.CreatedAt)
+	if err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create refund: %w", err)
+	}
+	return nil
+}
+
+func (r *RefundRepo) CreateBatch(ctx context.Context, refunds []models.Refund) ([]models.Refund, error) {
+	if len(refunds) == 0 {
+		return nil, nil
+	}
+
+	bookingIDs := make([]uuid.UUID, len(refunds))
+	userIDs := make([]uuid.UUID, len(refunds))
+	concertIDs := make([]uuid.UUID, len(refunds))
+	amounts := make([]int64, len(refunds))
+	currencies := make([]string, len(refunds))
+	reasons := make([]string, len(refunds))
+	for i, refund := range refunds {
+		bookingIDs[i] = refund.BookingID
+		userIDs[i] = refund.UserID
+		concertIDs[i] = refund.ConcertID
+		amounts[i] = refund.Amount.Amount
+		currencies[i] = refund.Amount.Currency
+		reasons[i] = refund.Reason
+	}
+
+	query := `
+		INSERT INTO refunds (booking_id, user_id, concert_id, amount, currency, reason, status)
+		SELECT b, u, c, a, cur, rsn, $7
+		FROM unnest($1::uuid[], $2::uuid[], $3::uuid[], $4::bigint[], $5::text[], $6::text[])
+			AS t(b, u, c, a, cur, rsn)
+		ON CONFLICT (booking_id) DO NOTHING
+		RETURNING ` + refundColumns + `
+	`
+	var created []models.Refund
+	err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &created, query,
+		bookingIDs,
+		userIDs,
+		concertIDs,
+		amounts,
+		currencies,
+		reasons,
+		models.RefundStatusPending,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refunds: %w", err)
+	}
+	return created, nil
+}
+
+func (r *RefundRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE id = $1`
+	var refund models.Refund
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &refund, query, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get refund: %w", err)
+	}
+	return &refund, nil
+}
+
+func (r *RefundRepo) GetByUser(ctx context.Context, userID uuid.UUID) ([]models.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE user_id = $1 ORDER BY created_at DESC`
+	return r.list(ctx, query, userID)
+}
+
+func (r *RefundRepo) GetByConcert(ctx context.Context, concertID uuid.UUID) ([]models.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE concert_id = $1 ORDER BY created_at DESC`
+	return r.list(ctx, query, concertID)
+}
+
+func (r *RefundRepo) Claim(ctx context.Context, limit int, staleBefore time.Time) ([]models.Refund, error) {
+	query := `
+		UPDATE refunds
+		SET status = $2, claimed_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM refunds
+			WHERE status = $1 OR (status = $2 AND claimed_at < $3)
+			ORDER BY created_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + refundColumns + `
+	`
+	return r.list(ctx, query, models.RefundStatusPending, models.RefundStatusProcessing, staleBefore, limit)
+}
+
+func (r *RefundRepo) list(ctx context.Context, query string, args ...any) ([]models.Refund, error) {
+	var refunds []models.Refund
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &refunds, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list refunds: %w", err)
+	}
+	return refunds, nil
+}
+
+func (r *RefundRepo) Finish(
+	ctx context.Context,
+	id uuid.UUID,
+	status models.RefundStatus,
+	providerRef, failureReason *string,
+) error {
+	query := `
+		UPDATE refunds
+		SET status = $2, provider_ref = $3, failure_reason = $4, processed_at = NOW()
+		WHERE id = $1 AND status = $5
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query,
+		id,
+		status,
+		providerRef,
+		failureReason,
+		models.RefundStatusProcessing,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to finish refund: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (r *RefundRepo) Retry(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE refunds
+		SET status = $2, failure_reason = NULL, processed_at = NULL, claimed_at = NULL
+		WHERE id = $1 AND status = $3
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, models.RefundStatusPending, models.RefundStatusFailed)
+	if err != nil {
+		return fmt.Errorf("failed to retry refund: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrRefundNotFailed
+	}
+	return nil
+}
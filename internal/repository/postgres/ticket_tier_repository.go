@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+const ticketTierColumns = `
+	t.id, t.concert_id, t.name, t.price AS "price.amount", t.currency AS "price.currency",
+	t.quota, t.available, t.created_at,
+	COALESCE(array_agg(ts.section_id) FILTER (WHERE ts.section_id IS NOT NULL), '{}') AS section_ids
+`
+
+type TicketTierRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewTicketTierRepo(db *pgxpool.Pool) *TicketTierRepo {
+	return &TicketTierRepo{db: db}
+}
+
+func (r *TicketTierRepo) Create(ctx context.Context, tier *models.TicketTier) error {
+	executor := tx.Executor(ctx, r.db)
+
+	if err := executor.QueryRow(ctx, `
+		INSERT INTO ticket_tiers (concert_id, name, price, currency, quota, available)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		RETURNING id, available, created_at
+	`, tier.ConcertID, tier.Name, tier.Price.Amount, tier.Price.Currency, tier.Quota).
+		Scan(&tier.ID, &tier.Available, &tier.CreatedAt); err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create ticket tier: %w", err)
+	}
+
+	if _, err := executor.Exec(ctx, `
+		INSERT INTO ticket_tier_sections (tier_id, section_id, concert_id)
+		SELECT $1, unnest($2::uuid[]), $3
+	`, tier.ID, tier.SectionIDs, tier.ConcertID); err != nil {
+		if IsUnique(err) {
+			return models.ErrTierSectionInUse
+		}
+		return fmt.Errorf("failed to assign sections to ticket tier: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TicketTierRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.TicketTier, error) {
+	query := `SELECT ` + ticketTierColumns + `
+		FROM ticket_tiers t
+		LEFT JOIN ticket_tier_sections ts ON ts.tier_id = t.id
+		WHERE t.id = $1
+		GROUP BY t.id
+	`
+	var tier models.TicketTier
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &tier, query, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get ticket tier by id: %w", err)
+	}
+	return &tier, nil
+}
+
+func (r *TicketTierRepo) GetByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.TicketTier, error) {
+	query := `SELECT ` + ticketTierColumns + `
+		FROM ticket_tiers t
+		LEFT JOIN ticket_tier_sections ts ON ts.tier_id = t.id
+		WHERE t.concert_id = $1
+		GROUP BY t.id
+		ORDER BY t.price DESC, t.name
+	`
+	var tiers []models.TicketTier
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &tiers, query, concertID); err != nil {
+		return nil, fmt.Errorf("failed to get ticket tiers: %w", err)
+	}
+	return tiers, nil
+}
+
+func (r *TicketTierRepo) CountSectionSeats(
+	ctx context.Context,
+	venueID uuid.UUID,
+	sectionIDs []uuid.UUID,
+) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT sec.id), COUNT(st.id)
+		FROM venue_sections sec
+		LEFT JOIN venue_rows r ON r.section_id = sec.id
+		LEFT JOIN venue_seats st ON st.row_id = r.id
+		WHERE sec.venue_id = $1 AND sec.id = ANY($2)
+	`
+	var sections, seats int
+	if err := tx.Executor(ctx, r.db).QueryRow(ctx, query, venueID, sectionIDs).Scan(&sections, &seats); err != nil {
+		return 0, fmt.Errorf("failed to count section seats: %w", err)
+	}
+	if sections != len(sectionIDs) {
+		return 0, models.ErrInvalidTier
+	}
+	return seats, nil
+}
+
+func (r *TicketTierRepo) ResolveSeats(
+	ctx context.Context,
+	concertID uuid.UUID,
+	seats []int,
+) ([]models.SeatAssignment, error) {
+	query := `
+		SELECT s.id AS seat_id, s.ordinal, t.id AS tier_id,
+			t.price AS "price.amount", t.currency AS "price.currency"
+		FROM concerts c
+		JOIN venue_seats s ON s.venue_id = c.venue_id
+		JOIN venue_rows r ON r.id = s.row_id
+		JOIN ticket_tier_sections ts ON ts.concert_id = c.id AND ts.section_id = r.section_id
+		JOIN ticket_tiers t ON t.id = ts.tier_id
+		WHERE c.id = $1 AND s.ordinal = ANY($2)
+		ORDER BY s.ordinal
+	`
+	var assignments []models.SeatAssignment
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &assignments, query, concertID, seats); err != nil {
+		return nil, fmt.Errorf("failed to resolve seats: %w", err)
+	}
+	return assignments, nil
+}
+
+func (r *TicketTierRepo) ResolveSeatIDs(
+	ctx context.Context,
+	concertID uuid.UUID,
+	seatIDs []uuid.UUID,
+) ([]models.SeatAssignment, error) {
+	query := `
+		SELECT s.id AS seat_id, s.ordinal, t.id AS tier_id,
+			t.price AS "price.amount", t.currency AS "price.currency"
+		FROM concerts c
+		JOIN venue_seats s ON s.venue_id = c.venue_id
+		JOIN venue_rows r ON r.id = s.row_id
+		JOIN ticket_tier_sections ts ON ts.concert_id = c.id AND ts.section_id = r.section_id
+		JOIN ticket_tiers t ON t.id = ts.tier_id
+		WHERE c.id = $1 AND s.id = ANY($2) AND s.ordinal <= c.total_seats
+		ORDER BY s.ordinal
+	`
+	var assignments []models.SeatAssignment
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &assignments, query, concertID, seatIDs); err != nil {
+		return nil, fmt.Errorf("failed to resolve seats: %w", err)
+	}
+	return assignments, nil
+}
+
+func (r *TicketTierRepo) FindSeatCandidates(
+	ctx context.Context,
+	concertID uuid.UUID,
+	tierID *uuid.UUID,
+	limit int,
+) ([]models.SeatCandidate, error) {
+	query := `
+		SELECT s.id AS seat_id, s.ordinal, s.row_id, s.number, t.id AS tier_id,
+			t.price AS "price.amount", t.currency AS "price.currency"
+		FROM ticket_tiers t
+		JOIN concerts c ON c.id = t.concert_id
+		JOIN ticket_tier_sections ts ON ts.tier_id = t.id
+		JOIN venue_sections sec ON sec.id = ts.section_id
+		JOIN venue_rows r ON r.section_id = sec.id
+		JOIN venue_seats s ON s.row_id = r.id
+		WHERE t.concert_id = $1
+		  AND ($2::uuid IS NULL OR t.id = $2)
+		  AND t.available > 0
+		  AND s.ordinal <= c.total_seats
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM bookings b
+			WHERE b.concert_id = t.concert_id AND b.seat_id = s.id AND b.status <> $4
+		  )
+		ORDER BY sec.position, r.position, s.number
+		LIMIT $3
+	`
+	var candidates []models.SeatCandidate
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &candidates, query,
+		concertID,
+		tierID,
+		limit,
+		models.BookingStatusCancelled,
+	); err != nil {
+		return nil, fmt.Errorf("failed to find seat candidates: %w", err)
+	}
+	return candidates, nil
+}
+
+func (r *TicketTierRepo) LockFreeSeats(
+	ctx context.Context,
+	concertID uuid.UUID,
+	seatIDs []uuid.UUID,
+) ([]models.SeatAssignment, error) {
+	query := `
+		SELECT s.id AS seat_id, s.ordinal, t.id AS tier_id,
+			t.price AS "price.amount", t.currency AS "price.currency"
+		FROM venue_seats s
+		JOIN venue_rows r ON r.id = s.row_id
+		JOIN ticket_tier_sections ts ON ts.section_id = r.section_id AND ts.concert_id = $1
+		JOIN ticket_tiers t ON t.id = ts.tier_id
+		WHERE s.id = ANY($2)
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM bookings b
+			WHERE b.concert_id = $1 AND b.seat_id = s.id AND b.status <> $3
+		  )
+		ORDER BY s.ordinal
+		FOR UPDATE OF s SKIP LOCKED
+	`
+	var assignments []models.SeatAssignment
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &assignments, query,
+		concertID,
+		seatIDs,
+		models.BookingStatusCancelled,
+	); err != nil {
+		return nil, fmt.Errorf("failed to lock seats: %w", err)
+	}
+	return assignments, nil
+}
+
+func (r *TicketTierRepo) DeleteByConcertID(ctx context.Context, concertID uuid.UUID) error {
+	query := `DELETE FROM ticket_tiers WHERE concert_id = $1`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query, concertID); err != nil {
+		return fmt.Errorf("failed to delete ticket tiers: %w", err)
+	}
+	return nil
+}
+
+func (r *TicketTierRepo) Decrement(ctx context.Context, id uuid.UUID, count int) error {
+	query := `
+		UPDATE ticket_tiers
+		SET available = available - $2
+		WHERE id = $1 AND available >= $2
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, count)
+	if err != nil {
+		return fmt.Errorf("failed to decrement tier inventory: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrNoSeats
+	}
+	return nil
+}
+
+func (r *TicketTierRepo) Increment(ctx context.Context, id uuid.UUID, count int) error {
+	query := `
+		UPDATE ticket_tiers
+		SET available = available + $2
+		WHERE id = $1 AND available + $2 <= quota
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, count)
+	if err != nil {
+		return fmt.Errorf("failed to increment tier inventory: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrSeatsOverflow
+	}
+	return nil
+}
@@ -26,11 +26,11 @@ func (r *UserRepo) Create(ctx context.Context, user *models.User) error {
 	query := `
         INSERT INTO users (email, password_hash)
         VALUES ($1, $2)
-        RETURNING id, created_at
+        RETURNING id, role, created_at
     `
 	if err := tx.Executor(ctx, r.db).
 		QueryRow(ctx, query, user.Email, user.PasswordHash).
-		Scan(&user.ID, &user.CreatedAt); err != nil {
+		Scan(&user.ID, &user.Role, &user.CreatedAt); err != nil {
 		if IsUnique(err) {
 			return models.ErrAlreadyExists
 		}
@@ -41,7 +41,7 @@ func (r *UserRepo) Create(ctx context.Context, user *models.User) error {
 
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-        SELECT id, email, password_hash, created_at
+        SELECT id, email, password_hash, role, created_at
         FROM users
         WHERE email = $1
     `
@@ -57,7 +57,7 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*models.User,
 
 func (r *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	query := `
-        SELECT id, email, password_hash, created_at
+        SELECT id, email, password_hash, role, created_at
         FROM users
         WHERE id = $1
     `
@@ -70,3 +70,19 @@ func (r *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.User, err
 	}
 	return &user, nil
 }
+
+func (r *UserRepo) UpdateRole(ctx context.Context, id uuid.UUID, role models.UserRole) error {
+	query := `
+        UPDATE users
+        SET role = $2
+        WHERE id = $1
+    `
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query, id, role)
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
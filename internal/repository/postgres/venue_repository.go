@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+type VenueRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewVenueRepo(db *pgxpool.Pool) *VenueRepo {
+	return &VenueRepo{db: db}
+}
+
+func (r *VenueRepo) Create(ctx context.Context, venue *models.Venue) error {
+	query := `
+		INSERT INTO venues (name, address, timezone)
+		VALUES ($1, $2, $3)
+		RETURNING id, capacity, created_at
+	`
+	if err := tx.Executor(ctx, r.db).
+		QueryRow(ctx, query, venue.Name, venue.Address, venue.Timezone).
+		Scan(&venue.ID, &venue.Capacity, &venue.CreatedAt); err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create venue: %w", err)
+	}
+	return nil
+}
+
+func (r *VenueRepo) GetAll(ctx context.Context) ([]models.Venue, error) {
+	query := `
+		SELECT id, name, address, timezone, capacity, created_at
+		FROM venues
+		ORDER BY name
+	`
+	var venues []models.Venue
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &venues, query); err != nil {
+		return nil, fmt.Errorf("failed to get venues: %w", err)
+	}
+	return venues, nil
+}
+
+func (r *VenueRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Venue, error) {
+	return r.get(ctx, id, "")
+}
+
+func (r *VenueRepo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Venue, error) {
+	return r.get(ctx, id, "FOR UPDATE")
+}
+
+func (r *VenueRepo) get(ctx context.Context, id uuid.UUID, lock string) (*models.Venue, error) {
+	query := `
+		SELECT id, name, address, timezone, capacity, created_at
+		FROM venues
+		WHERE id = $1
+	` + lock
+	var venue models.Venue
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &venue, query, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get venue by id: %w", err)
+	}
+	return &venue, nil
+}
+
+func (r *VenueRepo) GetLayout(ctx context.Context, venueID uuid.UUID) ([]models.VenueSection, error) {
+	executor := tx.Executor(ctx, r.db)
+
+	var sections []models.VenueSection
+	if err := pgxscan.Select(ctx, executor, &sections, `
+		SELECT id, venue_id, name, position
+		FROM venue_sections
+		WHERE venue_id = $1
+		ORDER BY position
+	`, venueID); err != nil {
+		return nil, fmt.Errorf("failed to get venue sections: %w", err)
+	}
+
+	var rows []models.VenueRow
+	if err := pgxscan.Select(ctx, executor, &rows, `
+		SELECT r.id, r.section_id, r.label, r.position
+		FROM venue_rows r
+		JOIN venue_sections s ON s.id = r.section_id
+		WHERE s.venue_id = $1
+		ORDER BY r.position
+	`, venueID); err != nil {
+		return nil, fmt.Errorf("failed to get venue rows: %w", err)
+	}
+
+	var seats []models.VenueSeat
+	if err := pgxscan.Select(ctx, executor, &seats, `
+		SELECT id, venue_id, row_id, number, ordinal
+		FROM venue_seats
+		WHERE venue_id = $1
+		ORDER BY ordinal
+	`, venueID); err != nil {
+		return nil, fmt.Errorf("failed to get venue seats: %w", err)
+	}
+
+	seatsByRow := make(map[uuid.UUID][]models.VenueSeat)
+	for _, seat := range seats {
+		seatsByRow[seat.RowID] = append(seatsByRow[seat.RowID], seat)
+	}
+
+	rowsBySection := make(map[uuid.UUID][]models.VenueRow)
+	for _, row := range rows {
+		row.Seats = seatsByRow[row.ID]
+		rowsBySection[row.SectionID] = append(rowsBySection[row.SectionID], row)
+	}
+
+	for i := range sections {
+		sections[i].Rows = rowsBySection[sections[i].ID]
+	}
+
+	return sections, nil
+}
+
+func (r *VenueRepo) CreateSection(ctx context.Context, section *models.VenueSection) error {
+	executor := tx.Executor(ctx, r.db)
+
+	if err := executor.QueryRow(ctx, `
+		INSERT INTO venue_sections (venue_id, name, position)
+		VALUES ($1, $2, (SELECT COALESCE(MAX(position), 0) + 1 FROM venue_sections WHERE venue_id = $1))
+		RETURNING id, position
+	`, section.VenueID, section.Name).Scan(&section.ID, &section.Position); err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create venue section: %w", err)
+	}
+
+	var ordinal int
+	if err := executor.QueryRow(ctx, `
+		SELECT COALESCE(MAX(ordinal), 0) FROM venue_seats WHERE venue_id = $1
+	`, section.VenueID).Scan(&ordinal); err != nil {
+		return fmt.Errorf("failed to get last seat ordinal: %w", err)
+	}
+
+	seatCount := 0
+	for i := range section.Rows {
+		row := &section.Rows[i]
+		row.SectionID = section.ID
+		row.Position = i + 1
+
+		if err := executor.QueryRow(ctx, `
+			INSERT INTO venue_rows (section_id, label, position)
+			VALUES ($1, $2, $3)
+			RETURNING id
+		`, row.SectionID, row.Label, row.Position).Scan(&row.ID); err != nil {
+			if IsUnique(err) {
+				return models.ErrAlreadyExists
+			}
+			return fmt.Errorf("failed to create venue row: %w", err)
+		}
+
+		numbers := make([]int, len(row.Seats))
+		for j, seat := range row.Seats {
+			numbers[j] = seat.Number
+		}
+
+		var seats []models.VenueSeat
+		if err := pgxscan.Select(ctx, executor, &seats, `
+			INSERT INTO venue_seats (venue_id, row_id, number, ordinal)
+			SELECT $1, $2, n.number, $3 + n.idx
+			FROM unnest($4::int[]) WITH ORDINALITY AS n(number, idx)
+			RETURNING id, venue_id, row_id, number, ordinal
+		`, section.VenueID, row.ID, ordinal+seatCount, numbers); err != nil {
+			if IsUnique(err) {
+				return models.ErrAlreadyExists
+			}
+			return fmt.Errorf("failed to create venue seats: %w", err)
+		}
+		slices.SortFunc(seats, func(a, b models.VenueSeat) int { return a.Ordinal - b.Ordinal })
+		row.Seats = seats
+
+		seatCount += len(row.Seats)
+	}
+
+	if _, err := executor.Exec(ctx, `
+		UPDATE venues SET capacity = capacity + $2 WHERE id = $1
+	`, section.VenueID, seatCount); err != nil {
+		return fmt.Errorf("failed to update venue capacity: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository/tx"
+)
+
+type WaitlistRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewWaitlistRepo(db *pgxpool.Pool) *WaitlistRepo {
+	return &WaitlistRepo{db: db}
+}
+
+func (r *WaitlistRepo) Create(ctx context.Context, entry *models.WaitlistEntry) error {
+	query := `
+		INSERT INTO waitlist_entries (concert_id, user_id, status)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	err := tx.Executor(ctx, r.db).QueryRow(ctx, query,
+		entry.ConcertID,
+		entry.UserID,
+		entry.Status,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		if IsUnique(err) {
+			return models.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+	return nil
+}
+
+func (r *WaitlistRepo) GetActive(ctx context.Context, concertID, userID uuid.UUID) (*models.WaitlistEntry, error) {
+	query := `
+		SELECT w.id, w.concert_id, w.user_id, w.status, w.booking_id, w.offer_expires_at, w.created_at,
+			CASE WHEN w.status = $3 THEN (
+				SELECT COUNT(*)
+				FROM waitlist_entries o
+				WHERE o.concert_id = w.concert_id AND o.status = $3
+					AND (o.created_at, o.id) <= (w.created_at, w.id)
+			) ELSE 0 END AS position
+		FROM waitlist_entries w
+		WHERE w.concert_id = $1 AND w.user_id = $2 AND w.status IN ($3, $4)
+	`
+	var entry models.WaitlistEntry
+	if err := pgxscan.Get(ctx, tx.Executor(ctx, r.db), &entry, query,
+		concertID,
+		userID,
+		models.WaitlistStatusWaiting,
+		models.WaitlistStatusOffered,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *WaitlistRepo) Leave(ctx context.Context, concertID, userID uuid.UUID) error {
+	query := `
+		UPDATE waitlist_entries
+		SET status = $3
+		WHERE concert_id = $1 AND user_id = $2 AND status = $4
+	`
+	res, err := tx.Executor(ctx, r.db).Exec(ctx, query,
+		concertID,
+		userID,
+		models.WaitlistStatusLeft,
+		models.WaitlistStatusWaiting,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to leave waitlist: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WaitlistRepo) NextWaiting(
+	ctx context.Context,
+	concertID uuid.UUID,
+	limit int,
+) ([]models.WaitlistEntry, error) {
+	query := `
+		SELECT w.id, w.concert_id, w.user_id, w.status, w.booking_id, w.offer_expires_at, w.created_at
+		FROM waitlist_entries w
+		JOIN concerts c ON c.id = w.concert_id
+		WHERE w.concert_id = $1 AND w.status = $2
+			AND c.status IN ($3, $4) AND c.date > NOW()
+		ORDER BY w.created_at, w.id
+		LIMIT $5
+		FOR UPDATE OF w SKIP LOCKED
+	`
+	var entries []models.WaitlistEntry
+	if err := pgxscan.Select(ctx, tx.Executor(ctx, r.db), &entries, query,
+		concertID,
+		models.WaitlistStatusWaiting,
+		models.ConcertStatusOnSale,
+		models.ConcertStatusSoldOut,
+		limit,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get next waitlist entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *WaitlistRepo) MarkOffered(ctx context.Context, id, bookingID uuid.UUID, expiresAt time.Time) error {
+	query := `
+		UPDATE waitlist_entries
+		SET status = $2, booking_id = $3, offer_expires_at = $4
+		WHERE id = $1
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query,
+		id,
+		models.WaitlistStatusOffered,
+		bookingID,
+		expiresAt,
+	); err != nil {
+		return fmt.Errorf("failed to mark waitlist offer: %w", err)
+	}
+	return nil
+}
+
+func (r *WaitlistRepo) MarkClaimed(ctx context.Context, bookingID uuid.UUID) error {
+	query := `
+		UPDATE waitlist_entries
+		SET status = $2
+		WHERE booking_id = $1 AND status = $3
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query,
+		bookingID,
+		models.WaitlistStatusClaimed,
+		models.WaitlistStatusOffered,
+	); err != nil {
+		return fmt.Errorf("failed to mark waitlist offer claimed: %w", err)
+	}
+	return nil
+}
+
+func (r *WaitlistRepo) ExpireOffers(ctx context.Context, bookingIDs []uuid.UUID) error {
+	query := `
+		UPDATE waitlist_entries
+		SET status = $2
+		WHERE booking_id = ANY($1) AND status = $3
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query,
+		bookingIDs,
+		models.WaitlistStatusExpired,
+		models.WaitlistStatusOffered,
+	); err != nil {
+		return fmt.Errorf("failed to expire waitlist offers: %w", err)
+	}
+	return nil
+}
+
+func (r *WaitlistRepo) ExpireWaiting(ctx context.Context, concertID uuid.UUID) error {
+	query := `
+		UPDATE waitlist_entries
+		SET status = $2
+		WHERE concert_id = $1 AND status = $3
+	`
+	if _, err := tx.Executor(ctx, r.db).Exec(ctx, query,
+		concertID,
+		models.WaitlistStatusExpired,
+		models.WaitlistStatusWaiting,
+	); err != nil {
+		return fmt.Errorf("failed to expire waitlist entries: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,12 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -9,28 +15,43 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/yohnnn/booking_service/internal/cache"
 	"github.com/yohnnn/booking_service/internal/models"
 	"github.com/yohnnn/booking_service/internal/repository"
 )
 
+const refreshTokenBytes = 32
+
 type AuthService struct {
-	logger    *slog.Logger
-	userRepo  repository.UserRepository
-	secretKey []byte
-	tokenTTL  time.Duration
+	logger      *slog.Logger
+	userRepo    repository.UserRepository
+	refreshRepo repository.RefreshTokenRepository
+	denylist    cache.TokenDenylistRepository
+	manager     TxManager
+	secretKey   []byte
+	tokenTTL    time.Duration
+	refreshTTL  time.Duration
 }
 
 func NewAuthService(
 	logger *slog.Logger,
 	userRepo repository.UserRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	denylist cache.TokenDenylistRepository,
+	manager TxManager,
 	secretKey string,
 	tokenTTL time.Duration,
+	refreshTTL time.Duration,
 ) *AuthService {
 	return &AuthService{
-		logger:    logger,
-		userRepo:  userRepo,
-		secretKey: []byte(secretKey),
-		tokenTTL:  tokenTTL,
+		logger:      logger,
+		userRepo:    userRepo,
+		refreshRepo: refreshRepo,
+		denylist:    denylist,
+		manager:     manager,
+		secretKey:   []byte(secretKey),
+		tokenTTL:    tokenTTL,
+		refreshTTL:  refreshTTL,
 	}
 }
 
@@ -52,27 +73,106 @@ func (s *AuthService) Register(ctx context.Context, email, password string) (*mo
 	return user, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+func (s *AuthService) Login(ctx context.Context, email, password string) (*models.TokenPair, error) {
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(s.tokenTTL).Unix(),
+	return s.issueTokens(ctx, user, uuid.New())
+}
+
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*models.TokenPair, error) {
+	stored, err := s.refreshRepo.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if stored.RevokedAt != nil || !stored.ExpiresAt.After(time.Now()) {
+		return nil, models.ErrInvalidToken
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if stored.UsedAt != nil {
+		return nil, s.revokeFamily(ctx, stored)
+	}
+
+	var pair *models.TokenPair
+
+	err = s.manager.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.refreshRepo.MarkUsed(ctx, stored.ID); err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.GetByID(ctx, stored.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		pair, err = s.issueTokens(ctx, user, stored.FamilyID)
+		return err
+	})
+
+	if errors.Is(err, models.ErrTokenReused) {
+		return nil, s.revokeFamily(ctx, stored)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+func (s *AuthService) Logout(ctx context.Context, claims *models.TokenClaims, refreshToken string) error {
+	if ttl := time.Until(claims.ExpiresAt); ttl > 0 {
+		if err := s.denylist.Revoke(ctx, claims.ID, ttl); err != nil {
+			return fmt.Errorf("failed to revoke access token: %w", err)
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := s.refreshRepo.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if errors.Is(err, models.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if stored.UserID != claims.UserID {
+		return nil
+	}
+
+	return s.refreshRepo.RevokeFamily(ctx, stored.FamilyID)
+}
+
+func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*models.TokenClaims, error) {
+	claims, err := s.ParseToken(tokenString)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", models.ErrInvalidAccessToken, err)
+	}
+
+	revoked, err := s.denylist.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token denylist: %w", err)
+	}
+	if revoked {
+		return nil, models.ErrTokenRevoked
+	}
 
-	return token.SignedString(s.secretKey)
+	return claims, nil
 }
 
-func (s *AuthService) ParseToken(tokenString string) (uuid.UUID, error) {
+func (s *AuthService) ParseToken(tokenString string) (*models.TokenClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
@@ -81,22 +181,122 @@ func (s *AuthService) ParseToken(tokenString string) (uuid.UUID, error) {
 	})
 
 	if err != nil {
-		return uuid.Nil, err
+		return nil, err
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		userIDStr, ok := claims["user_id"].(string)
 		if !ok {
-			return uuid.Nil, jwt.ErrInvalidKey
+			return nil, jwt.ErrInvalidKey
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			return uuid.Nil, err
+			return nil, err
+		}
+
+		role := models.UserRoleUser
+		if roleStr, ok := claims["role"].(string); ok {
+			role = models.UserRole(roleStr)
+		}
+		if !role.Valid() {
+			return nil, jwt.ErrTokenInvalidClaims
+		}
+
+		tokenID, ok := claims["jti"].(string)
+		if !ok || tokenID == "" {
+			return nil, jwt.ErrTokenInvalidClaims
+		}
+
+		expiresAt, err := claims.GetExpirationTime()
+		if err != nil || expiresAt == nil {
+			return nil, jwt.ErrTokenInvalidClaims
 		}
 
-		return userID, nil
+		return &models.TokenClaims{
+			UserID:    userID,
+			Role:      role,
+			ID:        tokenID,
+			ExpiresAt: expiresAt.Time,
+		}, nil
+	}
+
+	return nil, jwt.ErrTokenInvalidClaims
+}
+
+func (s *AuthService) SetRole(ctx context.Context, email string, role models.UserRole) (*models.User, error) {
+	if !role.Valid() {
+		return nil, models.ErrInvalidRole
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, user.ID, role); err != nil {
+		return nil, err
 	}
 
-	return uuid.Nil, jwt.ErrTokenInvalidClaims
+	user.Role = role
+
+	return user, nil
+}
+
+func (s *AuthService) issueTokens(
+	ctx context.Context,
+	user *models.User,
+	familyID uuid.UUID,
+) (*models.TokenPair, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.tokenTTL)
+
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"role":    user.Role,
+		"jti":     uuid.NewString(),
+		"iat":     now.Unix(),
+		"exp":     expiresAt.Unix(),
+	}
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := s.refreshRepo.Create(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: now.Add(s.refreshTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (s *AuthService) revokeFamily(ctx context.Context, token *models.RefreshToken) error {
+	s.logger.WarnContext(ctx, "refresh token reuse detected", "user_id", token.UserID, "family_id", token.FamilyID)
+
+	if err := s.refreshRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+
+	return models.ErrTokenReused
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
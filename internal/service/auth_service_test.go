@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -86,7 +87,7 @@ func TestAuthService_Register(t *testing.T) {
 			userRepo := mocks.NewMockUserRepository(ctrl)
 			tt.mockBehavior(userRepo)
 
-			s := NewAuthService(testLogger(), userRepo, "test-secret", 24*time.Hour)
+			s := NewAuthService(testLogger(), userRepo, nil, nil, nil, "test-secret", time.Hour, 24*time.Hour)
 
 			user, err := s.Register(context.Background(), tt.email, tt.password)
 			if tt.wantErr {
@@ -104,8 +105,9 @@ func TestAuthService_Register(t *testing.T) {
 
 func TestAuthService_Login(t *testing.T) {
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	userID := uuid.New()
 
-	type mockBehavior func(repo *mocks.MockUserRepository)
+	type mockBehavior func(repo *mocks.MockUserRepository, refreshRepo *mocks.MockRefreshTokenRepository)
 
 	tests := []struct {
 		name         string
@@ -113,29 +115,35 @@ func TestAuthService_Login(t *testing.T) {
 		password     string
 		mockBehavior mockBehavior
 		wantErr      bool
-		wantToken    bool
 	}{
 		{
 			name:     "success",
 			email:    "test@example.com",
 			password: "password123",
-			mockBehavior: func(repo *mocks.MockUserRepository) {
+			mockBehavior: func(repo *mocks.MockUserRepository, refreshRepo *mocks.MockRefreshTokenRepository) {
 				repo.EXPECT().
 					GetByEmail(gomock.Any(), "test@example.com").
 					Return(&models.User{
-						ID:           uuid.New(),
+						ID:           userID,
 						Email:        "test@example.com",
 						PasswordHash: string(hashedPassword),
 					}, nil)
+				refreshRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, token *models.RefreshToken) error {
+						assert.Equal(t, userID, token.UserID)
+						assert.NotEqual(t, uuid.Nil, token.FamilyID)
+						assert.Len(t, token.TokenHash, 64)
+						return nil
+					})
 			},
-			wantErr:   false,
-			wantToken: true,
+			wantErr: false,
 		},
 		{
 			name:     "user not found",
 			email:    "notfound@example.com",
 			password: "password123",
-			mockBehavior: func(repo *mocks.MockUserRepository) {
+			mockBehavior: func(repo *mocks.MockUserRepository, _ *mocks.MockRefreshTokenRepository) {
 				repo.EXPECT().
 					GetByEmail(gomock.Any(), "notfound@example.com").
 					Return(nil, models.ErrNotFound)
@@ -146,11 +154,11 @@ func TestAuthService_Login(t *testing.T) {
 			name:     "wrong password",
 			email:    "test@example.com",
 			password: "wrongpassword",
-			mockBehavior: func(repo *mocks.MockUserRepository) {
+			mockBehavior: func(repo *mocks.MockUserRepository, _ *mocks.MockRefreshTokenRepository) {
 				repo.EXPECT().
 					GetByEmail(gomock.Any(), "test@example.com").
 					Return(&models.User{
-						ID:           uuid.New(),
+						ID:           userID,
 						Email:        "test@example.com",
 						PasswordHash: string(hashedPassword),
 					}, nil)
@@ -161,13 +169,31 @@ func TestAuthService_Login(t *testing.T) {
 			name:     "repository error",
 			email:    "test@example.com",
 			password: "password123",
-			mockBehavior: func(repo *mocks.MockUserRepository) {
+			mockBehavior: func(repo *mocks.MockUserRepository, _ *mocks.MockRefreshTokenRepository) {
 				repo.EXPECT().
 					GetByEmail(gomock.Any(), "test@example.com").
 					Return(nil, assert.AnError)
 			},
 			wantErr: true,
 		},
+		{
+			name:     "refresh token store error",
+			email:    "test@example.com",
+			password: "password123",
+			mockBehavior: func(repo *mocks.MockUserRepository, refreshRepo *mocks.MockRefreshTokenRepository) {
+				repo.EXPECT().
+					GetByEmail(gomock.Any(), "test@example.com").
+					Return(&models.User{
+						ID:           userID,
+						Email:        "test@example.com",
+						PasswordHash: string(hashedPassword),
+					}, nil)
+				refreshRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -176,54 +202,105 @@ func TestAuthService_Login(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mocks.NewMockUserRepository(ctrl)
-			tt.mockBehavior(userRepo)
+			refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+			tt.mockBehavior(userRepo, refreshRepo)
 
-			s := NewAuthService(testLogger(), userRepo, "test-secret", 24*time.Hour)
+			s := NewAuthService(testLogger(), userRepo, refreshRepo, nil, nil, "test-secret", time.Hour, 24*time.Hour)
 
-			token, err := s.Login(context.Background(), tt.email, tt.password)
+			tokens, err := s.Login(context.Background(), tt.email, tt.password)
 			if tt.wantErr {
 				require.Error(t, err)
-				assert.Empty(t, token)
+				assert.Nil(t, tokens)
 				return
 			}
 
 			require.NoError(t, err)
-			if tt.wantToken {
-				assert.NotEmpty(t, token)
-			}
+			assert.NotEmpty(t, tokens.AccessToken)
+			assert.NotEmpty(t, tokens.RefreshToken)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), tokens.ExpiresAt, time.Minute)
 		})
 	}
 }
 
 func TestAuthService_ParseToken(t *testing.T) {
 	secretKey := "test-secret"
-	s := NewAuthService(testLogger(), nil, secretKey, 24*time.Hour)
+	s := NewAuthService(testLogger(), nil, nil, nil, nil, secretKey, 24*time.Hour, 24*time.Hour)
 
 	userID := uuid.New()
-	user := &models.User{ID: userID, Email: "test@example.com"}
+	user := &models.User{ID: userID, Email: "test@example.com", Role: models.UserRoleAdmin}
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
 	user.PasswordHash = string(hashedPassword)
 
+	login := func(secret string, ttl time.Duration) string {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		repo := mocks.NewMockUserRepository(ctrl)
+		repo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, nil)
+		refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+		refreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		svc := NewAuthService(testLogger(), repo, refreshRepo, nil, nil, secret, ttl, 24*time.Hour)
+		tokens, err := svc.Login(context.Background(), "test@example.com", "pass")
+		require.NoError(t, err)
+		return tokens.AccessToken
+	}
+
 	tests := []struct {
-		name    string
-		token   func() string
-		wantID  uuid.UUID
-		wantErr bool
+		name     string
+		token    func() string
+		wantID   uuid.UUID
+		wantRole models.UserRole
+		wantErr  bool
 	}{
 		{
 			name: "valid token",
 			token: func() string {
-				ctrl := gomock.NewController(t)
-				defer ctrl.Finish()
-				repo := mocks.NewMockUserRepository(ctrl)
-				repo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, nil)
-				svc := NewAuthService(testLogger(), repo, secretKey, 24*time.Hour)
-				token, _ := svc.Login(context.Background(), "test@example.com", "pass")
-				return token
+				return login(secretKey, 24*time.Hour)
 			},
-			wantID:  userID,
-			wantErr: false,
+			wantID:   userID,
+			wantRole: models.UserRoleAdmin,
+			wantErr:  false,
+		},
+		{
+			name: "token without role defaults to user",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"user_id": userID.String(),
+					"jti":     uuid.NewString(),
+					"exp":     time.Now().Add(time.Hour).Unix(),
+				})
+				signed, _ := token.SignedString([]byte(secretKey))
+				return signed
+			},
+			wantID:   userID,
+			wantRole: models.UserRoleUser,
+			wantErr:  false,
+		},
+		{
+			name: "token without jti",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"user_id": userID.String(),
+					"role":    "user",
+					"exp":     time.Now().Add(time.Hour).Unix(),
+				})
+				signed, _ := token.SignedString([]byte(secretKey))
+				return signed
+			},
+			wantErr: true,
+		},
+		{
+			name: "token with unknown role",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"user_id": userID.String(),
+					"role":    "superuser",
+					"exp":     time.Now().Add(time.Hour).Unix(),
+				})
+				signed, _ := token.SignedString([]byte(secretKey))
+				return signed
+			},
+			wantErr: true,
 		},
 		{
 			name: "invalid token",
@@ -235,26 +312,354 @@ func TestAuthService_ParseToken(t *testing.T) {
 		{
 			name: "token with wrong secret",
 			token: func() string {
-				ctrl := gomock.NewController(t)
-				defer ctrl.Finish()
-				repo := mocks.NewMockUserRepository(ctrl)
-				repo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, nil)
-				svc := NewAuthService(testLogger(), repo, "wrong-secret", 24*time.Hour)
-				token, _ := svc.Login(context.Background(), "test@example.com", "pass")
-				return token
+				return login("wrong-secret", 24*time.Hour)
 			},
 			wantErr: true,
 		},
 		{
 			name: "expired token",
 			token: func() string {
-				ctrl := gomock.NewController(t)
-				defer ctrl.Finish()
-				repo := mocks.NewMockUserRepository(ctrl)
-				repo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, nil)
-				svc := NewAuthService(testLogger(), repo, secretKey, -1*time.Hour)
-				token, _ := svc.Login(context.Background(), "test@example.com", "pass")
-				return token
+				return login(secretKey, -1*time.Hour)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := s.ParseToken(tt.token())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, claims.UserID)
+			assert.Equal(t, tt.wantRole, claims.Role)
+			assert.NotEmpty(t, claims.ID)
+		})
+	}
+}
+
+func TestAuthService_SetRole(t *testing.T) {
+	userID := uuid.New()
+
+	type mockBehavior func(repo *mocks.MockUserRepository)
+
+	tests := []struct {
+		name         string
+		email        string
+		role         models.UserRole
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name:  "promote to admin",
+			email: "admin@example.com",
+			role:  models.UserRoleAdmin,
+			mockBehavior: func(repo *mocks.MockUserRepository) {
+				repo.EXPECT().
+					GetByEmail(gomock.Any(), "admin@example.com").
+					Return(&models.User{ID: userID, Email: "admin@example.com", Role: models.UserRoleUser}, nil)
+				repo.EXPECT().
+					UpdateRole(gomock.Any(), userID, models.UserRoleAdmin).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:         "invalid role",
+			email:        "admin@example.com",
+			role:         models.UserRole("root"),
+			mockBehavior: func(_ *mocks.MockUserRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidRole,
+		},
+		{
+			name:  "user not found",
+			email: "missing@example.com",
+			role:  models.UserRoleAdmin,
+			mockBehavior: func(repo *mocks.MockUserRepository) {
+				repo.EXPECT().
+					GetByEmail(gomock.Any(), "missing@example.com").
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			tt.mockBehavior(userRepo)
+
+			s := NewAuthService(testLogger(), userRepo, nil, nil, nil, "test-secret", time.Hour, 24*time.Hour)
+
+			user, err := s.SetRole(context.Background(), tt.email, tt.role)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.role, user.Role)
+		})
+	}
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	userID := uuid.New()
+	familyID := uuid.New()
+	tokenID := uuid.New()
+	refreshToken := "refresh-token"
+
+	stored := func() *models.RefreshToken {
+		return &models.RefreshToken{
+			ID:        tokenID,
+			UserID:    userID,
+			FamilyID:  familyID,
+			TokenHash: hashRefreshToken(refreshToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	type mockBehavior func(
+		userRepo *mocks.MockUserRepository,
+		refreshRepo *mocks.MockRefreshTokenRepository,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "success rotates token within family",
+			mockBehavior: func(
+				userRepo *mocks.MockUserRepository,
+				refreshRepo *mocks.MockRefreshTokenRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				refreshRepo.EXPECT().
+					GetByHash(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(stored(), nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				refreshRepo.EXPECT().
+					MarkUsed(gomock.Any(), tokenID).
+					Return(nil)
+				userRepo.EXPECT().
+					GetByID(gomock.Any(), userID).
+					Return(&models.User{ID: userID, Role: models.UserRoleUser}, nil)
+				refreshRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, token *models.RefreshToken) error {
+						assert.Equal(t, familyID, token.FamilyID)
+						assert.NotEqual(t, hashRefreshToken(refreshToken), token.TokenHash)
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown token",
+			mockBehavior: func(
+				_ *mocks.MockUserRepository,
+				refreshRepo *mocks.MockRefreshTokenRepository,
+				_ *mocks.MockTxManager,
+			) {
+				refreshRepo.EXPECT().
+					GetByHash(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidToken,
+		},
+		{
+			name: "expired token",
+			mockBehavior: func(
+				_ *mocks.MockUserRepository,
+				refreshRepo *mocks.MockRefreshTokenRepository,
+				_ *mocks.MockTxManager,
+			) {
+				token := stored()
+				token.ExpiresAt = time.Now().Add(-time.Minute)
+				refreshRepo.EXPECT().
+					GetByHash(gomock.Any(), gomock.Any()).
+					Return(token, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidToken,
+		},
+		{
+			name: "reused token revokes family",
+			mockBehavior: func(
+				_ *mocks.MockUserRepository,
+				refreshRepo *mocks.MockRefreshTokenRepository,
+				_ *mocks.MockTxManager,
+			) {
+				token := stored()
+				usedAt := time.Now().Add(-time.Minute)
+				token.UsedAt = &usedAt
+				refreshRepo.EXPECT().
+					GetByHash(gomock.Any(), gomock.Any()).
+					Return(token, nil)
+				refreshRepo.EXPECT().
+					RevokeFamily(gomock.Any(), familyID).
+					Return(nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrTokenReused,
+		},
+		{
+			name: "concurrent rotation revokes family",
+			mockBehavior: func(
+				_ *mocks.MockUserRepository,
+				refreshRepo *mocks.MockRefreshTokenRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				refreshRepo.EXPECT().
+					GetByHash(gomock.Any(), gomock.Any()).
+					Return(stored(), nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				refreshRepo.EXPECT().
+					MarkUsed(gomock.Any(), tokenID).
+					Return(models.ErrTokenReused)
+				refreshRepo.EXPECT().
+					RevokeFamily(gomock.Any(), familyID).
+					Return(nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrTokenReused,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(userRepo, refreshRepo, txManager)
+
+			s := NewAuthService(
+				testLogger(),
+				userRepo,
+				refreshRepo,
+				nil,
+				txManager,
+				"test-secret",
+				time.Hour,
+				time.Hour,
+			)
+
+			tokens, err := s.Refresh(context.Background(), refreshToken)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, tokens.AccessToken)
+			assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+		})
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	userID := uuid.New()
+	familyID := uuid.New()
+
+	claims := &models.TokenClaims{
+		UserID:    userID,
+		Role:      models.UserRoleUser,
+		ID:        uuid.NewString(),
+		ExpiresAt: time.Now().Add(10 * time.Minute),
+	}
+
+	type mockBehavior func(
+		refreshRepo *mocks.MockRefreshTokenRepository,
+		denylist *mocks.MockTokenDenylistRepository,
+	)
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name:         "revokes access token and refresh family",
+			refreshToken: "refresh-token",
+			mockBehavior: func(
+				refreshRepo *mocks.MockRefreshTokenRepository,
+				denylist *mocks.MockTokenDenylistRepository,
+			) {
+				denylist.EXPECT().
+					Revoke(gomock.Any(), claims.ID, gomock.Any()).
+					Return(nil)
+				refreshRepo.EXPECT().
+					GetByHash(gomock.Any(), hashRefreshToken("refresh-token")).
+					Return(&models.RefreshToken{UserID: userID, FamilyID: familyID}, nil)
+				refreshRepo.EXPECT().
+					RevokeFamily(gomock.Any(), familyID).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "access token only",
+			mockBehavior: func(_ *mocks.MockRefreshTokenRepository, denylist *mocks.MockTokenDenylistRepository) {
+				denylist.EXPECT().
+					Revoke(gomock.Any(), claims.ID, gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:         "refresh token of another user is ignored",
+			refreshToken: "refresh-token",
+			mockBehavior: func(
+				refreshRepo *mocks.MockRefreshTokenRepository,
+				denylist *mocks.MockTokenDenylistRepository,
+			) {
+				denylist.EXPECT().
+					Revoke(gomock.Any(), claims.ID, gomock.Any()).
+					Return(nil)
+				refreshRepo.EXPECT().
+					GetByHash(gomock.Any(), gomock.Any()).
+					Return(&models.RefreshToken{UserID: uuid.New(), FamilyID: familyID}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "denylist error",
+			mockBehavior: func(_ *mocks.MockRefreshTokenRepository, denylist *mocks.MockTokenDenylistRepository) {
+				denylist.EXPECT().
+					Revoke(gomock.Any(), claims.ID, gomock.Any()).
+					Return(assert.AnError)
 			},
 			wantErr: true,
 		},
@@ -262,14 +667,76 @@ func TestAuthService_ParseToken(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			parsedID, err := s.ParseToken(tt.token())
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			refreshRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+			denylist := mocks.NewMockTokenDenylistRepository(ctrl)
+			tt.mockBehavior(refreshRepo, denylist)
+
+			s := NewAuthService(testLogger(), nil, refreshRepo, denylist, nil, "test-secret", time.Hour, time.Hour)
+
+			err := s.Logout(context.Background(), claims, tt.refreshToken)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
 			}
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.wantID, parsedID)
+		})
+	}
+}
+
+func TestAuthService_ValidateToken(t *testing.T) {
+	secretKey := "test-secret"
+	userID := uuid.New()
+	tokenID := uuid.NewString()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID.String(),
+		"role":    "user",
+		"jti":     tokenID,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte(secretKey))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		token       string
+		revoked     bool
+		denylistErr error
+		wantErrType error
+	}{
+		{name: "active token", token: signed},
+		{name: "revoked token", token: signed, revoked: true, wantErrType: models.ErrTokenRevoked},
+		{name: "denylist unavailable", token: signed, denylistErr: assert.AnError, wantErrType: assert.AnError},
+		{name: "malformed token", token: "not-a-jwt", wantErrType: models.ErrInvalidAccessToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			denylist := mocks.NewMockTokenDenylistRepository(ctrl)
+			if tt.token == signed {
+				denylist.EXPECT().
+					IsRevoked(gomock.Any(), tokenID).
+					Return(tt.revoked, tt.denylistErr)
+			}
+
+			s := NewAuthService(testLogger(), nil, nil, denylist, nil, secretKey, time.Hour, time.Hour)
+
+			claims, err := s.ValidateToken(context.Background(), tt.token)
+			if tt.wantErrType != nil {
+				require.ErrorIs(t, err, tt.wantErrType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, userID, claims.UserID)
+			assert.Equal(t, tokenID, claims.ID)
 		})
 	}
 }
@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"log/slog"
-	"sync"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -14,57 +19,575 @@ import (
 	"github.com/yohnnn/booking_service/internal/repository"
 )
 
+const (
+	bestSeatsAttempts     = 3
+	seatCandidatesPerSeat = 20
+	minSeatCandidates     = 200
+)
+
 type BookingService struct {
-	logger        *slog.Logger
-	bookingRepo   repository.BookingRepository
-	concertRepo   repository.ConcertRepository
-	cacheRepo     cache.ConcertCacheRepository
-	manager       TxManager
-	eventProducer event.EventProducer
-	wg            sync.WaitGroup
+	logger       *slog.Logger
+	bookingRepo  repository.BookingRepository
+	concertRepo  repository.ConcertRepository
+	tierRepo     repository.TicketTierRepository
+	presaleRepo  repository.PresaleRepository
+	waitlistRepo repository.WaitlistRepository
+	promoRepo    repository.PromoCodeRepository
+	outboxRepo   repository.OutboxRepository
+	ledgerRepo   repository.LedgerRepository
+	cacheRepo    cache.ConcertCacheRepository
+	seatMap      cache.SeatMapRepository
+	waitingRoom  cache.WaitingRoomRepository
+	manager      TxManager
+	holdTTL      time.Duration
+	offerTTL     time.Duration
+	fees         models.FeePolicy
+	codeKey      []byte
 }
 
 func NewBookingService(
 	logger *slog.Logger,
 	bookingRepo repository.BookingRepository,
 	concertRepo repository.ConcertRepository,
+	tierRepo repository.TicketTierRepository,
+	presaleRepo repository.PresaleRepository,
+	waitlistRepo repository.WaitlistRepository,
+	promoRepo repository.PromoCodeRepository,
+	outboxRepo repository.OutboxRepository,
+	ledgerRepo repository.LedgerRepository,
 	cacheRepo cache.ConcertCacheRepository,
+	seatMap cache.SeatMapRepository,
+	waitingRoom cache.WaitingRoomRepository,
 	manager TxManager,
-	eventProducer event.EventProducer,
+	holdTTL time.Duration,
+	offerTTL time.Duration,
+	fees models.FeePolicy,
+	codeKey string,
 ) *BookingService {
 	return &BookingService{
-		logger:        logger,
-		bookingRepo:   bookingRepo,
-		concertRepo:   concertRepo,
-		cacheRepo:     cacheRepo,
-		manager:       manager,
-		eventProducer: eventProducer,
+		logger:       logger,
+		bookingRepo:  bookingRepo,
+		concertRepo:  concertRepo,
+		tierRepo:     tierRepo,
+		presaleRepo:  presaleRepo,
+		waitlistRepo: waitlistRepo,
+		promoRepo:    promoRepo,
+		outboxRepo:   outboxRepo,
+		ledgerRepo:   ledgerRepo,
+		cacheRepo:    cacheRepo,
+		seatMap:      seatMap,
+		waitingRoom:  waitingRoom,
+		manager:      manager,
+		holdTTL:      holdTTL,
+		offerTTL:     offerTTL,
+		fees:         fees,
+		codeKey:      []byte(codeKey),
 	}
 }
 
-func (s *BookingService) CreateBooking(
+func (s *BookingService) CreateBookings(
+	ctx context.Context,
+	userID uuid.UUID,
+	req models.BookingRequest,
+) ([]models.Booking, error) {
+	explicit := len(req.Seats) > 0 || len(req.SeatIDs) > 0
+	if !explicit && req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	if !explicit && req.Quantity < 1 {
+		return nil, models.ErrInvalidSeat
+	}
+
+	queued, err := s.checkAdmission(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var bookings []models.Booking
+
+	err = s.manager.WithTx(ctx, func(ctx context.Context) error {
+		concert, err := s.concertRepo.GetByID(ctx, req.ConcertID)
+		if err != nil {
+			return fmt.Errorf("failed to get concert: %w", err)
+		}
+
+		if concert.Status != models.ConcertStatusOnSale {
+			return fmt.Errorf("%w: %s", models.ErrConcertNotOnSale, concert.Status)
+		}
+
+		for _, seat := range req.Seats {
+			if seat < 1 || seat > concert.TotalSeats {
+				return models.ErrInvalidSeat
+			}
+		}
+
+		if !concert.Date.After(time.Now()) {
+			return models.ErrConcertPassed
+		}
+
+		if err := s.checkSaleWindow(ctx, concert, userID, req.AccessCode); err != nil {
+			return err
+		}
+
+		assignments, err := s.assignSeats(ctx, concert.ID, req)
+		if err != nil {
+			return err
+		}
+
+		perTier := make(map[uuid.UUID]int)
+		for _, a := range assignments {
+			perTier[a.TierID]++
+		}
+		tierIDs := slices.SortedFunc(maps.Keys(perTier), func(a, b uuid.UUID) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, tierID := range tierIDs {
+			if err := s.tierRepo.Decrement(ctx, tierID, perTier[tierID]); err != nil {
+				return fmt.Errorf("failed to decrement tier %s inventory: %w", tierID, err)
+			}
+		}
+
+		if err := s.concertRepo.DecrementSeats(ctx, concert.ID, len(assignments)); err != nil {
+			return fmt.Errorf("failed to decrement seats (maybe sold out): %w", err)
+		}
+
+		var promo *models.PromoCode
+		if req.PromoCode != "" {
+			promo, err = s.checkPromoCode(ctx, userID, concert.ID, req.PromoCode, len(assignments))
+			if err != nil {
+				return err
+			}
+		}
+
+		expiresAt := time.Now().Add(s.holdTTL)
+		bookings = make([]models.Booking, 0, len(assignments))
+		for _, a := range assignments {
+			booking := models.Booking{
+				UserID:     userID,
+				ConcertID:  concert.ID,
+				SeatNumber: a.Ordinal,
+				TierID:     a.TierID,
+				FaceValue:  a.Price,
+				Status:     models.BookingStatusPending,
+				ExpiresAt:  &expiresAt,
+			}
+
+			if promo != nil {
+				discount, err := promo.Discount(a.Price)
+				if err != nil {
+					return err
+				}
+				booking.Discount = discount
+				booking.PromoCodeID = &promo.ID
+			}
+			s.fees.Apply(&booking)
+
+			if err := s.bookingRepo.Create(ctx, &booking); err != nil {
+				return fmt.Errorf("failed to create booking record for seat %d: %w", a.Ordinal, err)
+			}
+
+			bookings = append(bookings, booking)
+		}
+
+		if promo != nil {
+			bookingIDs := make([]uuid.UUID, len(bookings))
+			for i, b := range bookings {
+				bookingIDs[i] = b.ID
+			}
+			if err := s.promoRepo.Redeem(ctx, promo.ID, userID, bookingIDs); err != nil {
+				return err
+			}
+		}
+
+		if err := postLedger(ctx, s.ledgerRepo, saleTransactions(bookings)...); err != nil {
+			return err
+		}
+
+		evt := event.BookingCreatedEvent{
+			BookingID:  bookings[0].ID.String(),
+			BookingIDs: make([]string, 0, len(bookings)),
+			UserID:     userID.String(),
+			ConcertID:  concert.ID.String(),
+			Seat:       bookings[0].SeatNumber,
+			Seats:      make([]int, 0, len(bookings)),
+		}
+		total := models.Money{Currency: bookings[0].Price.Currency}
+		for _, b := range bookings {
+			evt.BookingIDs = append(evt.BookingIDs, b.ID.String())
+			evt.Seats = append(evt.Seats, b.SeatNumber)
+			if total, err = total.Add(b.Price); err != nil {
+				return err
+			}
+			evt.FaceValue += b.FaceValue.Amount
+			evt.Discount += b.Discount.Amount
+			evt.ServiceFee += b.ServiceFee.Amount
+			evt.VAT += b.VAT.Amount
+		}
+		evt.Amount = total.Amount
+		evt.Currency = total.Currency
+		if promo != nil {
+			evt.PromoCode = promo.Code
+		}
+
+		return enqueueEvent(ctx, s.outboxRepo, event.TypeBookingCreated, evt.BookingID, evt)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	afterCommit(ctx, func(ctx context.Context) {
+		_ = s.cacheRepo.Delete(ctx)
+
+		seats := make([]int, 0, len(bookings))
+		for _, b := range bookings {
+			seats = append(seats, b.SeatNumber)
+		}
+		s.syncSeatMap(ctx, req.ConcertID, seats, models.SeatHeld)
+
+		if queued {
+			if err := s.waitingRoom.Consume(ctx, req.ConcertID, userID, req.QueueToken); err != nil {
+				s.logger.WarnContext(ctx, "failed to consume queue token", "concert_id", req.ConcertID, "error", err)
+			}
+		}
+	})
+
+	return bookings, nil
+}
+
+func (s *BookingService) assignSeats(
+	ctx context.Context,
+	concertID uuid.UUID,
+	req models.BookingRequest,
+) ([]models.SeatAssignment, error) {
+	if len(req.Seats) == 0 && len(req.SeatIDs) == 0 {
+		if req.TierID != nil {
+			tier, err := s.tierRepo.GetByID(ctx, *req.TierID)
+			if errors.Is(err, models.ErrNotFound) || (err == nil && tier.ConcertID != concertID) {
+				return nil, models.ErrInvalidTier
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ticket tier: %w", err)
+			}
+		}
+
+		return s.assignBestSeats(ctx, concertID, req.TierID, req.Quantity)
+	}
+
+	var (
+		assignments []models.SeatAssignment
+		err         error
+	)
+	if len(req.SeatIDs) > 0 {
+		assignments, err = s.tierRepo.ResolveSeatIDs(ctx, concertID, req.SeatIDs)
+	} else {
+		assignments, err = s.tierRepo.ResolveSeats(ctx, concertID, req.Seats)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(assignments) != len(req.Seats)+len(req.SeatIDs) {
+		return nil, models.ErrInvalidSeat
+	}
+
+	bySeat := make(map[int]models.SeatAssignment, len(assignments))
+	byID := make(map[uuid.UUID]models.SeatAssignment, len(assignments))
+	for _, a := range assignments {
+		if req.TierID != nil && a.TierID != *req.TierID {
+			return nil, models.ErrSeatNotInTier
+		}
+		bySeat[a.Ordinal] = a
+		byID[a.SeatID] = a
+	}
+
+	ordered := make([]models.SeatAssignment, 0, len(assignments))
+	for _, seat := range req.Seats {
+		ordered = append(ordered, bySeat[seat])
+	}
+	for _, seatID := range req.SeatIDs {
+		ordered = append(ordered, byID[seatID])
+	}
+	return ordered, nil
+}
+
+func (s *BookingService) checkSaleWindow(
+	ctx context.Context,
+	concert *models.Concert,
+	userID uuid.UUID,
+	accessCode string,
+) error {
+	now := time.Now()
+
+	if concert.SaleEndsAt != nil && !now.Before(*concert.SaleEndsAt) {
+		return models.ErrSaleEnded
+	}
+	if concert.SaleStartsAt == nil || !now.Before(*concert.SaleStartsAt) {
+		return nil
+	}
+
+	presales, err := s.presaleRepo.GetByConcertID(ctx, concert.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get presales: %w", err)
+	}
+
+	accessCode = normalizeAccessCode(accessCode)
+	codeHash := ""
+	if accessCode != "" {
+		codeHash = hashAccessCode(s.codeKey, accessCode)
+	}
+
+	inPresale := false
+	for _, p := range presales {
+		if !p.ActiveAt(now) {
+			continue
+		}
+		inPresale = true
+		if slices.Contains(p.UserIDs, userID) {
+			return nil
+		}
+		if codeHash != "" && p.AccessCodeHash != "" &&
+			subtle.ConstantTimeCompare([]byte(codeHash), []byte(p.AccessCodeHash)) == 1 {
+			return nil
+		}
+	}
+
+	switch {
+	case !inPresale:
+		return models.ErrSaleNotStarted
+	case accessCode != "":
+		return models.ErrInvalidAccessCode
+	default:
+		return models.ErrPresaleAccessRequired
+	}
+}
+
+func (s *BookingService) checkPromoCode(
 	ctx context.Context,
 	userID, concertID uuid.UUID,
-	seat int,
+	code string,
+	tickets int,
+) (*models.PromoCode, error) {
+	promo, err := s.promoRepo.GetByCodeForUpdate(ctx, normalizePromoCode(code))
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, fmt.Errorf("%w: unknown code", models.ErrPromoCodeNotApplicable)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !promo.ActiveAt(time.Now()) {
+		return nil, fmt.Errorf("%w: code is not active", models.ErrPromoCodeNotApplicable)
+	}
+	if !promo.AppliesTo(concertID) {
+		return nil, fmt.Errorf("%w: code is for another concert", models.ErrPromoCodeNotApplicable)
+	}
+
+	if promo.MaxUsesPerUser != nil {
+		used, err := s.promoRepo.CountRedemptions(ctx, promo.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if used+tickets > *promo.MaxUsesPerUser {
+			return nil, models.ErrPromoCodeUserLimit
+		}
+	}
+
+	if promo.MaxUses != nil && promo.UsedCount+tickets > *promo.MaxUses {
+		return nil, models.ErrPromoCodeExhausted
+	}
+
+	return promo, nil
+}
+
+func (s *BookingService) checkAdmission(
+	ctx context.Context,
+	userID uuid.UUID,
+	req models.BookingRequest,
+) (bool, error) {
+	active, err := s.waitingRoom.IsActive(ctx, req.ConcertID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check waiting room: %w", err)
+	}
+	if !active {
+		return false, nil
+	}
+	if req.QueueToken == "" {
+		return false, models.ErrNotAdmitted
+	}
+
+	admitted, err := s.waitingRoom.IsAdmitted(ctx, req.ConcertID, userID, req.QueueToken, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to check waiting room admission: %w", err)
+	}
+	if !admitted {
+		return false, models.ErrNotAdmitted
+	}
+	return true, nil
+}
+
+func (s *BookingService) assignBestSeats(
+	ctx context.Context,
+	concertID uuid.UUID,
+	tierID *uuid.UUID,
+	quantity int,
+) ([]models.SeatAssignment, error) {
+	skipped := make(map[uuid.UUID]bool)
+
+	for range bestSeatsAttempts {
+		limit := max(quantity*seatCandidatesPerSeat, minSeatCandidates) + len(skipped)
+		candidates, err := s.tierRepo.FindSeatCandidates(ctx, concertID, tierID, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = slices.DeleteFunc(candidates, func(c models.SeatCandidate) bool {
+			return skipped[c.SeatID]
+		})
+		if len(candidates) < quantity {
+			return nil, models.ErrNoSeats
+		}
+
+		chosen := pickBestSeats(candidates, quantity)
+		seatIDs := make([]uuid.UUID, 0, len(chosen))
+		for _, c := range chosen {
+			seatIDs = append(seatIDs, c.SeatID)
+		}
+
+		locked, err := s.tierRepo.LockFreeSeats(ctx, concertID, seatIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(locked) == quantity {
+			return locked, nil
+		}
+
+		for _, id := range seatIDs {
+			skipped[id] = true
+		}
+		for _, a := range locked {
+			delete(skipped, a.SeatID)
+		}
+	}
+
+	return nil, models.ErrNoSeats
+}
+
+func pickBestSeats(candidates []models.SeatCandidate, quantity int) []models.SeatCandidate {
+	start := 0
+	for i := 1; i <= len(candidates); i++ {
+		if i < len(candidates) &&
+			candidates[i].RowID == candidates[i-1].RowID &&
+			candidates[i].Number == candidates[i-1].Number+1 {
+			continue
+		}
+		if i-start >= quantity {
+			return candidates[start : start+quantity]
+		}
+		start = i
+	}
+
+	return candidates[:quantity]
+}
+
+func (s *BookingService) CancelBooking(ctx context.Context, userID, bookingID uuid.UUID) (*models.Booking, error) {
+	return s.cancel(ctx, userID, bookingID, false)
+}
+
+func (s *BookingService) ReleaseBooking(ctx context.Context, userID, bookingID uuid.UUID) (*models.Booking, error) {
+	return s.cancel(ctx, userID, bookingID, true)
+}
+
+func (s *BookingService) cancel(
+	ctx context.Context,
+	userID, bookingID uuid.UUID,
+	allowConfirmed bool,
 ) (*models.Booking, error) {
+	var (
+		booking *models.Booking
+		offers  []models.Booking
+	)
+
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		booking, err = s.bookingRepo.GetByID(ctx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+
+		if booking.UserID != userID {
+			return models.ErrForbidden
+		}
+
+		if booking.Status == models.BookingStatusCancelled {
+			return models.ErrBookingCancelled
+		}
+
+		if booking.Status == models.BookingStatusConfirmed && !allowConfirmed {
+			return models.ErrRefundRequired
+		}
+
+		if err := s.bookingRepo.Cancel(ctx, booking.ID, booking.Status); err != nil {
+			return fmt.Errorf("failed to cancel booking: %w", err)
+		}
+
+		if booking.Status == models.BookingStatusPending {
+			reversals := saleReversalTransactions([]models.Booking{*booking})
+			if err := postLedger(ctx, s.ledgerRepo, reversals...); err != nil {
+				return err
+			}
+		}
+
+		booking.Status = models.BookingStatusCancelled
+
+		offers, err = s.releaseSeats(ctx, []models.Booking{*booking})
+		if err != nil {
+			return err
+		}
+
+		return s.enqueueCancelled(ctx, *booking)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	afterCommit(ctx, func(ctx context.Context) {
+		_ = s.cacheRepo.Delete(ctx)
+		s.syncReleasedSeats(ctx, []models.Booking{*booking}, offers)
+	})
+
+	return booking, nil
+}
+
+func (s *BookingService) ConfirmBooking(ctx context.Context, userID, bookingID uuid.UUID) (*models.Booking, error) {
 	var booking *models.Booking
 
 	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
-		if err := s.concertRepo.DecrementSeats(ctx, concertID); err != nil {
-			return fmt.Errorf("failed to decrement seats (maybe sold out): %w", err)
+		var err error
+		booking, err = s.bookingRepo.GetByID(ctx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+
+		if booking.UserID != userID {
+			return models.ErrForbidden
 		}
 
-		booking = &models.Booking{
-			UserID:     userID,
-			ConcertID:  concertID,
-			SeatNumber: seat,
-			Status:     models.BookingStatusConfirmed,
+		if booking.Status != models.BookingStatusPending {
+			return models.ErrNotPending
 		}
 
-		if err := s.bookingRepo.Create(ctx, booking); err != nil {
-			return fmt.Errorf("failed to create booking record: %w", err)
+		if err := s.bookingRepo.Confirm(ctx, booking.ID); err != nil {
+			return fmt.Errorf("failed to confirm booking: %w", err)
 		}
 
+		if err := s.waitlistRepo.MarkClaimed(ctx, booking.ID); err != nil {
+			return err
+		}
+
+		booking.Status = models.BookingStatusConfirmed
+		booking.ExpiresAt = nil
+
 		return nil
 	})
 
@@ -72,29 +595,265 @@ func (s *BookingService) CreateBooking(
 		return nil, err
 	}
 
-	_ = s.cacheRepo.Delete(ctx)
+	afterCommit(ctx, func(ctx context.Context) {
+		s.syncSeatMap(ctx, booking.ConcertID, []int{booking.SeatNumber}, models.SeatSold)
+	})
+
+	return booking, nil
+}
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
+func (s *BookingService) ReleaseExpiredHolds(ctx context.Context, limit int) (int, error) {
+	var expired, offers []models.Booking
 
-		evt := event.BookingCreatedEvent{
-			BookingID: booking.ID.String(),
-			UserID:    booking.UserID.String(),
-			ConcertID: booking.ConcertID.String(),
-			Seat:      booking.SeatNumber,
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		expired, err = s.bookingRepo.ExpireHolds(ctx, limit)
+		if err != nil {
+			return fmt.Errorf("failed to expire holds: %w", err)
 		}
 
-		if err := s.eventProducer.SendBookingCreated(context.Background(), evt); err != nil {
-			s.logger.Error("failed to send booking event", "error", err)
+		if len(expired) == 0 {
+			return nil
 		}
-	}()
 
-	return booking, nil
+		if err := postLedger(ctx, s.ledgerRepo, saleReversalTransactions(expired)...); err != nil {
+			return err
+		}
+
+		offers, err = s.releaseSeats(ctx, expired)
+		if err != nil {
+			return err
+		}
+
+		for _, b := range expired {
+			if err := s.enqueueCancelled(ctx, b); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	afterCommit(ctx, func(ctx context.Context) {
+		_ = s.cacheRepo.Delete(ctx)
+		s.syncReleasedSeats(ctx, expired, offers)
+	})
+
+	return len(expired), nil
 }
 
-func (s *BookingService) Wait() {
-	s.wg.Wait()
+func (s *BookingService) releaseSeats(ctx context.Context, released []models.Booking) ([]models.Booking, error) {
+	bookingIDs := make([]uuid.UUID, len(released))
+	byConcert := make(map[uuid.UUID][]models.Booking)
+	var concertIDs []uuid.UUID
+	for i, b := range released {
+		bookingIDs[i] = b.ID
+		if _, ok := byConcert[b.ConcertID]; !ok {
+			concertIDs = append(concertIDs, b.ConcertID)
+		}
+		byConcert[b.ConcertID] = append(byConcert[b.ConcertID], b)
+	}
+
+	if err := s.waitlistRepo.ExpireOffers(ctx, bookingIDs); err != nil {
+		return nil, err
+	}
+
+	var discounted []uuid.UUID
+	for _, b := range released {
+		if b.PromoCodeID != nil {
+			discounted = append(discounted, b.ID)
+		}
+	}
+	if len(discounted) > 0 {
+		if err := s.promoRepo.Release(ctx, discounted); err != nil {
+			return nil, err
+		}
+	}
+
+	var offers []models.Booking
+	freed := make(map[uuid.UUID]int)
+	freedTiers := make(map[uuid.UUID]int)
+	for _, concertID := range concertIDs {
+		bookings := byConcert[concertID]
+
+		entries, err := s.waitlistRepo.NextWaiting(ctx, concertID, len(bookings))
+		if err != nil {
+			return nil, err
+		}
+
+		for i, b := range bookings {
+			if i < len(entries) {
+				offer, err := s.offerSeat(ctx, entries[i], b)
+				if err != nil {
+					return nil, err
+				}
+				offers = append(offers, *offer)
+				continue
+			}
+			freed[concertID]++
+			freedTiers[b.TierID]++
+		}
+	}
+
+	for tierID, count := range freedTiers {
+		if err := s.tierRepo.Increment(ctx, tierID, count); err != nil {
+			return nil, fmt.Errorf("failed to return seats to tier inventory: %w", err)
+		}
+	}
+
+	for concertID, count := range freed {
+		if err := s.concertRepo.IncrementSeats(ctx, concertID, count); err != nil {
+			return nil, fmt.Errorf("failed to return seats to inventory: %w", err)
+		}
+	}
+
+	return offers, nil
+}
+
+func (s *BookingService) offerSeat(
+	ctx context.Context,
+	entry models.WaitlistEntry,
+	released models.Booking,
+) (*models.Booking, error) {
+	expiresAt := time.Now().Add(s.offerTTL)
+	offer := &models.Booking{
+		UserID:     entry.UserID,
+		ConcertID:  released.ConcertID,
+		SeatNumber: released.SeatNumber,
+		TierID:     released.TierID,
+		FaceValue:  released.FaceValue,
+		Status:     models.BookingStatusPending,
+		ExpiresAt:  &expiresAt,
+	}
+	s.fees.Apply(offer)
+
+	if err := s.bookingRepo.Create(ctx, offer); err != nil {
+		return nil, fmt.Errorf("failed to create waitlist offer: %w", err)
+	}
+
+	if err := postLedger(ctx, s.ledgerRepo, saleTransactions([]models.Booking{*offer})...); err != nil {
+		return nil, err
+	}
+
+	if err := s.waitlistRepo.MarkOffered(ctx, entry.ID, offer.ID, expiresAt); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "waitlist offer made",
+		"concert_id", offer.ConcertID, "user_id", offer.UserID, "seat", offer.SeatNumber)
+
+	return offer, enqueueEvent(ctx, s.outboxRepo, event.TypeWaitlistOffered, entry.UserID.String(),
+		event.WaitlistOfferedEvent{
+			EntryID:   entry.ID.String(),
+			BookingID: offer.ID.String(),
+			UserID:    offer.UserID.String(),
+			ConcertID: offer.ConcertID.String(),
+			Seat:      offer.SeatNumber,
+			Amount:    offer.Price.Amount,
+			Currency:  offer.Price.Currency,
+			ExpiresAt: expiresAt,
+		})
+}
+
+func (s *BookingService) syncReleasedSeats(ctx context.Context, released, offers []models.Booking) {
+	offered := make(map[uuid.UUID][]int)
+	for _, o := range offers {
+		offered[o.ConcertID] = append(offered[o.ConcertID], o.SeatNumber)
+	}
+
+	freed := make(map[uuid.UUID][]int)
+	for _, b := range released {
+		if !slices.Contains(offered[b.ConcertID], b.SeatNumber) {
+			freed[b.ConcertID] = append(freed[b.ConcertID], b.SeatNumber)
+		}
+	}
+
+	for concertID, seats := range freed {
+		s.syncSeatMap(ctx, concertID, seats, models.SeatFree)
+	}
+	for concertID, seats := range offered {
+		s.syncSeatMap(ctx, concertID, seats, models.SeatHeld)
+	}
+}
+
+func (s *BookingService) GetSeatMap(
+	ctx context.Context,
+	concertID uuid.UUID,
+	showDrafts bool,
+) (*models.SeatAvailability, error) {
+	seats, found, err := s.seatMap.Get(ctx, concertID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to read seat map from cache", "concert_id", concertID, "error", err)
+	}
+
+	var version int64
+	cacheable := !found
+	if !found {
+		if version, err = s.seatMap.Version(ctx, concertID); err != nil {
+			s.logger.WarnContext(ctx, "failed to read seat map version", "concert_id", concertID, "error", err)
+			cacheable = false
+		}
+	}
+
+	concert, err := s.concertRepo.GetByID(ctx, concertID)
+	if err != nil {
+		return nil, err
+	}
+	if concert.Status == models.ConcertStatusDraft && !showDrafts {
+		return nil, models.ErrNotFound
+	}
+
+	if !found {
+		bookings, err := s.bookingRepo.GetActiveByConcertID(ctx, concertID)
+		if err != nil {
+			return nil, err
+		}
+
+		seats = models.NewSeatMap(concert.TotalSeats)
+		for _, b := range bookings {
+			state := models.SeatHeld
+			if b.Status == models.BookingStatusConfirmed {
+				state = models.SeatSold
+			}
+			seats.Set(b.SeatNumber, state)
+		}
+
+		if cacheable {
+			if _, err := s.seatMap.Set(ctx, concertID, seats, version); err != nil {
+				s.logger.WarnContext(ctx, "failed to cache seat map", "concert_id", concertID, "error", err)
+			}
+		}
+	}
+
+	return &models.SeatAvailability{
+		ConcertID:  concertID,
+		TotalSeats: concert.TotalSeats,
+		Seats:      seats,
+	}, nil
+}
+
+func (s *BookingService) syncSeatMap(ctx context.Context, concertID uuid.UUID, seats []int, state models.SeatState) {
+	if err := s.seatMap.SetStates(ctx, concertID, seats, state); err != nil {
+		s.logger.WarnContext(ctx, "failed to update seat map, dropping it", "concert_id", concertID, "error", err)
+		_ = s.seatMap.Delete(ctx, concertID)
+	}
+}
+
+func (s *BookingService) enqueueCancelled(ctx context.Context, booking models.Booking) error {
+	return enqueueEvent(ctx, s.outboxRepo, event.TypeBookingCancelled, booking.ID.String(), event.BookingCancelledEvent{
+		BookingID: booking.ID.String(),
+		UserID:    booking.UserID.String(),
+		ConcertID: booking.ConcertID.String(),
+		Seat:      booking.SeatNumber,
+	})
 }
 
 func (s *BookingService) GetUserBookings(ctx context.Context, userID uuid.UUID) ([]models.Booking, error) {
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
 	"testing"
 	"time"
 
@@ -10,51 +12,102 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/yohnnn/booking_service/internal/event"
 	"github.com/yohnnn/booking_service/internal/models"
 	"github.com/yohnnn/booking_service/internal/service/mocks"
 )
 
-func TestBookingService_CreateBooking(t *testing.T) {
+const (
+	testHoldTTL  = 15 * time.Minute
+	testOfferTTL = 30 * time.Minute
+
+	testAccessCodeKey = "test-access-code-key"
+)
+
+var testFeePolicy = models.FeePolicy{ServiceFeePercent: 10, VATPercent: 20, VATBase: models.VATBaseFee}
+
+func TestBookingService_CreateBookings(t *testing.T) {
 	userID := uuid.New()
 	concertID := uuid.New()
+	tierID := uuid.New()
+
+	concert := &models.Concert{
+		ID:             concertID,
+		Name:           "Rock Festival",
+		VenueName:      "Stadium",
+		Date:           time.Now().Add(24 * time.Hour),
+		Price:          models.NewMoney(10000, "RUB"),
+		TotalSeats:     100,
+		AvailableSeats: 50,
+		Status:         models.ConcertStatusOnSale,
+	}
+	pastConcert := *concert
+	pastConcert.Date = time.Now().Add(-time.Hour)
+	draftConcert := *concert
+	draftConcert.Status = models.ConcertStatusDraft
+	layoutSeats := seatAssignments(tierID, 21, 22)
 
 	type mockBehavior func(
 		bookingRepo *mocks.MockBookingRepository,
 		concertRepo *mocks.MockConcertRepository,
+		tierRepo *mocks.MockTicketTierRepository,
 		cacheRepo *mocks.MockConcertCacheRepository,
+		seatMap *mocks.MockSeatMapRepository,
+		waitingRoom *mocks.MockWaitingRoomRepository,
 		txManager *mocks.MockTxManager,
-		producer *mocks.MockEventProducer,
+		outboxRepo *mocks.MockOutboxRepository,
+		ledgerRepo *mocks.MockLedgerRepository,
 	)
 
 	tests := []struct {
 		name         string
 		userID       uuid.UUID
 		concertID    uuid.UUID
-		seat         int
+		tierID       *uuid.UUID
+		seats        []int
+		seatIDs      []uuid.UUID
+		quantity     int
+		queueToken   string
 		mockBehavior mockBehavior
 		wantErr      bool
 		wantErrType  error
-		checkResult  func(t *testing.T, booking *models.Booking)
+		checkResult  func(t *testing.T, bookings []models.Booking)
 	}{
 		{
 			name:      "success",
 			userID:    userID,
 			concertID: concertID,
-			seat:      1,
+			seats:     []int{1},
 			mockBehavior: func(
 				bookingRepo *mocks.MockBookingRepository,
 				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
 				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
 				txManager *mocks.MockTxManager,
-				producer *mocks.MockEventProducer,
+				outboxRepo *mocks.MockOutboxRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
 			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
 				txManager.EXPECT().
 					WithTx(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 				concertRepo.EXPECT().
-					DecrementSeats(gomock.Any(), concertID).
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{1}).
+					Return(seatAssignments(tierID, 1), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 1).
 					Return(nil)
 				bookingRepo.EXPECT().
 					Create(gomock.Any(), gomock.Any()).
@@ -66,39 +119,222 @@ func TestBookingService_CreateBooking(t *testing.T) {
 				cacheRepo.EXPECT().
 					Delete(gomock.Any()).
 					Return(nil)
-				producer.EXPECT().
-					SendBookingCreated(gomock.Any(), gomock.Any()).
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{1}, models.SeatHeld).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						for _, tx := range txs {
+							assert.Equal(t, models.LedgerTransactionSale, tx.Type)
+							assert.NoError(t, tx.Validate())
+						}
+						return nil
+					})
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			wantErr: false,
-			checkResult: func(t *testing.T, booking *models.Booking) {
+			checkResult: func(t *testing.T, bookings []models.Booking) {
 				t.Helper()
+				require.Len(t, bookings, 1)
+				booking := bookings[0]
 				assert.Equal(t, userID, booking.UserID)
 				assert.Equal(t, concertID, booking.ConcertID)
 				assert.Equal(t, 1, booking.SeatNumber)
-				assert.Equal(t, models.BookingStatusConfirmed, booking.Status)
+				assert.Equal(t, models.BookingStatusPending, booking.Status)
+				require.NotNil(t, booking.ExpiresAt)
+				assert.WithinDuration(t, time.Now().Add(testHoldTTL), *booking.ExpiresAt, time.Minute)
 				assert.NotEqual(t, uuid.Nil, booking.ID)
 			},
 		},
 		{
-			name:      "no seats available",
+			name:      "multiple seats booked atomically",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{10, 11, 12},
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{10, 11, 12}).
+					Return(seatAssignments(tierID, 10, 11, 12), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 3).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 3).
+					Return(nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, b *models.Booking) error {
+						b.ID = uuid.New()
+						b.CreatedAt = time.Now()
+						return nil
+					}).
+					Times(3)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{10, 11, 12}, models.SeatHeld).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *models.OutboxMessage) error {
+						var evt event.BookingCreatedEvent
+						require.NoError(t, json.Unmarshal(msg.Payload, &evt))
+						assert.Equal(t, event.TypeBookingCreated, msg.EventType)
+						assert.Equal(t, []int{10, 11, 12}, evt.Seats)
+						assert.Len(t, evt.BookingIDs, 3)
+						return nil
+					})
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, bookings []models.Booking) {
+				t.Helper()
+				require.Len(t, bookings, 3)
+				for i, seat := range []int{10, 11, 12} {
+					assert.Equal(t, seat, bookings[i].SeatNumber)
+					assert.Equal(t, models.BookingStatusPending, bookings[i].Status)
+				}
+			},
+		},
+		{
+			name:      "one of multiple seats already booked",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{10, 11},
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{10, 11}).
+					Return(seatAssignments(tierID, 10, 11), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 2).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 2).
+					Return(nil)
+				gomock.InOrder(
+					bookingRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil),
+					bookingRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(models.ErrAlreadyExists),
+				)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrAlreadyExists,
+		},
+		{
+			name:      "one of multiple seats out of range",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{10, 500},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidSeat,
+		},
+		{
+			name:      "no seats available in tier",
 			userID:    userID,
 			concertID: concertID,
-			seat:      1,
+			seats:     []int{1},
 			mockBehavior: func(
 				_ *mocks.MockBookingRepository,
 				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
 				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
 				txManager *mocks.MockTxManager,
-				_ *mocks.MockEventProducer,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
 			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
 				txManager.EXPECT().
 					WithTx(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 				concertRepo.EXPECT().
-					DecrementSeats(gomock.Any(), concertID).
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{1}).
+					Return(seatAssignments(tierID, 1), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 1).
 					Return(models.ErrNoSeats)
 			},
 			wantErr:     true,
@@ -108,21 +344,37 @@ func TestBookingService_CreateBooking(t *testing.T) {
 			name:      "seat already booked",
 			userID:    userID,
 			concertID: concertID,
-			seat:      5,
+			seats:     []int{5},
 			mockBehavior: func(
 				bookingRepo *mocks.MockBookingRepository,
 				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
 				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
 				txManager *mocks.MockTxManager,
-				_ *mocks.MockEventProducer,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
 			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
 				txManager.EXPECT().
 					WithTx(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 				concertRepo.EXPECT().
-					DecrementSeats(gomock.Any(), concertID).
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{5}).
+					Return(seatAssignments(tierID, 5), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 1).
 					Return(nil)
 				bookingRepo.EXPECT().
 					Create(gomock.Any(), gomock.Any()).
@@ -135,21 +387,37 @@ func TestBookingService_CreateBooking(t *testing.T) {
 			name:      "repository error on create",
 			userID:    userID,
 			concertID: concertID,
-			seat:      1,
+			seats:     []int{1},
 			mockBehavior: func(
 				bookingRepo *mocks.MockBookingRepository,
 				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
 				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
 				txManager *mocks.MockTxManager,
-				_ *mocks.MockEventProducer,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
 			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
 				txManager.EXPECT().
 					WithTx(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 				concertRepo.EXPECT().
-					DecrementSeats(gomock.Any(), concertID).
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{1}).
+					Return(seatAssignments(tierID, 1), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 1).
 					Return(nil)
 				bookingRepo.EXPECT().
 					Create(gomock.Any(), gomock.Any()).
@@ -158,122 +426,2296 @@ func TestBookingService_CreateBooking(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:      "transaction error",
+			name:      "concert cancelled before seats are taken",
 			userID:    userID,
 			concertID: concertID,
-			seat:      1,
+			seats:     []int{1},
 			mockBehavior: func(
 				_ *mocks.MockBookingRepository,
-				_ *mocks.MockConcertRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
 				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
 				txManager *mocks.MockTxManager,
-				_ *mocks.MockEventProducer,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
 			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
 				txManager.EXPECT().
 					WithTx(gomock.Any(), gomock.Any()).
-					Return(assert.AnError)
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{1}).
+					Return(seatAssignments(tierID, 1), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 1).
+					Return(models.ErrConcertNotOnSale)
 			},
-			wantErr: true,
+			wantErr:     true,
+			wantErrType: models.ErrConcertNotOnSale,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			bookingRepo := mocks.NewMockBookingRepository(ctrl)
-			concertRepo := mocks.NewMockConcertRepository(ctrl)
-			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
-			txManager := mocks.NewMockTxManager(ctrl)
-			producer := mocks.NewMockEventProducer(ctrl)
-
-			tt.mockBehavior(bookingRepo, concertRepo, cacheRepo, txManager, producer)
-
-			s := NewBookingService(testLogger(), bookingRepo, concertRepo, cacheRepo, txManager, producer)
-
-			booking, err := s.CreateBooking(context.Background(), tt.userID, tt.concertID, tt.seat)
-			if tt.wantErr {
-				require.Error(t, err)
-				if tt.wantErrType != nil {
-					assert.ErrorIs(t, err, tt.wantErrType)
-				}
-				return
-			}
-
-			require.NoError(t, err)
-			require.NotNil(t, booking)
-			if tt.checkResult != nil {
-				tt.checkResult(t, booking)
-			}
-
-			s.Wait()
-		})
-	}
-}
-
-func TestBookingService_GetUserBookings(t *testing.T) {
-	userID := uuid.New()
-
-	bookings := []models.Booking{
 		{
-			ID:         uuid.New(),
-			UserID:     userID,
-			ConcertID:  uuid.New(),
-			SeatNumber: 1,
-			Status:     models.BookingStatusConfirmed,
-			CreatedAt:  time.Now(),
+			name:      "outbox write error",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{1},
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{1}).
+					Return(seatAssignments(tierID, 1), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 1).
+					Return(nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+			},
+			wantErr:     true,
+			wantErrType: assert.AnError,
 		},
 		{
-			ID:         uuid.New(),
-			UserID:     userID,
-			ConcertID:  uuid.New(),
-			SeatNumber: 5,
-			Status:     models.BookingStatusConfirmed,
-			CreatedAt:  time.Now(),
+			name:      "concert not found",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{1},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
 		},
-	}
-
-	type mockBehavior func(repo *mocks.MockBookingRepository)
-
-	tests := []struct {
-		name         string
-		userID       uuid.UUID
-		mockBehavior mockBehavior
-		want         []models.Booking
-		wantErr      bool
-	}{
 		{
-			name:   "success",
-			userID: userID,
-			mockBehavior: func(repo *mocks.MockBookingRepository) {
-				repo.EXPECT().
-					GetByUserID(gomock.Any(), userID).
-					Return(bookings, nil)
+			name:      "seat above total seats",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{101},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
 			},
-			want:    bookings,
-			wantErr: false,
+			wantErr:     true,
+			wantErrType: models.ErrInvalidSeat,
 		},
 		{
-			name:   "empty bookings",
-			userID: userID,
-			mockBehavior: func(repo *mocks.MockBookingRepository) {
-				repo.EXPECT().
-					GetByUserID(gomock.Any(), userID).
-					Return(nil, nil)
+			name:      "waiting room active without token",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{1},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				_ *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(true, nil)
 			},
-			want:    nil,
-			wantErr: false,
+			wantErr:     true,
+			wantErrType: models.ErrNotAdmitted,
 		},
 		{
-			name:   "repository error",
-			userID: userID,
-			mockBehavior: func(repo *mocks.MockBookingRepository) {
-				repo.EXPECT().
-					GetByUserID(gomock.Any(), userID).
-					Return(nil, assert.AnError)
+			name:       "waiting room token not admitted",
+			userID:     userID,
+			concertID:  concertID,
+			seats:      []int{1},
+			queueToken: "queue-token",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				_ *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(true, nil)
+				waitingRoom.EXPECT().
+					IsAdmitted(gomock.Any(), concertID, userID, "queue-token", gomock.Any()).
+					Return(false, nil)
 			},
-			wantErr: true,
+			wantErr:     true,
+			wantErrType: models.ErrNotAdmitted,
+		},
+		{
+			name:       "admitted from waiting room consumes the token",
+			userID:     userID,
+			concertID:  concertID,
+			seats:      []int{1},
+			queueToken: "queue-token",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(true, nil)
+				waitingRoom.EXPECT().
+					IsAdmitted(gomock.Any(), concertID, userID, "queue-token", gomock.Any()).
+					Return(true, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{1}).
+					Return(seatAssignments(tierID, 1), nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 1).
+					Return(nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{1}, models.SeatHeld).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+				waitingRoom.EXPECT().
+					Consume(gomock.Any(), concertID, userID, "queue-token").
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, bookings []models.Booking) {
+				t.Helper()
+				require.Len(t, bookings, 1)
+			},
+		},
+		{
+			name:      "concert already passed",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{1},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&pastConcert, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrConcertPassed,
+		},
+		{
+			name:      "concert not on sale",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{1},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&draftConcert, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrConcertNotOnSale,
+		},
+		{
+			name:      "book by tier and quantity",
+			userID:    userID,
+			concertID: concertID,
+			tierID:    &tierID,
+			quantity:  2,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					GetByID(gomock.Any(), tierID).
+					Return(&models.TicketTier{ID: tierID, ConcertID: concertID}, nil)
+				candidates := append(
+					seatCandidates(tierID, uuid.New(), 1, 3),
+					seatCandidates(tierID, uuid.New(), 7, 8)...,
+				)
+				tierRepo.EXPECT().
+					FindSeatCandidates(gomock.Any(), concertID, &tierID, 200).
+					Return(candidates, nil)
+				tierRepo.EXPECT().
+					LockFreeSeats(gomock.Any(), concertID, []uuid.UUID{candidates[2].SeatID, candidates[3].SeatID}).
+					DoAndReturn(lockCandidates(candidates))
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 2).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 2).
+					Return(nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{7, 8}, models.SeatHeld).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *models.OutboxMessage) error {
+						var evt event.BookingCreatedEvent
+						require.NoError(t, json.Unmarshal(msg.Payload, &evt))
+						assert.Equal(t, []int{7, 8}, evt.Seats)
+						assert.Equal(t, int64(22400), evt.Amount)
+						assert.Equal(t, int64(20000), evt.FaceValue)
+						assert.Equal(t, int64(2000), evt.ServiceFee)
+						assert.Equal(t, int64(400), evt.VAT)
+						assert.Equal(t, "RUB", evt.Currency)
+						return nil
+					})
+			},
+			checkResult: func(t *testing.T, bookings []models.Booking) {
+				t.Helper()
+				require.Len(t, bookings, 2)
+				for _, b := range bookings {
+					assert.Equal(t, tierID, b.TierID)
+					assert.Equal(t, models.NewMoney(10000, "RUB"), b.FaceValue)
+					assert.Equal(t, models.NewMoney(1000, "RUB"), b.ServiceFee)
+					assert.Equal(t, models.NewMoney(200, "RUB"), b.VAT)
+					assert.Equal(t, models.NewMoney(11200, "RUB"), b.Price)
+				}
+			},
+		},
+		{
+			name:      "not enough free seats in tier",
+			userID:    userID,
+			concertID: concertID,
+			tierID:    &tierID,
+			quantity:  3,
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					GetByID(gomock.Any(), tierID).
+					Return(&models.TicketTier{ID: tierID, ConcertID: concertID}, nil)
+				tierRepo.EXPECT().
+					FindSeatCandidates(gomock.Any(), concertID, &tierID, 200).
+					Return(seatCandidates(tierID, uuid.New(), 7), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNoSeats,
+		},
+		{
+			name:      "best available seat without tier and quantity",
+			userID:    userID,
+			concertID: concertID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				candidates := seatCandidates(tierID, uuid.New(), 5, 6)
+				tierRepo.EXPECT().
+					FindSeatCandidates(gomock.Any(), concertID, nil, 200).
+					Return(candidates, nil)
+				tierRepo.EXPECT().
+					LockFreeSeats(gomock.Any(), concertID, []uuid.UUID{candidates[0].SeatID}).
+					DoAndReturn(lockCandidates(candidates))
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 1).
+					Return(nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{5}, models.SeatHeld).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, bookings []models.Booking) {
+				t.Helper()
+				require.Len(t, bookings, 1)
+				assert.Equal(t, 5, bookings[0].SeatNumber)
+			},
+		},
+		{
+			name:      "retry best available when chosen seat is taken",
+			userID:    userID,
+			concertID: concertID,
+			quantity:  2,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				candidates := seatCandidates(tierID, uuid.New(), 7, 8, 9, 10)
+				gomock.InOrder(
+					tierRepo.EXPECT().
+						FindSeatCandidates(gomock.Any(), concertID, nil, 200).
+						Return(candidates[:2], nil),
+					tierRepo.EXPECT().
+						LockFreeSeats(gomock.Any(), concertID, []uuid.UUID{candidates[0].SeatID, candidates[1].SeatID}).
+						DoAndReturn(lockCandidates(candidates[1:2])),
+					tierRepo.EXPECT().
+						FindSeatCandidates(gomock.Any(), concertID, nil, 201).
+						Return(candidates, nil),
+					tierRepo.EXPECT().
+						LockFreeSeats(gomock.Any(), concertID, []uuid.UUID{candidates[1].SeatID, candidates[2].SeatID}).
+						DoAndReturn(lockCandidates(candidates)),
+				)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 2).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 2).
+					Return(nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{8, 9}, models.SeatHeld).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, bookings []models.Booking) {
+				t.Helper()
+				require.Len(t, bookings, 2)
+				assert.Equal(t, 8, bookings[0].SeatNumber)
+				assert.Equal(t, 9, bookings[1].SeatNumber)
+			},
+		},
+		{
+			name:      "tier belongs to another concert",
+			userID:    userID,
+			concertID: concertID,
+			tierID:    &tierID,
+			quantity:  1,
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					GetByID(gomock.Any(), tierID).
+					Return(&models.TicketTier{ID: tierID, ConcertID: uuid.New()}, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidTier,
+		},
+		{
+			name:      "seat outside requested tier",
+			userID:    userID,
+			concertID: concertID,
+			tierID:    &tierID,
+			seats:     []int{3},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{3}).
+					Return(seatAssignments(uuid.New(), 3), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrSeatNotInTier,
+		},
+		{
+			name:      "seat without tier",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{3, 4},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeats(gomock.Any(), concertID, []int{3, 4}).
+					Return(seatAssignments(tierID, 3), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidSeat,
+		},
+		{
+			name:      "seats addressed by layout id",
+			userID:    userID,
+			concertID: concertID,
+			seatIDs:   []uuid.UUID{layoutSeats[1].SeatID, layoutSeats[0].SeatID},
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeatIDs(gomock.Any(), concertID, []uuid.UUID{layoutSeats[1].SeatID, layoutSeats[0].SeatID}).
+					Return(layoutSeats, nil)
+				tierRepo.EXPECT().
+					Decrement(gomock.Any(), tierID, 2).
+					Return(nil)
+				concertRepo.EXPECT().
+					DecrementSeats(gomock.Any(), concertID, 2).
+					Return(nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, b *models.Booking) error {
+						b.ID = uuid.New()
+						return nil
+					}).
+					Times(2)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{22, 21}, models.SeatHeld).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, bookings []models.Booking) {
+				t.Helper()
+				require.Len(t, bookings, 2)
+				assert.Equal(t, 22, bookings[0].SeatNumber)
+				assert.Equal(t, 21, bookings[1].SeatNumber)
+			},
+		},
+		{
+			name:      "unknown seat id",
+			userID:    userID,
+			concertID: concertID,
+			seatIDs:   []uuid.UUID{uuid.New()},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					ResolveSeatIDs(gomock.Any(), concertID, gomock.Len(1)).
+					Return(nil, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidSeat,
+		},
+		{
+			name:      "transaction error",
+			userID:    userID,
+			concertID: concertID,
+			seats:     []int{1},
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				waitingRoom.EXPECT().
+					IsActive(gomock.Any(), concertID).
+					Return(false, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			tierRepo := mocks.NewMockTicketTierRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			waitingRoom := mocks.NewMockWaitingRoomRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+
+			tt.mockBehavior(
+				bookingRepo,
+				concertRepo,
+				tierRepo,
+				cacheRepo,
+				seatMap,
+				waitingRoom,
+				txManager,
+				outboxRepo,
+				ledgerRepo,
+			)
+
+			s := NewBookingService(
+				testLogger(),
+				bookingRepo,
+				concertRepo,
+				tierRepo,
+				mocks.NewMockPresaleRepository(ctrl),
+				mocks.NewMockWaitlistRepository(ctrl),
+				mocks.NewMockPromoCodeRepository(ctrl),
+				outboxRepo,
+				ledgerRepo,
+				cacheRepo,
+				seatMap,
+				waitingRoom,
+				txManager,
+				testHoldTTL,
+				testOfferTTL,
+				testFeePolicy,
+				testAccessCodeKey,
+			)
+
+			bookings, err := s.CreateBookings(context.Background(), tt.userID, models.BookingRequest{
+				ConcertID:  tt.concertID,
+				TierID:     tt.tierID,
+				Seats:      tt.seats,
+				SeatIDs:    tt.seatIDs,
+				Quantity:   tt.quantity,
+				QueueToken: tt.queueToken,
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, bookings)
+			if tt.checkResult != nil {
+				tt.checkResult(t, bookings)
+			}
+		})
+	}
+}
+
+func TestBookingService_CreateBookingsWithPromoCode(t *testing.T) {
+	userID := uuid.New()
+	concertID := uuid.New()
+	tierID := uuid.New()
+
+	concert := &models.Concert{
+		ID:         concertID,
+		Date:       time.Now().Add(24 * time.Hour),
+		Price:      models.NewMoney(10000, "RUB"),
+		TotalSeats: 100,
+		Status:     models.ConcertStatusOnSale,
+	}
+	promo := func(discountType models.PromoDiscountType, value int64) *models.PromoCode {
+		return &models.PromoCode{
+			ID:           uuid.New(),
+			Code:         "SPRING",
+			DiscountType: discountType,
+			Value:        value,
+			Currency:     "RUB",
+			Active:       true,
+		}
+	}
+	limit := func(n int) *int {
+		return &n
+	}
+
+	type mockBehavior func(promoRepo *mocks.MockPromoCodeRepository)
+
+	tests := []struct {
+		name         string
+		code         string
+		mockBehavior mockBehavior
+		wantPrice    int64
+		wantDiscount int64
+		wantErrType  error
+	}{
+		{
+			name: "percentage discount on every ticket",
+			code: " spring ",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				p := promo(models.PromoDiscountPercent, 20)
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "SPRING").
+					Return(p, nil)
+				promoRepo.EXPECT().
+					Redeem(gomock.Any(), p.ID, userID, gomock.Len(2)).
+					Return(nil)
+			},
+			wantPrice:    8960,
+			wantDiscount: 2000,
+		},
+		{
+			name: "fixed discount for this concert within per-user limit",
+			code: "SPRING",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				p := promo(models.PromoDiscountFixed, 3000)
+				p.ConcertID = &concertID
+				p.MaxUsesPerUser = limit(3)
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "SPRING").
+					Return(p, nil)
+				promoRepo.EXPECT().
+					CountRedemptions(gomock.Any(), p.ID, userID).
+					Return(1, nil)
+				promoRepo.EXPECT().
+					Redeem(gomock.Any(), p.ID, userID, gomock.Len(2)).
+					Return(nil)
+			},
+			wantPrice:    7840,
+			wantDiscount: 3000,
+		},
+		{
+			name: "unknown code",
+			code: "NOPE",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "NOPE").
+					Return(nil, models.ErrNotFound)
+			},
+			wantErrType: models.ErrPromoCodeNotApplicable,
+		},
+		{
+			name: "code for another concert",
+			code: "SPRING",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				other := uuid.New()
+				p := promo(models.PromoDiscountPercent, 20)
+				p.ConcertID = &other
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "SPRING").
+					Return(p, nil)
+			},
+			wantErrType: models.ErrPromoCodeNotApplicable,
+		},
+		{
+			name: "expired code",
+			code: "SPRING",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				endedAt := time.Now().Add(-time.Hour)
+				p := promo(models.PromoDiscountPercent, 20)
+				p.EndsAt = &endedAt
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "SPRING").
+					Return(p, nil)
+			},
+			wantErrType: models.ErrPromoCodeNotApplicable,
+		},
+		{
+			name: "per-user limit counts every ticket",
+			code: "SPRING",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				p := promo(models.PromoDiscountPercent, 20)
+				p.MaxUsesPerUser = limit(2)
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "SPRING").
+					Return(p, nil)
+				promoRepo.EXPECT().
+					CountRedemptions(gomock.Any(), p.ID, userID).
+					Return(1, nil)
+			},
+			wantErrType: models.ErrPromoCodeUserLimit,
+		},
+		{
+			name: "per-user limit reached",
+			code: "SPRING",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				p := promo(models.PromoDiscountPercent, 20)
+				p.MaxUsesPerUser = limit(1)
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "SPRING").
+					Return(p, nil)
+				promoRepo.EXPECT().
+					CountRedemptions(gomock.Any(), p.ID, userID).
+					Return(1, nil)
+			},
+			wantErrType: models.ErrPromoCodeUserLimit,
+		},
+		{
+			name: "usage cap exhausted",
+			code: "SPRING",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				p := promo(models.PromoDiscountPercent, 20)
+				p.MaxUses = limit(100)
+				p.UsedCount = 99
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "SPRING").
+					Return(p, nil)
+			},
+			wantErrType: models.ErrPromoCodeExhausted,
+		},
+		{
+			name: "fixed discount covering the whole ticket",
+			code: "SPRING",
+			mockBehavior: func(promoRepo *mocks.MockPromoCodeRepository) {
+				p := promo(models.PromoDiscountFixed, 10000)
+				promoRepo.EXPECT().
+					GetByCodeForUpdate(gomock.Any(), "SPRING").
+					Return(p, nil)
+			},
+			wantErrType: models.ErrPromoCodeNotApplicable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			tierRepo := mocks.NewMockTicketTierRepository(ctrl)
+			promoRepo := mocks.NewMockPromoCodeRepository(ctrl)
+			outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			waitingRoom := mocks.NewMockWaitingRoomRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+
+			waitingRoom.EXPECT().
+				IsActive(gomock.Any(), concertID).
+				Return(false, nil)
+			txManager.EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+			concertRepo.EXPECT().
+				GetByID(gomock.Any(), concertID).
+				Return(concert, nil)
+			tierRepo.EXPECT().
+				ResolveSeats(gomock.Any(), concertID, []int{1, 2}).
+				Return(seatAssignments(tierID, 1, 2), nil)
+			tierRepo.EXPECT().
+				Decrement(gomock.Any(), tierID, 2).
+				Return(nil)
+			concertRepo.EXPECT().
+				DecrementSeats(gomock.Any(), concertID, 2).
+				Return(nil)
+			tt.mockBehavior(promoRepo)
+
+			if tt.wantErrType == nil {
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, b *models.Booking) error {
+						b.ID = uuid.New()
+						return nil
+					}).
+					Times(2)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 2)
+						for _, tx := range txs {
+							assert.NoError(t, tx.Validate())
+						}
+						return nil
+					})
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *models.OutboxMessage) error {
+						var evt event.BookingCreatedEvent
+						require.NoError(t, json.Unmarshal(msg.Payload, &evt))
+						assert.Equal(t, 2*tt.wantPrice, evt.Amount)
+						assert.Equal(t, 2*tt.wantDiscount, evt.Discount)
+						assert.Equal(t, "SPRING", evt.PromoCode)
+						return nil
+					})
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{1, 2}, models.SeatHeld).
+					Return(nil)
+			}
+
+			s := NewBookingService(
+				testLogger(),
+				bookingRepo,
+				concertRepo,
+				tierRepo,
+				mocks.NewMockPresaleRepository(ctrl),
+				mocks.NewMockWaitlistRepository(ctrl),
+				promoRepo,
+				outboxRepo,
+				ledgerRepo,
+				cacheRepo,
+				seatMap,
+				waitingRoom,
+				txManager,
+				testHoldTTL,
+				testOfferTTL,
+				testFeePolicy,
+				testAccessCodeKey,
+			)
+
+			bookings, err := s.CreateBookings(context.Background(), userID, models.BookingRequest{
+				ConcertID: concertID,
+				Seats:     []int{1, 2},
+				PromoCode: tt.code,
+			})
+			if tt.wantErrType != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantErrType)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, bookings, 2)
+			for _, b := range bookings {
+				assert.Equal(t, models.NewMoney(tt.wantPrice, "RUB"), b.Price)
+				assert.Equal(t, models.NewMoney(tt.wantDiscount, "RUB"), b.Discount)
+				require.NotNil(t, b.PromoCodeID)
+			}
+		})
+	}
+}
+
+func TestBookingService_GetUserBookings(t *testing.T) {
+	userID := uuid.New()
+
+	bookings := []models.Booking{
+		{
+			ID:         uuid.New(),
+			UserID:     userID,
+			ConcertID:  uuid.New(),
+			SeatNumber: 1,
+			Status:     models.BookingStatusConfirmed,
+			CreatedAt:  time.Now(),
+		},
+		{
+			ID:         uuid.New(),
+			UserID:     userID,
+			ConcertID:  uuid.New(),
+			SeatNumber: 5,
+			Status:     models.BookingStatusConfirmed,
+			CreatedAt:  time.Now(),
+		},
+	}
+
+	type mockBehavior func(repo *mocks.MockBookingRepository)
+
+	tests := []struct {
+		name         string
+		userID       uuid.UUID
+		mockBehavior mockBehavior
+		want         []models.Booking
+		wantErr      bool
+	}{
+		{
+			name:   "success",
+			userID: userID,
+			mockBehavior: func(repo *mocks.MockBookingRepository) {
+				repo.EXPECT().
+					GetByUserID(gomock.Any(), userID).
+					Return(bookings, nil)
+			},
+			want:    bookings,
+			wantErr: false,
+		},
+		{
+			name:   "empty bookings",
+			userID: userID,
+			mockBehavior: func(repo *mocks.MockBookingRepository) {
+				repo.EXPECT().
+					GetByUserID(gomock.Any(), userID).
+					Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:   "repository error",
+			userID: userID,
+			mockBehavior: func(repo *mocks.MockBookingRepository) {
+				repo.EXPECT().
+					GetByUserID(gomock.Any(), userID).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			tt.mockBehavior(bookingRepo)
+
+			s := NewBookingService(
+				testLogger(),
+				bookingRepo,
+				mocks.NewMockConcertRepository(ctrl),
+				mocks.NewMockTicketTierRepository(ctrl),
+				mocks.NewMockPresaleRepository(ctrl),
+				mocks.NewMockWaitlistRepository(ctrl),
+				mocks.NewMockPromoCodeRepository(ctrl),
+				mocks.NewMockOutboxRepository(ctrl),
+				mocks.NewMockLedgerRepository(ctrl),
+				mocks.NewMockConcertCacheRepository(ctrl),
+				mocks.NewMockSeatMapRepository(ctrl),
+				mocks.NewMockWaitingRoomRepository(ctrl),
+				mocks.NewMockTxManager(ctrl),
+				testHoldTTL,
+				testOfferTTL,
+				testFeePolicy,
+				testAccessCodeKey,
+			)
+
+			got, err := s.GetUserBookings(context.Background(), tt.userID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBookingService_CancelBooking(t *testing.T) {
+	userID := uuid.New()
+	bookingID := uuid.New()
+	concertID := uuid.New()
+	tierID := uuid.New()
+	waitingUserID := uuid.New()
+
+	booking := func() *models.Booking {
+		return &models.Booking{
+			ID:         bookingID,
+			UserID:     userID,
+			ConcertID:  concertID,
+			TierID:     tierID,
+			SeatNumber: 7,
+			Status:     models.BookingStatusPending,
+			CreatedAt:  time.Now(),
+		}
+	}
+	confirmed := func() *models.Booking {
+		b := booking()
+		b.Status = models.BookingStatusConfirmed
+		return b
+	}
+
+	type mockBehavior func(
+		bookingRepo *mocks.MockBookingRepository,
+		concertRepo *mocks.MockConcertRepository,
+		tierRepo *mocks.MockTicketTierRepository,
+		cacheRepo *mocks.MockConcertCacheRepository,
+		seatMap *mocks.MockSeatMapRepository,
+		txManager *mocks.MockTxManager,
+		outboxRepo *mocks.MockOutboxRepository,
+		waitlistRepo *mocks.MockWaitlistRepository,
+		ledgerRepo *mocks.MockLedgerRepository,
+		promoRepo *mocks.MockPromoCodeRepository,
+	)
+
+	tests := []struct {
+		name         string
+		userID       uuid.UUID
+		release      bool
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name:   "success",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				bookingRepo.EXPECT().
+					Cancel(gomock.Any(), bookingID, models.BookingStatusPending).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					ExpireOffers(gomock.Any(), []uuid.UUID{bookingID}).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertID, 1).
+					Return(nil, nil)
+				tierRepo.EXPECT().
+					Increment(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					IncrementSeats(gomock.Any(), concertID, 1).
+					Return(nil)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{7}, models.SeatFree).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "inventory overflow is not reported as missing booking",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				bookingRepo.EXPECT().
+					Cancel(gomock.Any(), bookingID, models.BookingStatusPending).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					ExpireOffers(gomock.Any(), []uuid.UUID{bookingID}).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertID, 1).
+					Return(nil, nil)
+				tierRepo.EXPECT().
+					Increment(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					IncrementSeats(gomock.Any(), concertID, 1).
+					Return(models.ErrSeatsOverflow)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrSeatsOverflow,
+		},
+		{
+			name:   "unpaid booking reverses sale and frees promo code",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				promoRepo *mocks.MockPromoCodeRepository,
+			) {
+				promoID := uuid.New()
+				priced := booking()
+				priced.FaceValue = models.NewMoney(10000, "RUB")
+				priced.Discount = models.NewMoney(1000, "RUB")
+				priced.PromoCodeID = &promoID
+				testFeePolicy.Apply(priced)
+
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(priced, nil)
+				bookingRepo.EXPECT().
+					Cancel(gomock.Any(), bookingID, models.BookingStatusPending).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionSaleReversal, txs[0].Type)
+						assert.NoError(t, txs[0].Validate())
+						for _, e := range txs[0].Entries {
+							if e.Account == models.LedgerAccountCustomer {
+								assert.Equal(t, models.LedgerCredit, e.Direction)
+								assert.Equal(t, priced.Price, e.Amount)
+							}
+						}
+						return nil
+					})
+				waitlistRepo.EXPECT().
+					ExpireOffers(gomock.Any(), []uuid.UUID{bookingID}).
+					Return(nil)
+				promoRepo.EXPECT().
+					Release(gomock.Any(), []uuid.UUID{bookingID}).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertID, 1).
+					Return(nil, nil)
+				tierRepo.EXPECT().
+					Increment(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					IncrementSeats(gomock.Any(), concertID, 1).
+					Return(nil)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{7}, models.SeatFree).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "paid booking released for refund",
+			userID:  userID,
+			release: true,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(confirmed(), nil)
+				bookingRepo.EXPECT().
+					Cancel(gomock.Any(), bookingID, models.BookingStatusConfirmed).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					ExpireOffers(gomock.Any(), []uuid.UUID{bookingID}).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertID, 1).
+					Return(nil, nil)
+				tierRepo.EXPECT().
+					Increment(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					IncrementSeats(gomock.Any(), concertID, 1).
+					Return(nil)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{7}, models.SeatFree).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "paid booking requires refund",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(confirmed(), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrRefundRequired,
+		},
+		{
+			name:   "released seat offered to next waitlisted user",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				entry := models.WaitlistEntry{ID: uuid.New(), ConcertID: concertID, UserID: waitingUserID}
+				offerID := uuid.New()
+				discounted := booking()
+				discounted.FaceValue = models.NewMoney(10000, "RUB")
+				discounted.Discount = models.NewMoney(2000, "RUB")
+				discounted.ServiceFee = models.NewMoney(800, "RUB")
+				discounted.VAT = models.NewMoney(160, "RUB")
+				discounted.Price = models.NewMoney(8960, "RUB")
+
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(discounted, nil)
+				bookingRepo.EXPECT().
+					Cancel(gomock.Any(), bookingID, models.BookingStatusPending).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionSaleReversal, txs[0].Type)
+						assert.NoError(t, txs[0].Validate())
+						return nil
+					})
+				waitlistRepo.EXPECT().
+					ExpireOffers(gomock.Any(), []uuid.UUID{bookingID}).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertID, 1).
+					Return([]models.WaitlistEntry{entry}, nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, offer *models.Booking) error {
+						assert.Equal(t, waitingUserID, offer.UserID)
+						assert.Equal(t, 7, offer.SeatNumber)
+						assert.Equal(t, tierID, offer.TierID)
+						assert.Equal(t, models.NewMoney(10000, "RUB"), offer.FaceValue)
+						assert.Equal(t, models.NewMoney(11200, "RUB"), offer.Price)
+						assert.Zero(t, offer.Discount.Amount)
+						assert.Nil(t, offer.PromoCodeID)
+						assert.Equal(t, models.BookingStatusPending, offer.Status)
+						require.NotNil(t, offer.ExpiresAt)
+						assert.WithinDuration(t, time.Now().Add(testOfferTTL), *offer.ExpiresAt, time.Minute)
+						offer.ID = offerID
+						return nil
+					})
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionSale, txs[0].Type)
+						assert.NoError(t, txs[0].Validate())
+						assert.Equal(t, &offerID, txs[0].BookingID)
+						return nil
+					})
+				waitlistRepo.EXPECT().
+					MarkOffered(gomock.Any(), entry.ID, offerID, gomock.Any()).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *models.OutboxMessage) error {
+						assert.Equal(t, event.TypeWaitlistOffered, msg.EventType)
+						assert.Equal(t, waitingUserID.String(), msg.EventKey)
+						return nil
+					})
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{7}, models.SeatHeld).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "booking not found",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+		{
+			name:   "booking of another user",
+			userID: uuid.New(),
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrForbidden,
+		},
+		{
+			name:   "already cancelled",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				cancelled := booking()
+				cancelled.Status = models.BookingStatusCancelled
+
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(cancelled, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrBookingCancelled,
+		},
+		{
+			name:   "booking confirmed while cancelling",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				bookingRepo.EXPECT().
+					Cancel(gomock.Any(), bookingID, models.BookingStatusPending).
+					Return(models.ErrBookingChanged)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrBookingChanged,
+		},
+		{
+			name:   "increment seats error",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				bookingRepo.EXPECT().
+					Cancel(gomock.Any(), bookingID, models.BookingStatusPending).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					ExpireOffers(gomock.Any(), []uuid.UUID{bookingID}).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertID, 1).
+					Return(nil, nil)
+				tierRepo.EXPECT().
+					Increment(gomock.Any(), tierID, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					IncrementSeats(gomock.Any(), concertID, 1).
+					Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			tierRepo := mocks.NewMockTicketTierRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			promoRepo := mocks.NewMockPromoCodeRepository(ctrl)
+			waitlistRepo := mocks.NewMockWaitlistRepository(ctrl)
+
+			tt.mockBehavior(
+				bookingRepo,
+				concertRepo,
+				tierRepo,
+				cacheRepo,
+				seatMap,
+				txManager,
+				outboxRepo,
+				waitlistRepo,
+				ledgerRepo,
+				promoRepo,
+			)
+
+			s := NewBookingService(
+				testLogger(),
+				bookingRepo,
+				concertRepo,
+				tierRepo,
+				mocks.NewMockPresaleRepository(ctrl),
+				waitlistRepo,
+				promoRepo,
+				outboxRepo,
+				ledgerRepo,
+				cacheRepo,
+				seatMap,
+				mocks.NewMockWaitingRoomRepository(ctrl),
+				txManager,
+				testHoldTTL,
+				testOfferTTL,
+				testFeePolicy,
+				testAccessCodeKey,
+			)
+
+			cancel := s.CancelBooking
+			if tt.release {
+				cancel = s.ReleaseBooking
+			}
+
+			got, err := cancel(context.Background(), tt.userID, bookingID)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, models.BookingStatusCancelled, got.Status)
+		})
+	}
+}
+
+func TestBookingService_ConfirmBooking(t *testing.T) {
+	userID := uuid.New()
+	bookingID := uuid.New()
+	concertID := uuid.New()
+
+	pending := func() *models.Booking {
+		expiresAt := time.Now().Add(testHoldTTL)
+		return &models.Booking{
+			ID:         bookingID,
+			UserID:     userID,
+			ConcertID:  concertID,
+			SeatNumber: 3,
+			Status:     models.BookingStatusPending,
+			ExpiresAt:  &expiresAt,
+		}
+	}
+
+	type mockBehavior func(
+		bookingRepo *mocks.MockBookingRepository,
+		seatMap *mocks.MockSeatMapRepository,
+		txManager *mocks.MockTxManager,
+		waitlistRepo *mocks.MockWaitlistRepository,
+	)
+
+	tests := []struct {
+		name         string
+		userID       uuid.UUID
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name:   "success",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				waitlistRepo *mocks.MockWaitlistRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(pending(), nil)
+				bookingRepo.EXPECT().
+					Confirm(gomock.Any(), bookingID).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					MarkClaimed(gomock.Any(), bookingID).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertID, []int{3}, models.SeatSold).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "booking of another user",
+			userID: uuid.New(),
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockWaitlistRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(pending(), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrForbidden,
+		},
+		{
+			name:   "booking not pending",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockWaitlistRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				booking := pending()
+				booking.Status = models.BookingStatusCancelled
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotPending,
+		},
+		{
+			name:   "hold expired",
+			userID: userID,
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockWaitlistRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(pending(), nil)
+				bookingRepo.EXPECT().
+					Confirm(gomock.Any(), bookingID).
+					Return(models.ErrHoldExpired)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrHoldExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			waitlistRepo := mocks.NewMockWaitlistRepository(ctrl)
+			tt.mockBehavior(bookingRepo, seatMap, txManager, waitlistRepo)
+
+			s := NewBookingService(
+				testLogger(),
+				bookingRepo,
+				mocks.NewMockConcertRepository(ctrl),
+				mocks.NewMockTicketTierRepository(ctrl),
+				mocks.NewMockPresaleRepository(ctrl),
+				waitlistRepo,
+				mocks.NewMockPromoCodeRepository(ctrl),
+				mocks.NewMockOutboxRepository(ctrl),
+				mocks.NewMockLedgerRepository(ctrl),
+				mocks.NewMockConcertCacheRepository(ctrl),
+				seatMap,
+				mocks.NewMockWaitingRoomRepository(ctrl),
+				txManager,
+				testHoldTTL,
+				testOfferTTL,
+				testFeePolicy,
+				testAccessCodeKey,
+			)
+
+			got, err := s.ConfirmBooking(context.Background(), tt.userID, bookingID)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, models.BookingStatusConfirmed, got.Status)
+			assert.Nil(t, got.ExpiresAt)
+		})
+	}
+}
+
+func TestBookingService_ReleaseExpiredHolds(t *testing.T) {
+	concertA := uuid.New()
+	concertB := uuid.New()
+	tierA := uuid.New()
+	tierB := uuid.New()
+
+	expired := []models.Booking{
+		{ID: uuid.New(), ConcertID: concertA, TierID: tierA, SeatNumber: 1, Status: models.BookingStatusCancelled},
+		{ID: uuid.New(), ConcertID: concertA, TierID: tierA, SeatNumber: 2, Status: models.BookingStatusCancelled},
+		{ID: uuid.New(), ConcertID: concertB, TierID: tierB, SeatNumber: 9, Status: models.BookingStatusCancelled},
+	}
+	for i := range expired {
+		expired[i].FaceValue = models.NewMoney(10000, "RUB")
+		testFeePolicy.Apply(&expired[i])
+	}
+
+	type mockBehavior func(
+		bookingRepo *mocks.MockBookingRepository,
+		concertRepo *mocks.MockConcertRepository,
+		tierRepo *mocks.MockTicketTierRepository,
+		cacheRepo *mocks.MockConcertCacheRepository,
+		seatMap *mocks.MockSeatMapRepository,
+		txManager *mocks.MockTxManager,
+		outboxRepo *mocks.MockOutboxRepository,
+		waitlistRepo *mocks.MockWaitlistRepository,
+		ledgerRepo *mocks.MockLedgerRepository,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "releases seats per concert",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					ExpireHolds(gomock.Any(), 100).
+					Return(expired, nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 3)
+						for _, tx := range txs {
+							assert.Equal(t, models.LedgerTransactionSaleReversal, tx.Type)
+							assert.NoError(t, tx.Validate())
+						}
+						return nil
+					})
+				waitlistRepo.EXPECT().
+					ExpireOffers(gomock.Any(), []uuid.UUID{expired[0].ID, expired[1].ID, expired[2].ID}).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertA, 2).
+					Return(nil, nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertB, 1).
+					Return(nil, nil)
+				tierRepo.EXPECT().
+					Increment(gomock.Any(), tierA, 2).
+					Return(nil)
+				tierRepo.EXPECT().
+					Increment(gomock.Any(), tierB, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					IncrementSeats(gomock.Any(), concertA, 2).
+					Return(nil)
+				concertRepo.EXPECT().
+					IncrementSeats(gomock.Any(), concertB, 1).
+					Return(nil)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertA, []int{1, 2}, models.SeatFree).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertB, []int{9}, models.SeatFree).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(len(expired))
+			},
+			want:    len(expired),
+			wantErr: false,
+		},
+		{
+			name: "expired offers roll to the next waitlisted user",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				outboxRepo *mocks.MockOutboxRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+			) {
+				next := models.WaitlistEntry{ID: uuid.New(), ConcertID: concertA, UserID: uuid.New()}
+
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					ExpireHolds(gomock.Any(), 100).
+					Return(expired[:2], nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 2)
+						for _, tx := range txs {
+							assert.Equal(t, models.LedgerTransactionSaleReversal, tx.Type)
+							assert.NoError(t, tx.Validate())
+						}
+						return nil
+					})
+				waitlistRepo.EXPECT().
+					ExpireOffers(gomock.Any(), []uuid.UUID{expired[0].ID, expired[1].ID}).
+					Return(nil)
+				waitlistRepo.EXPECT().
+					NextWaiting(gomock.Any(), concertA, 2).
+					Return([]models.WaitlistEntry{next}, nil)
+				bookingRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, offer *models.Booking) error {
+						assert.Equal(t, next.UserID, offer.UserID)
+						assert.Equal(t, 1, offer.SeatNumber)
+						offer.ID = uuid.New()
+						return nil
+					})
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						for _, tx := range txs {
+							assert.Equal(t, models.LedgerTransactionSale, tx.Type)
+							assert.NoError(t, tx.Validate())
+						}
+						return nil
+					})
+				waitlistRepo.EXPECT().
+					MarkOffered(gomock.Any(), next.ID, gomock.Any(), gomock.Any()).
+					Return(nil)
+				tierRepo.EXPECT().
+					Increment(gomock.Any(), tierA, 1).
+					Return(nil)
+				concertRepo.EXPECT().
+					IncrementSeats(gomock.Any(), concertA, 1).
+					Return(nil)
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(3)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertA, []int{2}, models.SeatFree).
+					Return(nil)
+				seatMap.EXPECT().
+					SetStates(gomock.Any(), concertA, []int{1}, models.SeatHeld).
+					Return(nil)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "nothing to release",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					ExpireHolds(gomock.Any(), 100).
+					Return(nil, nil)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "repository error",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockWaitlistRepository,
+				_ *mocks.MockLedgerRepository,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					ExpireHolds(gomock.Any(), 100).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
 		},
 	}
 
@@ -283,25 +2725,531 @@ func TestBookingService_GetUserBookings(t *testing.T) {
 			defer ctrl.Finish()
 
 			bookingRepo := mocks.NewMockBookingRepository(ctrl)
-			tt.mockBehavior(bookingRepo)
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			tierRepo := mocks.NewMockTicketTierRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			waitlistRepo := mocks.NewMockWaitlistRepository(ctrl)
+
+			tt.mockBehavior(
+				bookingRepo,
+				concertRepo,
+				tierRepo,
+				cacheRepo,
+				seatMap,
+				txManager,
+				outboxRepo,
+				waitlistRepo,
+				ledgerRepo,
+			)
 
 			s := NewBookingService(
 				testLogger(),
 				bookingRepo,
-				mocks.NewMockConcertRepository(ctrl),
+				concertRepo,
+				tierRepo,
+				mocks.NewMockPresaleRepository(ctrl),
+				waitlistRepo,
+				mocks.NewMockPromoCodeRepository(ctrl),
+				outboxRepo,
+				ledgerRepo,
+				cacheRepo,
+				seatMap,
+				mocks.NewMockWaitingRoomRepository(ctrl),
+				txManager,
+				testHoldTTL,
+				testOfferTTL,
+				testFeePolicy,
+				testAccessCodeKey,
+			)
+
+			got, err := s.ReleaseExpiredHolds(context.Background(), 100)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBookingService_GetSeatMap(t *testing.T) {
+	concertID := uuid.New()
+	concert := &models.Concert{ID: concertID, TotalSeats: 10}
+	draft := &models.Concert{ID: concertID, TotalSeats: 10, Status: models.ConcertStatusDraft}
+
+	cached := models.NewSeatMap(10)
+	cached.Set(2, models.SeatSold)
+
+	type mockBehavior func(
+		bookingRepo *mocks.MockBookingRepository,
+		concertRepo *mocks.MockConcertRepository,
+		seatMap *mocks.MockSeatMapRepository,
+	)
+
+	tests := []struct {
+		name         string
+		showDrafts   bool
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+		checkResult  func(t *testing.T, seats *models.SeatAvailability)
+	}{
+		{
+			name: "served from cache",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				seatMap *mocks.MockSeatMapRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				seatMap.EXPECT().
+					Get(gomock.Any(), concertID).
+					Return(cached, true, nil)
+			},
+			checkResult: func(t *testing.T, seats *models.SeatAvailability) {
+				t.Helper()
+				assert.Equal(t, []models.SeatRange{{2, 2}}, seats.Seats.Ranges(10, models.SeatSold))
+			},
+		},
+		{
+			name: "rebuilt from bookings on cache miss",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				seatMap *mocks.MockSeatMapRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				seatMap.EXPECT().
+					Get(gomock.Any(), concertID).
+					Return(nil, false, nil)
+				seatMap.EXPECT().
+					Version(gomock.Any(), concertID).
+					Return(int64(3), nil)
+				bookingRepo.EXPECT().
+					GetActiveByConcertID(gomock.Any(), concertID).
+					Return([]models.Booking{
+						{SeatNumber: 1, Status: models.BookingStatusConfirmed},
+						{SeatNumber: 4, Status: models.BookingStatusPending},
+						{SeatNumber: 5, Status: models.BookingStatusPending},
+						{SeatNumber: 10, Status: models.BookingStatusConfirmed},
+					}, nil)
+				seatMap.EXPECT().
+					Set(gomock.Any(), concertID, gomock.Any(), int64(3)).
+					Return(true, nil)
+			},
+			checkResult: func(t *testing.T, seats *models.SeatAvailability) {
+				t.Helper()
+				assert.Equal(t, 10, seats.TotalSeats)
+				assert.Equal(t, []models.SeatRange{{2, 3}, {6, 9}}, seats.Seats.Ranges(10, models.SeatFree))
+				assert.Equal(t, []models.SeatRange{{4, 5}}, seats.Seats.Ranges(10, models.SeatHeld))
+				assert.Equal(t, []models.SeatRange{{1, 1}, {10, 10}}, seats.Seats.Ranges(10, models.SeatSold))
+			},
+		},
+		{
+			name: "cache error falls back to database",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				seatMap *mocks.MockSeatMapRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				seatMap.EXPECT().
+					Get(gomock.Any(), concertID).
+					Return(nil, false, assert.AnError)
+				seatMap.EXPECT().
+					Version(gomock.Any(), concertID).
+					Return(int64(0), assert.AnError)
+				bookingRepo.EXPECT().
+					GetActiveByConcertID(gomock.Any(), concertID).
+					Return(nil, nil)
+			},
+			checkResult: func(t *testing.T, seats *models.SeatAvailability) {
+				t.Helper()
+				assert.Equal(t, []models.SeatRange{{1, 10}}, seats.Seats.Ranges(10, models.SeatFree))
+			},
+		},
+		{
+			name: "write during rebuild leaves cache empty",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				seatMap *mocks.MockSeatMapRepository,
+			) {
+				seatMap.EXPECT().
+					Get(gomock.Any(), concertID).
+					Return(nil, false, nil)
+				seatMap.EXPECT().
+					Version(gomock.Any(), concertID).
+					Return(int64(7), nil)
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				bookingRepo.EXPECT().
+					GetActiveByConcertID(gomock.Any(), concertID).
+					Return([]models.Booking{{SeatNumber: 3, Status: models.BookingStatusPending}}, nil)
+				seatMap.EXPECT().
+					Set(gomock.Any(), concertID, gomock.Any(), int64(7)).
+					Return(false, nil)
+			},
+			checkResult: func(t *testing.T, seats *models.SeatAvailability) {
+				t.Helper()
+				assert.Equal(t, []models.SeatRange{{3, 3}}, seats.Seats.Ranges(10, models.SeatHeld))
+			},
+		},
+		{
+			name: "concert not found",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				seatMap *mocks.MockSeatMapRepository,
+			) {
+				seatMap.EXPECT().
+					Get(gomock.Any(), concertID).
+					Return(nil, false, nil)
+				seatMap.EXPECT().
+					Version(gomock.Any(), concertID).
+					Return(int64(0), nil)
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+		{
+			name: "draft concert hidden",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				seatMap *mocks.MockSeatMapRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(draft, nil)
+				seatMap.EXPECT().
+					Get(gomock.Any(), concertID).
+					Return(cached, true, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+		{
+			name:       "draft concert shown to admin",
+			showDrafts: true,
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				seatMap *mocks.MockSeatMapRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(draft, nil)
+				seatMap.EXPECT().
+					Get(gomock.Any(), concertID).
+					Return(cached, true, nil)
+			},
+			checkResult: func(t *testing.T, seats *models.SeatAvailability) {
+				t.Helper()
+				assert.Equal(t, 10, seats.TotalSeats)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			tt.mockBehavior(bookingRepo, concertRepo, seatMap)
+
+			s := NewBookingService(
+				testLogger(),
+				bookingRepo,
+				concertRepo,
+				mocks.NewMockTicketTierRepository(ctrl),
+				mocks.NewMockPresaleRepository(ctrl),
+				mocks.NewMockWaitlistRepository(ctrl),
+				mocks.NewMockPromoCodeRepository(ctrl),
+				mocks.NewMockOutboxRepository(ctrl),
+				mocks.NewMockLedgerRepository(ctrl),
 				mocks.NewMockConcertCacheRepository(ctrl),
+				seatMap,
+				mocks.NewMockWaitingRoomRepository(ctrl),
 				mocks.NewMockTxManager(ctrl),
-				mocks.NewMockEventProducer(ctrl),
+				testHoldTTL,
+				testOfferTTL,
+				testFeePolicy,
+				testAccessCodeKey,
 			)
 
-			got, err := s.GetUserBookings(context.Background(), tt.userID)
+			got, err := s.GetSeatMap(context.Background(), concertID, tt.showDrafts)
 			if tt.wantErr {
 				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
 				return
 			}
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
+			if tt.checkResult != nil {
+				tt.checkResult(t, got)
+			}
+		})
+	}
+}
+
+func TestBookingService_CheckSaleWindow(t *testing.T) {
+	userID := uuid.New()
+	concertID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	onSale := &models.Concert{ID: concertID, Date: time.Now().Add(24 * time.Hour)}
+	ended := *onSale
+	ended.SaleEndsAt = &past
+	scheduled := *onSale
+	scheduled.SaleStartsAt = &future
+
+	presale := models.Presale{
+		StartsAt:       past,
+		EndsAt:         future,
+		AccessCodeHash: hashAccessCode([]byte(testAccessCodeKey), "FANCLUB"),
+		UserIDs:        []uuid.UUID{userID},
+	}
+	upcoming := presale
+	upcoming.StartsAt = future
+	upcoming.EndsAt = future.Add(time.Hour)
+
+	type mockBehavior func(presaleRepo *mocks.MockPresaleRepository)
+
+	tests := []struct {
+		name         string
+		concert      *models.Concert
+		userID       uuid.UUID
+		accessCode   string
+		mockBehavior mockBehavior
+		wantErrType  error
+	}{
+		{
+			name:         "general sale open",
+			concert:      onSale,
+			userID:       userID,
+			mockBehavior: func(_ *mocks.MockPresaleRepository) {},
+		},
+		{
+			name:         "sale ended",
+			concert:      &ended,
+			userID:       userID,
+			mockBehavior: func(_ *mocks.MockPresaleRepository) {},
+			wantErrType:  models.ErrSaleEnded,
+		},
+		{
+			name:    "no presale running",
+			concert: &scheduled,
+			userID:  userID,
+			mockBehavior: func(presaleRepo *mocks.MockPresaleRepository) {
+				presaleRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.Presale{upcoming}, nil)
+			},
+			wantErrType: models.ErrSaleNotStarted,
+		},
+		{
+			name:    "allowlisted user",
+			concert: &scheduled,
+			userID:  userID,
+			mockBehavior: func(presaleRepo *mocks.MockPresaleRepository) {
+				presaleRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.Presale{presale}, nil)
+			},
+		},
+		{
+			name:       "valid access code",
+			concert:    &scheduled,
+			userID:     uuid.New(),
+			accessCode: " fanclub ",
+			mockBehavior: func(presaleRepo *mocks.MockPresaleRepository) {
+				presaleRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.Presale{presale}, nil)
+			},
+		},
+		{
+			name:       "wrong access code",
+			concert:    &scheduled,
+			userID:     uuid.New(),
+			accessCode: "guess",
+			mockBehavior: func(presaleRepo *mocks.MockPresaleRepository) {
+				presaleRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.Presale{presale}, nil)
+			},
+			wantErrType: models.ErrInvalidAccessCode,
+		},
+		{
+			name:    "presale without access",
+			concert: &scheduled,
+			userID:  uuid.New(),
+			mockBehavior: func(presaleRepo *mocks.MockPresaleRepository) {
+				presaleRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.Presale{presale}, nil)
+			},
+			wantErrType: models.ErrPresaleAccessRequired,
+		},
+		{
+			name:    "repository error",
+			concert: &scheduled,
+			userID:  userID,
+			mockBehavior: func(presaleRepo *mocks.MockPresaleRepository) {
+				presaleRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(nil, assert.AnError)
+			},
+			wantErrType: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			presaleRepo := mocks.NewMockPresaleRepository(ctrl)
+			tt.mockBehavior(presaleRepo)
+
+			s := NewBookingService(
+				testLogger(),
+				mocks.NewMockBookingRepository(ctrl),
+				mocks.NewMockConcertRepository(ctrl),
+				mocks.NewMockTicketTierRepository(ctrl),
+				presaleRepo,
+				mocks.NewMockWaitlistRepository(ctrl),
+				mocks.NewMockPromoCodeRepository(ctrl),
+				mocks.NewMockOutboxRepository(ctrl),
+				mocks.NewMockLedgerRepository(ctrl),
+				mocks.NewMockConcertCacheRepository(ctrl),
+				mocks.NewMockSeatMapRepository(ctrl),
+				mocks.NewMockWaitingRoomRepository(ctrl),
+				mocks.NewMockTxManager(ctrl),
+				testHoldTTL,
+				testOfferTTL,
+				testFeePolicy,
+				testAccessCodeKey,
+			)
+
+			err := s.checkSaleWindow(context.Background(), tt.concert, tt.userID, tt.accessCode)
+			if tt.wantErrType != nil {
+				assert.ErrorIs(t, err, tt.wantErrType)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func seatAssignments(tierID uuid.UUID, seats ...int) []models.SeatAssignment {
+	assignments := make([]models.SeatAssignment, 0, len(seats))
+	for _, seat := range seats {
+		assignments = append(assignments, models.SeatAssignment{
+			SeatID:  uuid.New(),
+			Ordinal: seat,
+			TierID:  tierID,
+			Price:   models.NewMoney(10000, "RUB"),
+		})
+	}
+	return assignments
+}
+
+func seatCandidates(tierID, rowID uuid.UUID, seats ...int) []models.SeatCandidate {
+	candidates := make([]models.SeatCandidate, 0, len(seats))
+	for _, a := range seatAssignments(tierID, seats...) {
+		candidates = append(candidates, models.SeatCandidate{SeatAssignment: a, RowID: rowID, Number: a.Ordinal})
+	}
+	return candidates
+}
+
+func lockCandidates(
+	free []models.SeatCandidate,
+) func(context.Context, uuid.UUID, []uuid.UUID) ([]models.SeatAssignment, error) {
+	return func(_ context.Context, _ uuid.UUID, seatIDs []uuid.UUID) ([]models.SeatAssignment, error) {
+		var locked []models.SeatAssignment
+		for _, c := range free {
+			if slices.Contains(seatIDs, c.SeatID) {
+				locked = append(locked, c.SeatAssignment)
+			}
+		}
+		return locked, nil
+	}
+}
+
+func TestFeePolicy_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   models.FeePolicy
+		discount int64
+		wantFee  int64
+		wantVAT  int64
+		want     int64
+	}{
+		{
+			name:    "fee and vat on face value",
+			policy:  testFeePolicy,
+			wantFee: 1000,
+			wantVAT: 200,
+			want:    11200,
+		},
+		{
+			name:     "fee on discounted value",
+			policy:   testFeePolicy,
+			discount: 2500,
+			wantFee:  750,
+			wantVAT:  150,
+			want:     8400,
+		},
+		{
+			name:    "vat on fee and net value",
+			policy:  models.FeePolicy{ServiceFeePercent: 10, VATPercent: 20, VATBase: models.VATBaseTotal},
+			wantFee: 1000,
+			wantVAT: 2200,
+			want:    13200,
+		},
+		{
+			name:   "fees disabled",
+			policy: models.FeePolicy{},
+			want:   10000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := models.Booking{
+				FaceValue: models.NewMoney(10000, "RUB"),
+				Discount:  models.NewMoney(tt.discount, "RUB"),
+			}
+
+			tt.policy.Apply(&booking)
+
+			assert.Equal(t, models.NewMoney(tt.wantFee, "RUB"), booking.ServiceFee)
+			assert.Equal(t, models.NewMoney(tt.wantVAT, "RUB"), booking.VAT)
+			assert.Equal(t, models.NewMoney(tt.want, "RUB"), booking.Price)
 		})
 	}
 }
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/cache"
+	"github.com/yohnnn/booking_service/internal/event"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+type CancellationService struct {
+	logger           *slog.Logger
+	concertRepo      repository.ConcertRepository
+	bookingRepo      repository.BookingRepository
+	cancellationRepo repository.CancellationRepository
+	refundRepo       repository.RefundRepository
+	ledgerRepo       repository.LedgerRepository
+	promoRepo        repository.PromoCodeRepository
+	waitlistRepo     repository.WaitlistRepository
+	outboxRepo       repository.OutboxRepository
+	cacheRepo        cache.ConcertCacheRepository
+	seatMap          cache.SeatMapRepository
+	waitingRoom      cache.WaitingRoomRepository
+	manager          TxManager
+}
+
+func NewCancellationService(
+	logger *slog.Logger,
+	concertRepo repository.ConcertRepository,
+	bookingRepo repository.BookingRepository,
+	cancellationRepo repository.CancellationRepository,
+	refundRepo repository.RefundRepository,
+	ledgerRepo repository.LedgerRepository,
+	promoRepo repository.PromoCodeRepository,
+	waitlistRepo repository.WaitlistRepository,
+	outboxRepo repository.OutboxRepository,
+	cacheRepo cache.ConcertCacheRepository,
+	seatMap cache.SeatMapRepository,
+	waitingRoom cache.WaitingRoomRepository,
+	manager TxManager,
+) *CancellationService {
+	return &CancellationService{
+		logger:           logger,
+		concertRepo:      concertRepo,
+		bookingRepo:      bookingRepo,
+		cancellationRepo: cancellationRepo,
+		refundRepo:       refundRepo,
+		ledgerRepo:       ledgerRepo,
+		promoRepo:        promoRepo,
+		waitlistRepo:     waitlistRepo,
+		outboxRepo:       outboxRepo,
+		cacheRepo:        cacheRepo,
+		seatMap:          seatMap,
+		waitingRoom:      waitingRoom,
+		manager:          manager,
+	}
+}
+
+func (s *CancellationService) Cancel(
+	ctx context.Context,
+	concertID uuid.UUID,
+	reason string,
+) (*models.ConcertCancellation, error) {
+	var cancellation *models.ConcertCancellation
+
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		concert, err := s.concertRepo.GetByID(ctx, concertID)
+		if err != nil {
+			return err
+		}
+
+		if concert.Status == models.ConcertStatusCancelled {
+			cancellation, err = s.cancellationRepo.GetByConcertID(ctx, concertID)
+			return err
+		}
+
+		if !concert.Status.CanTransitionTo(models.ConcertStatusCancelled) {
+			return fmt.Errorf("%w: %s -> %s",
+				models.ErrInvalidStatusTransition, concert.Status, models.ConcertStatusCancelled)
+		}
+
+		err = s.concertRepo.UpdateStatus(ctx, concertID, concert.Status, models.ConcertStatusCancelled)
+		if err != nil {
+			return err
+		}
+
+		cancellation = &models.ConcertCancellation{
+			ConcertID: concertID,
+			Reason:    reason,
+			Status:    models.CancellationStatusRunning,
+		}
+		return s.cancellationRepo.Create(ctx, cancellation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "concert cancellation started", "concert_id", concertID)
+
+	_ = s.cacheRepo.Delete(ctx)
+
+	return cancellation, nil
+}
+
+func (s *CancellationService) Get(ctx context.Context, concertID uuid.UUID) (*models.ConcertCancellation, error) {
+	return s.cancellationRepo.GetByConcertID(ctx, concertID)
+}
+
+func (s *CancellationService) ProcessPending(ctx context.Context, limit int) (int, error) {
+	var (
+		concertID uuid.UUID
+		users     int
+		completed bool
+	)
+
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		cancellation, err := s.cancellationRepo.NextRunning(ctx)
+		if err != nil {
+			if errors.Is(err, models.ErrNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to get running cancellation: %w", err)
+		}
+		concertID = cancellation.ConcertID
+
+		if err := s.waitlistRepo.ExpireWaiting(ctx, concertID); err != nil {
+			return err
+		}
+
+		bookings, err := s.bookingRepo.CancelActiveByConcert(ctx, concertID, limit)
+		if err != nil {
+			return err
+		}
+
+		if len(bookings) == 0 {
+			completed = true
+			return s.cancellationRepo.Complete(ctx, concertID)
+		}
+
+		refunds := make([]models.Refund, 0, len(bookings))
+		var (
+			unpaid     []models.Booking
+			discounted []uuid.UUID
+		)
+		for _, b := range bookings {
+			if b.PromoCodeID != nil {
+				discounted = append(discounted, b.ID)
+			}
+			if b.Status != models.BookingStatusConfirmed {
+				unpaid = append(unpaid, b)
+				continue
+			}
+			refunds = append(refunds, models.Refund{
+				BookingID: b.ID,
+				UserID:    b.UserID,
+				ConcertID: b.ConcertID,
+				Amount:    b.Price,
+				Reason:    models.RefundReasonConcertCancelled,
+			})
+		}
+
+		if len(discounted) > 0 {
+			if err := s.promoRepo.Release(ctx, discounted); err != nil {
+				return err
+			}
+		}
+
+		refunded, err := s.refundRepo.CreateBatch(ctx, refunds)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[uuid.UUID]*models.Booking, len(bookings))
+		for i := range bookings {
+			byID[bookings[i].ID] = &bookings[i]
+		}
+		entries := saleReversalTransactions(unpaid)
+		for i := range refunded {
+			entries = append(entries, refundTransaction(&refunded[i], byID[refunded[i].BookingID]))
+		}
+		if err := postLedger(ctx, s.ledgerRepo, entries...); err != nil {
+			return err
+		}
+
+		users, err = s.notifyUsers(ctx, cancellation, bookings)
+		if err != nil {
+			return err
+		}
+
+		return s.cancellationRepo.RecordProgress(ctx, concertID, len(bookings), len(refunded), users)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if completed {
+		if err := s.seatMap.Delete(ctx, concertID); err != nil {
+			s.logger.WarnContext(ctx, "failed to drop seat map", "concert_id", concertID, "error", err)
+		}
+		if _, err := s.waitingRoom.Close(ctx, concertID); err != nil {
+			s.logger.WarnContext(ctx, "failed to close waiting room", "concert_id", concertID, "error", err)
+		}
+		s.logger.InfoContext(ctx, "concert cancellation completed", "concert_id", concertID)
+	}
+
+	return users, nil
+}
+
+func (s *CancellationService) notifyUsers(
+	ctx context.Context,
+	cancellation *models.ConcertCancellation,
+	bookings []models.Booking,
+) (int, error) {
+	events := make(map[uuid.UUID]*event.ConcertCancelledEvent)
+	var order []uuid.UUID
+
+	for _, b := range bookings {
+		evt, ok := events[b.UserID]
+		if !ok {
+			evt = &event.ConcertCancelledEvent{
+				ConcertID: cancellation.ConcertID.String(),
+				UserID:    b.UserID.String(),
+				Reason:    cancellation.Reason,
+				Currency:  b.Price.Currency,
+			}
+			events[b.UserID] = evt
+			order = append(order, b.UserID)
+		}
+
+		evt.BookingIDs = append(evt.BookingIDs, b.ID.String())
+		evt.Seats = append(evt.Seats, b.SeatNumber)
+		if b.Status == models.BookingStatusConfirmed {
+			evt.RefundAmount += b.Price.Amount
+		}
+	}
+
+	for _, userID := range order {
+		err := enqueueEvent(ctx, s.outboxRepo, event.TypeConcertCancelled, userID.String(), events[userID])
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(order), nil
+}
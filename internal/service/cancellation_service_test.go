@@ -0,0 +1,472 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/event"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestCancellationService_Cancel(t *testing.T) {
+	concertID := uuid.New()
+	upcoming := time.Now().Add(24 * time.Hour)
+
+	type mockBehavior func(
+		concertRepo *mocks.MockConcertRepository,
+		cancellationRepo *mocks.MockCancellationRepository,
+		cacheRepo *mocks.MockConcertCacheRepository,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				cancellationRepo *mocks.MockCancellationRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID, Date: upcoming, Status: models.ConcertStatusSoldOut}, nil)
+				concertRepo.EXPECT().
+					UpdateStatus(gomock.Any(), concertID, models.ConcertStatusSoldOut, models.ConcertStatusCancelled).
+					Return(nil)
+				cancellationRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *models.ConcertCancellation) error {
+						assert.Equal(t, models.CancellationStatusRunning, c.Status)
+						assert.Equal(t, "artist illness", c.Reason)
+						return nil
+					})
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "already cancelled returns existing job",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				cancellationRepo *mocks.MockCancellationRepository,
+				cacheRepo *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID, Date: upcoming, Status: models.ConcertStatusCancelled}, nil)
+				cancellationRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(&models.ConcertCancellation{
+						ConcertID: concertID,
+						Reason:    "artist illness",
+						Status:    models.CancellationStatusRunning,
+					}, nil)
+				cacheRepo.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "completed concert",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockCancellationRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID, Status: models.ConcertStatusCompleted}, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidStatusTransition,
+		},
+		{
+			name: "concert not found",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockCancellationRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			cancellationRepo := mocks.NewMockCancellationRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(concertRepo, cancellationRepo, cacheRepo, txManager)
+
+			s := NewCancellationService(
+				testLogger(),
+				concertRepo,
+				mocks.NewMockBookingRepository(ctrl),
+				cancellationRepo,
+				mocks.NewMockRefundRepository(ctrl),
+				mocks.NewMockLedgerRepository(ctrl),
+				mocks.NewMockPromoCodeRepository(ctrl),
+				mocks.NewMockWaitlistRepository(ctrl),
+				mocks.NewMockOutboxRepository(ctrl),
+				cacheRepo,
+				mocks.NewMockSeatMapRepository(ctrl),
+				mocks.NewMockWaitingRoomRepository(ctrl),
+				txManager,
+			)
+
+			got, err := s.Cancel(context.Background(), concertID, "artist illness")
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, concertID, got.ConcertID)
+			assert.Equal(t, models.CancellationStatusRunning, got.Status)
+		})
+	}
+}
+
+func TestCancellationService_ProcessPending(t *testing.T) {
+	concertID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+
+	cancellation := &models.ConcertCancellation{
+		ConcertID: concertID,
+		Reason:    "artist illness",
+		Status:    models.CancellationStatusRunning,
+	}
+	booking := func(userID uuid.UUID, seat int, status models.BookingStatus) models.Booking {
+		return models.Booking{
+			ID:         uuid.New(),
+			UserID:     userID,
+			ConcertID:  concertID,
+			SeatNumber: seat,
+			FaceValue:  models.NewMoney(5000, "RUB"),
+			Price:      models.NewMoney(5000, "RUB"),
+			Status:     status,
+		}
+	}
+	batch := []models.Booking{
+		booking(alice, 1, models.BookingStatusConfirmed),
+		booking(alice, 2, models.BookingStatusConfirmed),
+		booking(bob, 3, models.BookingStatusPending),
+	}
+	promoID := uuid.New()
+	batch[2].PromoCodeID = &promoID
+
+	type mockBehavior func(
+		bookingRepo *mocks.MockBookingRepository,
+		cancellationRepo *mocks.MockCancellationRepository,
+		refundRepo *mocks.MockRefundRepository,
+		ledgerRepo *mocks.MockLedgerRepository,
+		promoRepo *mocks.MockPromoCodeRepository,
+		waitlistRepo *mocks.MockWaitlistRepository,
+		outboxRepo *mocks.MockOutboxRepository,
+		seatMap *mocks.MockSeatMapRepository,
+		waitingRoom *mocks.MockWaitingRoomRepository,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "cancels batch, refunds paid bookings and notifies each user once",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				cancellationRepo *mocks.MockCancellationRepository,
+				refundRepo *mocks.MockRefundRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				promoRepo *mocks.MockPromoCodeRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				outboxRepo *mocks.MockOutboxRepository,
+				_ *mocks.MockSeatMapRepository,
+				_ *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				cancellationRepo.EXPECT().
+					NextRunning(gomock.Any()).
+					Return(cancellation, nil)
+				waitlistRepo.EXPECT().
+					ExpireWaiting(gomock.Any(), concertID).
+					Return(nil)
+				bookingRepo.EXPECT().
+					CancelActiveByConcert(gomock.Any(), concertID, 10).
+					Return(batch, nil)
+				promoRepo.EXPECT().
+					Release(gomock.Any(), []uuid.UUID{batch[2].ID}).
+					Return(nil)
+				refundRepo.EXPECT().
+					CreateBatch(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, refunds []models.Refund) ([]models.Refund, error) {
+						require.Len(t, refunds, 2)
+						for _, r := range refunds {
+							assert.Equal(t, alice, r.UserID)
+							assert.Equal(t, models.NewMoney(5000, "RUB"), r.Amount)
+							assert.Equal(t, models.RefundReasonConcertCancelled, r.Reason)
+						}
+						return refunds, nil
+					})
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 3)
+						assert.Equal(t, models.LedgerTransactionSaleReversal, txs[0].Type)
+						assert.Equal(t, &batch[2].ID, txs[0].BookingID)
+						for _, tx := range txs[1:] {
+							assert.Equal(t, models.LedgerTransactionRefund, tx.Type)
+						}
+						for _, tx := range txs {
+							assert.NoError(t, tx.Validate())
+						}
+						return nil
+					})
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *models.OutboxMessage) error {
+						assert.Equal(t, event.TypeConcertCancelled, msg.EventType)
+						assert.Equal(t, alice.String(), msg.EventKey)
+
+						var evt event.ConcertCancelledEvent
+						require.NoError(t, json.Unmarshal(msg.Payload, &evt))
+						assert.Equal(t, []int{1, 2}, evt.Seats)
+						assert.Equal(t, int64(10000), evt.RefundAmount)
+						assert.Equal(t, "artist illness", evt.Reason)
+						return nil
+					})
+				outboxRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *models.OutboxMessage) error {
+						assert.Equal(t, bob.String(), msg.EventKey)
+
+						var evt event.ConcertCancelledEvent
+						require.NoError(t, json.Unmarshal(msg.Payload, &evt))
+						assert.Equal(t, []int{3}, evt.Seats)
+						assert.Zero(t, evt.RefundAmount)
+						return nil
+					})
+				cancellationRepo.EXPECT().
+					RecordProgress(gomock.Any(), concertID, 3, 2, 2).
+					Return(nil)
+			},
+			want: 2,
+		},
+		{
+			name: "completes job when no bookings are left",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				cancellationRepo *mocks.MockCancellationRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				_ *mocks.MockOutboxRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				waitingRoom *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				cancellationRepo.EXPECT().
+					NextRunning(gomock.Any()).
+					Return(cancellation, nil)
+				waitlistRepo.EXPECT().
+					ExpireWaiting(gomock.Any(), concertID).
+					Return(nil)
+				bookingRepo.EXPECT().
+					CancelActiveByConcert(gomock.Any(), concertID, 10).
+					Return(nil, nil)
+				cancellationRepo.EXPECT().
+					Complete(gomock.Any(), concertID).
+					Return(nil)
+				seatMap.EXPECT().
+					Delete(gomock.Any(), concertID).
+					Return(nil)
+				waitingRoom.EXPECT().
+					Close(gomock.Any(), concertID).
+					Return(true, nil)
+			},
+			want: 0,
+		},
+		{
+			name: "no running cancellations",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				cancellationRepo *mocks.MockCancellationRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPromoCodeRepository,
+				_ *mocks.MockWaitlistRepository,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockSeatMapRepository,
+				_ *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				cancellationRepo.EXPECT().
+					NextRunning(gomock.Any()).
+					Return(nil, models.ErrNotFound)
+			},
+			want: 0,
+		},
+		{
+			name: "refund error aborts batch",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				cancellationRepo *mocks.MockCancellationRepository,
+				refundRepo *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				promoRepo *mocks.MockPromoCodeRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+				_ *mocks.MockOutboxRepository,
+				_ *mocks.MockSeatMapRepository,
+				_ *mocks.MockWaitingRoomRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				cancellationRepo.EXPECT().
+					NextRunning(gomock.Any()).
+					Return(cancellation, nil)
+				waitlistRepo.EXPECT().
+					ExpireWaiting(gomock.Any(), concertID).
+					Return(nil)
+				bookingRepo.EXPECT().
+					CancelActiveByConcert(gomock.Any(), concertID, 10).
+					Return(batch, nil)
+				promoRepo.EXPECT().
+					Release(gomock.Any(), []uuid.UUID{batch[2].ID}).
+					Return(nil)
+				refundRepo.EXPECT().
+					CreateBatch(gomock.Any(), gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			cancellationRepo := mocks.NewMockCancellationRepository(ctrl)
+			refundRepo := mocks.NewMockRefundRepository(ctrl)
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			promoRepo := mocks.NewMockPromoCodeRepository(ctrl)
+			waitlistRepo := mocks.NewMockWaitlistRepository(ctrl)
+			outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			waitingRoom := mocks.NewMockWaitingRoomRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(
+				bookingRepo,
+				cancellationRepo,
+				refundRepo,
+				ledgerRepo,
+				promoRepo,
+				waitlistRepo,
+				outboxRepo,
+				seatMap,
+				waitingRoom,
+				txManager,
+			)
+
+			s := NewCancellationService(
+				testLogger(),
+				mocks.NewMockConcertRepository(ctrl),
+				bookingRepo,
+				cancellationRepo,
+				refundRepo,
+				ledgerRepo,
+				promoRepo,
+				waitlistRepo,
+				outboxRepo,
+				mocks.NewMockConcertCacheRepository(ctrl),
+				seatMap,
+				waitingRoom,
+				txManager,
+			)
+
+			got, err := s.ProcessPending(context.Background(), 10)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,7 +2,19 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -14,36 +26,652 @@ import (
 type ConcertService struct {
 	logger      *slog.Logger
 	concertRepo repository.ConcertRepository
+	venueRepo   repository.VenueRepository
+	tierRepo    repository.TicketTierRepository
+	presaleRepo repository.PresaleRepository
 	cacheRepo   cache.ConcertCacheRepository
+	seatMap     cache.SeatMapRepository
+	manager     TxManager
+	codeKey     []byte
 }
 
 func NewConcertService(
 	logger *slog.Logger,
 	concertRepo repository.ConcertRepository,
+	venueRepo repository.VenueRepository,
+	tierRepo repository.TicketTierRepository,
+	presaleRepo repository.PresaleRepository,
 	cacheRepo cache.ConcertCacheRepository,
+	seatMap cache.SeatMapRepository,
+	manager TxManager,
+	codeKey string,
 ) *ConcertService {
 	return &ConcertService{
 		logger:      logger,
 		concertRepo: concertRepo,
+		venueRepo:   venueRepo,
+		tierRepo:    tierRepo,
+		presaleRepo: presaleRepo,
 		cacheRepo:   cacheRepo,
+		seatMap:     seatMap,
+		manager:     manager,
+		codeKey:     []byte(codeKey),
 	}
 }
 
-func (s *ConcertService) GetAll(ctx context.Context) ([]models.Concert, error) {
-	if concerts, err := s.cacheRepo.Get(ctx); err == nil && concerts != nil {
-		return concerts, nil
+const (
+	defaultConcertPageSize = 20
+	maxConcertPageSize     = 100
+)
+
+func (s *ConcertService) List(ctx context.Context, filter models.ConcertFilter) (*models.ConcertPage, error) {
+	filter, after, err := normalizeConcertFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query := concertCacheKey(filter)
+
+	if page, found, err := s.cacheRepo.Get(ctx, query); err == nil && found {
+		return &page, nil
 	}
 
-	concerts, err := s.concertRepo.GetAll(ctx)
+	concerts, err := s.concertRepo.List(ctx, filter, after, filter.Limit+1)
 	if err != nil {
 		return nil, err
 	}
 
-	_ = s.cacheRepo.Set(ctx, concerts)
+	page := &models.ConcertPage{Items: concerts}
+	if page.Items == nil {
+		page.Items = []models.Concert{}
+	}
+	for i := range page.Items {
+		page.Items[i].Localize()
+	}
+
+	if len(page.Items) > filter.Limit {
+		page.Items = page.Items[:filter.Limit]
+		page.NextCursor = encodeConcertCursor(filter.Sort, page.Items[len(page.Items)-1])
+	}
 
-	return concerts, nil
+	_ = s.cacheRepo.Set(ctx, query, page)
+
+	return page, nil
 }
 
 func (s *ConcertService) GetByID(ctx context.Context, id uuid.UUID) (*models.Concert, error) {
-	return s.concertRepo.GetByID(ctx, id)
+	concert, err := s.concertRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	var presales []models.Presale
+	if concert.SaleStartsAt != nil && now.Before(*concert.SaleStartsAt) {
+		presales, err = s.presaleRepo.GetByConcertID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	concert.SaleStatus = concert.SaleStatusAt(now, presales)
+	concert.Localize()
+
+	return concert, nil
+}
+
+func (s *ConcertService) Create(ctx context.Context, concert models.Concert) (*models.Concert, error) {
+	if err := validateConcert(&concert, nil); err != nil {
+		return nil, err
+	}
+
+	concert.Status = models.ConcertStatusDraft
+
+	if err := s.attachVenue(ctx, &concert); err != nil {
+		return nil, err
+	}
+
+	if err := s.concertRepo.Create(ctx, &concert); err != nil {
+		return nil, err
+	}
+
+	_ = s.cacheRepo.Delete(ctx)
+
+	return &concert, nil
+}
+
+func (s *ConcertService) Update(ctx context.Context, id uuid.UUID, concert models.Concert) (*models.Concert, error) {
+	return s.save(ctx, id, func(*models.Concert) models.Concert {
+		return concert
+	})
+}
+
+func (s *ConcertService) Patch(ctx context.Context, id uuid.UUID, patch models.ConcertPatch) (*models.Concert, error) {
+	return s.save(ctx, id, func(existing *models.Concert) models.Concert {
+		return applyConcertPatch(*existing, patch)
+	})
+}
+
+func (s *ConcertService) checkTierCoverage(ctx context.Context, concert *models.Concert) error {
+	tiers, err := s.tierRepo.GetByConcertID(ctx, concert.ID)
+	if err != nil {
+		return err
+	}
+
+	allocated := 0
+	for _, t := range tiers {
+		allocated += t.Quota
+	}
+	if allocated < concert.TotalSeats {
+		return fmt.Errorf("%w: ticket tiers cover %d of %d seats",
+			models.ErrInvalidStatusTransition, allocated, concert.TotalSeats)
+	}
+	return nil
+}
+
+func applyConcertPatch(concert models.Concert, patch models.ConcertPatch) models.Concert {
+	if patch.Name != nil {
+		concert.Name = *patch.Name
+	}
+	if patch.VenueID != nil {
+		concert.VenueID = *patch.VenueID
+	}
+	if patch.Date != nil {
+		concert.Date = *patch.Date
+	}
+	if patch.Price != nil {
+		concert.Price.Amount = *patch.Price
+	}
+	if patch.Currency != nil {
+		concert.Price.Currency = *patch.Currency
+	}
+	if patch.TotalSeats != nil {
+		concert.TotalSeats = *patch.TotalSeats
+	}
+	if patch.SaleStartsAt != nil || patch.ClearSaleStartsAt {
+		concert.SaleStartsAt = patch.SaleStartsAt
+	}
+	if patch.SaleEndsAt != nil || patch.ClearSaleEndsAt {
+		concert.SaleEndsAt = patch.SaleEndsAt
+	}
+
+	return concert
+}
+
+func (s *ConcertService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.concertRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	_ = s.cacheRepo.Delete(ctx)
+	_ = s.seatMap.Delete(ctx, id)
+
+	return nil
+}
+
+func (s *ConcertService) ChangeStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	status models.ConcertStatus,
+) (*models.Concert, error) {
+	if !status.Valid() {
+		return nil, models.ErrInvalidConcertStatus
+	}
+
+	var (
+		concert *models.Concert
+		from    models.ConcertStatus
+	)
+
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		concert, err = s.concertRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		from = concert.Status
+
+		if status == models.ConcertStatusSoldOut ||
+			concert.Status == models.ConcertStatusSoldOut && status == models.ConcertStatusOnSale {
+			return fmt.Errorf("%w: sold out status is managed automatically", models.ErrInvalidStatusTransition)
+		}
+		if status == models.ConcertStatusCancelled {
+			return fmt.Errorf("%w: use the cancel action to cancel a concert", models.ErrInvalidStatusTransition)
+		}
+		if !concert.Status.CanTransitionTo(status) {
+			return fmt.Errorf("%w: %s -> %s", models.ErrInvalidStatusTransition, concert.Status, status)
+		}
+		if status == models.ConcertStatusCompleted && concert.Date.After(time.Now()) {
+			return fmt.Errorf("%w: concert has not taken place yet", models.ErrInvalidStatusTransition)
+		}
+		if status == models.ConcertStatusOnSale {
+			if err := s.checkTierCoverage(ctx, concert); err != nil {
+				return err
+			}
+			if concert.AvailableSeats == 0 {
+				status = models.ConcertStatusSoldOut
+			}
+		}
+
+		if err := s.concertRepo.UpdateStatus(ctx, id, concert.Status, status); err != nil {
+			return err
+		}
+		concert.Status = status
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "concert status changed", "concert_id", id, "from", from, "to", status)
+
+	_ = s.cacheRepo.Delete(ctx)
+
+	return concert, nil
+}
+
+func (s *ConcertService) CompletePast(ctx context.Context, limit int) (int, error) {
+	completed, err := s.concertRepo.CompletePast(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	if completed > 0 {
+		_ = s.cacheRepo.Delete(ctx)
+	}
+
+	return completed, nil
+}
+
+func (s *ConcertService) GetTiers(
+	ctx context.Context,
+	concertID uuid.UUID,
+	showDrafts bool,
+) ([]models.TicketTier, error) {
+	concert, err := s.concertRepo.GetByID(ctx, concertID)
+	if err != nil {
+		return nil, err
+	}
+	if concert.Status == models.ConcertStatusDraft && !showDrafts {
+		return nil, models.ErrNotFound
+	}
+
+	tiers, err := s.tierRepo.GetByConcertID(ctx, concertID)
+	if err != nil {
+		return nil, err
+	}
+	if tiers == nil {
+		tiers = []models.TicketTier{}
+	}
+
+	return tiers, nil
+}
+
+func (s *ConcertService) AddTier(
+	ctx context.Context,
+	concertID uuid.UUID,
+	tier models.TicketTier,
+) (*models.TicketTier, error) {
+	tier.Name = strings.TrimSpace(tier.Name)
+	if tier.Name == "" || !tier.Price.IsPositive() || tier.Quota <= 0 || len(tier.SectionIDs) == 0 {
+		return nil, models.ErrInvalidTier
+	}
+	tier.ConcertID = concertID
+
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		concert, err := s.concertRepo.GetByIDForUpdate(ctx, concertID)
+		if err != nil {
+			return err
+		}
+
+		tier.Price.Currency = concert.Price.Currency
+
+		seats, err := s.tierRepo.CountSectionSeats(ctx, concert.VenueID, tier.SectionIDs)
+		if err != nil {
+			return err
+		}
+		if tier.Quota > seats {
+			return models.ErrTierQuotaExceeded
+		}
+
+		existing, err := s.tierRepo.GetByConcertID(ctx, concertID)
+		if err != nil {
+			return err
+		}
+		allocated := tier.Quota
+		for _, t := range existing {
+			allocated += t.Quota
+		}
+		if allocated > concert.TotalSeats {
+			return models.ErrTierQuotaExceeded
+		}
+
+		return s.tierRepo.Create(ctx, &tier)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tier, nil
+}
+
+func (s *ConcertService) GetPresales(ctx context.Context, concertID uuid.UUID) ([]models.Presale, error) {
+	if _, err := s.concertRepo.GetByID(ctx, concertID); err != nil {
+		return nil, err
+	}
+
+	presales, err := s.presaleRepo.GetByConcertID(ctx, concertID)
+	if err != nil {
+		return nil, err
+	}
+	if presales == nil {
+		presales = []models.Presale{}
+	}
+
+	return presales, nil
+}
+
+func (s *ConcertService) AddPresale(
+	ctx context.Context,
+	concertID uuid.UUID,
+	presale models.Presale,
+	accessCode string,
+) (*models.Presale, error) {
+	presale.Name = strings.TrimSpace(presale.Name)
+	accessCode = normalizeAccessCode(accessCode)
+	if presale.Name == "" || !presale.StartsAt.Before(presale.EndsAt) {
+		return nil, models.ErrInvalidPresale
+	}
+	if accessCode == "" && len(presale.UserIDs) == 0 {
+		return nil, fmt.Errorf("%w: access code or allowlist is required", models.ErrInvalidPresale)
+	}
+	if accessCode != "" {
+		presale.AccessCodeHash = hashAccessCode(s.codeKey, accessCode)
+	}
+	presale.ConcertID = concertID
+
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		concert, err := s.concertRepo.GetByID(ctx, concertID)
+		if err != nil {
+			return err
+		}
+
+		if presale.EndsAt.After(concert.Date) {
+			return fmt.Errorf("%w: presale must end before the concert", models.ErrInvalidPresale)
+		}
+
+		return s.presaleRepo.Create(ctx, &presale)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presale, nil
+}
+
+func (s *ConcertService) save(
+	ctx context.Context,
+	id uuid.UUID,
+	change func(existing *models.Concert) models.Concert,
+) (*models.Concert, error) {
+	var existing, concert *models.Concert
+
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		existing, err = s.concertRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		updated := change(existing)
+		updated.ID = id
+		concert = &updated
+
+		if err := validateConcert(concert, existing); err != nil {
+			return err
+		}
+
+		if err := s.attachVenue(ctx, concert); err != nil {
+			return err
+		}
+
+		if concert.VenueID != existing.VenueID || concert.Price.Currency != existing.Price.Currency {
+			if existing.Status == models.ConcertStatusOnSale || existing.Status == models.ConcertStatusSoldOut {
+				return models.ErrConcertPublished
+			}
+			hasBookings, err := s.concertRepo.HasBookings(ctx, concert.ID)
+			if err != nil {
+				return err
+			}
+			if hasBookings {
+				return models.ErrConcertHasBookings
+			}
+			if err := s.tierRepo.DeleteByConcertID(ctx, concert.ID); err != nil {
+				return err
+			}
+		} else if concert.TotalSeats < existing.TotalSeats {
+			tiers, err := s.tierRepo.GetByConcertID(ctx, concert.ID)
+			if err != nil {
+				return err
+			}
+			allocated := 0
+			for _, t := range tiers {
+				allocated += t.Quota
+			}
+			if allocated > concert.TotalSeats {
+				return models.ErrTierQuotaExceeded
+			}
+		}
+
+		return s.concertRepo.Update(ctx, concert)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cacheRepo.Delete(ctx)
+	if concert.TotalSeats != existing.TotalSeats {
+		_ = s.seatMap.Delete(ctx, concert.ID)
+	}
+
+	return concert, nil
+}
+
+func (s *ConcertService) attachVenue(ctx context.Context, concert *models.Concert) error {
+	venue, err := s.venueRepo.GetByID(ctx, concert.VenueID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return models.ErrVenueNotFound
+		}
+		return err
+	}
+
+	if concert.TotalSeats > venue.Capacity {
+		return models.ErrVenueCapacityExceeded
+	}
+
+	concert.VenueName = venue.Name
+	concert.VenueTimezone = venue.Timezone
+	concert.Localize()
+
+	return nil
+}
+
+func validateConcert(concert, existing *models.Concert) error {
+	if concert.Name == "" || concert.VenueID == uuid.Nil || !concert.Price.IsPositive() || concert.TotalSeats <= 0 {
+		return models.ErrInvalidConcert
+	}
+
+	if !models.ValidCurrency(concert.Price.Currency) {
+		return models.ErrInvalidCurrency
+	}
+
+	dateChanged := existing == nil || !concert.Date.Equal(existing.Date)
+	if dateChanged && !concert.Date.After(time.Now()) {
+		return models.ErrConcertInPast
+	}
+
+	if concert.SaleStartsAt != nil && concert.SaleEndsAt != nil && !concert.SaleStartsAt.Before(*concert.SaleEndsAt) {
+		return models.ErrInvalidSaleWindow
+	}
+	if concert.SaleEndsAt != nil && concert.SaleEndsAt.After(concert.Date) {
+		return models.ErrInvalidSaleWindow
+	}
+
+	return nil
+}
+
+func normalizeAccessCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func hashAccessCode(key []byte, code string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func normalizeConcertFilter(filter models.ConcertFilter) (models.ConcertFilter, *models.ConcertCursor, error) {
+	filter.Search = strings.TrimSpace(filter.Search)
+
+	if filter.Sort == "" {
+		filter.Sort = models.ConcertSortDate
+	}
+	if !filter.Sort.Valid() {
+		return filter, nil, models.ErrInvalidFilter
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultConcertPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > maxConcertPageSize {
+		return filter, nil, models.ErrInvalidFilter
+	}
+
+	if filter.DateFrom != nil && filter.DateTo != nil && filter.DateFrom.After(*filter.DateTo) {
+		return filter, nil, models.ErrInvalidFilter
+	}
+
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []models.ConcertStatus{models.ConcertStatusOnSale, models.ConcertStatusSoldOut}
+	}
+	for _, status := range filter.Statuses {
+		if !status.Valid() {
+			return filter, nil, models.ErrInvalidFilter
+		}
+		if status == models.ConcertStatusDraft && !filter.ShowDrafts {
+			return filter, nil, models.ErrForbidden
+		}
+	}
+	filter.Statuses = slices.Clone(filter.Statuses)
+	slices.Sort(filter.Statuses)
+	filter.Statuses = slices.Compact(filter.Statuses)
+
+	filter.Currency = strings.ToUpper(filter.Currency)
+	if filter.Currency != "" && !models.ValidCurrency(filter.Currency) {
+		return filter, nil, models.ErrInvalidFilter
+	}
+
+	if (filter.PriceMin != nil && *filter.PriceMin < 0) || (filter.PriceMax != nil && *filter.PriceMax < 0) {
+		return filter, nil, models.ErrInvalidFilter
+	}
+	if filter.PriceMin != nil && filter.PriceMax != nil && *filter.PriceMin > *filter.PriceMax {
+		return filter, nil, models.ErrInvalidFilter
+	}
+
+	if filter.Cursor == "" {
+		return filter, nil, nil
+	}
+
+	cursor, err := decodeConcertCursor(filter.Cursor)
+	if err != nil || cursor.Sort != filter.Sort {
+		return filter, nil, models.ErrInvalidCursor
+	}
+
+	return filter, cursor, nil
+}
+
+func concertCacheKey(filter models.ConcertFilter) string {
+	values := url.Values{}
+
+	if filter.DateFrom != nil {
+		values.Set("date_from", filter.DateFrom.UTC().Format(time.RFC3339Nano))
+	}
+	if filter.DateTo != nil {
+		values.Set("date_to", filter.DateTo.UTC().Format(time.RFC3339Nano))
+	}
+	if filter.VenueID != nil {
+		values.Set("venue_id", filter.VenueID.String())
+	}
+	if filter.Currency != "" {
+		values.Set("currency", filter.Currency)
+	}
+	if filter.PriceMin != nil {
+		values.Set("price_min", strconv.FormatInt(*filter.PriceMin, 10))
+	}
+	if filter.PriceMax != nil {
+		values.Set("price_max", strconv.FormatInt(*filter.PriceMax, 10))
+	}
+	for _, status := range filter.Statuses {
+		values.Add("status", string(status))
+	}
+	if filter.OnlyAvailable {
+		values.Set("available", "true")
+	}
+	if filter.Search != "" {
+		values.Set("q", strings.ToLower(filter.Search))
+	}
+	if filter.Cursor != "" {
+		values.Set("cursor", filter.Cursor)
+	}
+	values.Set("sort", string(filter.Sort))
+	values.Set("limit", strconv.Itoa(filter.Limit))
+
+	return values.Encode()
+}
+
+func encodeConcertCursor(sort models.ConcertSort, last models.Concert) string {
+	cursor := models.ConcertCursor{Sort: sort, ID: last.ID}
+
+	switch sort {
+	case models.ConcertSortPrice, models.ConcertSortPriceDesc:
+		cursor.Value = strconv.FormatInt(last.Price.Amount, 10)
+	case models.ConcertSortName, models.ConcertSortNameDesc:
+		cursor.Value = last.Name
+	default:
+		cursor.Value = last.Date.UTC().Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeConcertCursor(raw string) (*models.ConcertCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor models.ConcertCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	if cursor.ID == uuid.Nil {
+		return nil, models.ErrInvalidCursor
+	}
+
+	switch cursor.Sort {
+	case models.ConcertSortPrice, models.ConcertSortPriceDesc:
+		_, err = strconv.ParseInt(cursor.Value, 10, 64)
+	case models.ConcertSortDate, models.ConcertSortDateDesc:
+		_, err = time.Parse(time.RFC3339Nano, cursor.Value)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
 }
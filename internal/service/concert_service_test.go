@@ -14,86 +14,223 @@ import (
 	"github.com/yohnnn/booking_service/internal/service/mocks"
 )
 
-func TestConcertService_GetAll(t *testing.T) {
+func TestConcertService_List(t *testing.T) {
 	type mockBehavior func(repo *mocks.MockConcertRepository, cache *mocks.MockConcertCacheRepository)
 
 	concerts := []models.Concert{
 		{
 			ID:             uuid.New(),
 			Name:           "Rock Festival",
-			Place:          "Stadium",
+			VenueName:      "Stadium",
 			Date:           time.Now().Add(24 * time.Hour),
-			Price:          100.0,
+			Price:          models.NewMoney(10000, "RUB"),
 			TotalSeats:     1000,
 			AvailableSeats: 500,
 		},
 		{
 			ID:             uuid.New(),
 			Name:           "Jazz Night",
-			Place:          "Club",
+			VenueName:      "Club",
 			Date:           time.Now().Add(48 * time.Hour),
-			Price:          50.0,
+			Price:          models.NewMoney(5000, "RUB"),
 			TotalSeats:     200,
 			AvailableSeats: 100,
 		},
 	}
 
+	page := models.ConcertPage{Items: concerts}
+	priceMin, priceMax := int64(10000), int64(5000)
+
 	tests := []struct {
 		name         string
+		filter       models.ConcertFilter
 		mockBehavior mockBehavior
-		want         []models.Concert
+		want         *models.ConcertPage
 		wantErr      bool
+		wantErrType  error
+		checkResult  func(t *testing.T, page *models.ConcertPage)
 	}{
 		{
 			name: "success from cache",
 			mockBehavior: func(_ *mocks.MockConcertRepository, cache *mocks.MockConcertCacheRepository) {
 				cache.EXPECT().
-					Get(gomock.Any()).
-					Return(concerts, nil)
+					Get(gomock.Any(), "limit=20&sort=date&status=ON_SALE&status=SOLD_OUT").
+					Return(page, true, nil)
 			},
-			want:    concerts,
+			want:    &page,
 			wantErr: false,
 		},
 		{
 			name: "success from db (cache miss)",
 			mockBehavior: func(repo *mocks.MockConcertRepository, cache *mocks.MockConcertCacheRepository) {
 				cache.EXPECT().
-					Get(gomock.Any()).
-					Return(nil, nil)
+					Get(gomock.Any(), gomock.Any()).
+					Return(models.ConcertPage{}, false, nil)
 				repo.EXPECT().
-					GetAll(gomock.Any()).
+					List(gomock.Any(), gomock.Any(), nil, defaultConcertPageSize+1).
 					Return(concerts, nil)
 				cache.EXPECT().
-					Set(gomock.Any(), concerts).
+					Set(gomock.Any(), gomock.Any(), &page).
 					Return(nil)
 			},
-			want:    concerts,
+			want:    &page,
 			wantErr: false,
 		},
 		{
 			name: "success from db (cache error)",
 			mockBehavior: func(repo *mocks.MockConcertRepository, cache *mocks.MockConcertCacheRepository) {
 				cache.EXPECT().
-					Get(gomock.Any()).
-					Return(nil, assert.AnError)
+					Get(gomock.Any(), gomock.Any()).
+					Return(models.ConcertPage{}, false, assert.AnError)
 				repo.EXPECT().
-					GetAll(gomock.Any()).
+					List(gomock.Any(), gomock.Any(), nil, defaultConcertPageSize+1).
 					Return(concerts, nil)
 				cache.EXPECT().
-					Set(gomock.Any(), concerts).
+					Set(gomock.Any(), gomock.Any(), &page).
 					Return(nil)
 			},
-			want:    concerts,
+			want:    &page,
 			wantErr: false,
 		},
 		{
-			name: "repository error",
+			name:   "next cursor points at the last item of a full page",
+			filter: models.ConcertFilter{Sort: models.ConcertSortPriceDesc, Limit: 1},
+			mockBehavior: func(repo *mocks.MockConcertRepository, cache *mocks.MockConcertCacheRepository) {
+				cache.EXPECT().
+					Get(gomock.Any(), "limit=1&sort=-price&status=ON_SALE&status=SOLD_OUT").
+					Return(models.ConcertPage{}, false, nil)
+				repo.EXPECT().
+					List(gomock.Any(), gomock.Any(), nil, 2).
+					Return(concerts, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, page *models.ConcertPage) {
+				t.Helper()
+				require.Len(t, page.Items, 1)
+				require.NotEmpty(t, page.NextCursor)
+
+				cursor, err := decodeConcertCursor(page.NextCursor)
+				require.NoError(t, err)
+				assert.Equal(t, models.ConcertSortPriceDesc, cursor.Sort)
+				assert.Equal(t, "10000", cursor.Value)
+				assert.Equal(t, concerts[0].ID, cursor.ID)
+			},
+		},
+		{
+			name: "cursor is decoded and passed to repository",
+			filter: models.ConcertFilter{
+				Sort:   models.ConcertSortName,
+				Cursor: encodeConcertCursor(models.ConcertSortName, concerts[1]),
+			},
 			mockBehavior: func(repo *mocks.MockConcertRepository, cache *mocks.MockConcertCacheRepository) {
 				cache.EXPECT().
-					Get(gomock.Any()).
+					Get(gomock.Any(), gomock.Any()).
+					Return(models.ConcertPage{}, false, nil)
+				repo.EXPECT().
+					List(gomock.Any(), gomock.Any(), &models.ConcertCursor{
+						Sort:  models.ConcertSortName,
+						Value: "Jazz Night",
+						ID:    concerts[1].ID,
+					}, defaultConcertPageSize+1).
 					Return(nil, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			want:    &models.ConcertPage{Items: []models.Concert{}},
+			wantErr: false,
+		},
+		{
+			name: "cursor issued for another sort",
+			filter: models.ConcertFilter{
+				Sort:   models.ConcertSortDate,
+				Cursor: encodeConcertCursor(models.ConcertSortName, concerts[1]),
+			},
+			mockBehavior: func(_ *mocks.MockConcertRepository, _ *mocks.MockConcertCacheRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidCursor,
+		},
+		{
+			name:         "malformed cursor",
+			filter:       models.ConcertFilter{Cursor: "not-a-cursor"},
+			mockBehavior: func(_ *mocks.MockConcertRepository, _ *mocks.MockConcertCacheRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidCursor,
+		},
+		{
+			name:         "unknown sort",
+			filter:       models.ConcertFilter{Sort: "created_at"},
+			mockBehavior: func(_ *mocks.MockConcertRepository, _ *mocks.MockConcertCacheRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidFilter,
+		},
+		{
+			name:         "limit above maximum",
+			filter:       models.ConcertFilter{Limit: maxConcertPageSize + 1},
+			mockBehavior: func(_ *mocks.MockConcertRepository, _ *mocks.MockConcertCacheRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidFilter,
+		},
+		{
+			name: "admin lists drafts",
+			filter: models.ConcertFilter{
+				Statuses:   []models.ConcertStatus{models.ConcertStatusDraft, models.ConcertStatusDraft},
+				ShowDrafts: true,
+			},
+			mockBehavior: func(repo *mocks.MockConcertRepository, cache *mocks.MockConcertCacheRepository) {
+				cache.EXPECT().
+					Get(gomock.Any(), "limit=20&sort=date&status=DRAFT").
+					Return(models.ConcertPage{}, false, nil)
+				repo.EXPECT().
+					List(gomock.Any(), gomock.Any(), nil, defaultConcertPageSize+1).
+					DoAndReturn(func(
+						_ context.Context,
+						filter models.ConcertFilter,
+						_ *models.ConcertCursor,
+						_ int,
+					) ([]models.Concert, error) {
+						assert.Equal(t, []models.ConcertStatus{models.ConcertStatusDraft}, filter.Statuses)
+						return nil, nil
+					})
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			want:    &models.ConcertPage{Items: []models.Concert{}},
+			wantErr: false,
+		},
+		{
+			name:         "drafts hidden from public listing",
+			filter:       models.ConcertFilter{Statuses: []models.ConcertStatus{models.ConcertStatusDraft}},
+			mockBehavior: func(_ *mocks.MockConcertRepository, _ *mocks.MockConcertCacheRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrForbidden,
+		},
+		{
+			name:         "unknown status",
+			filter:       models.ConcertFilter{Statuses: []models.ConcertStatus{"ARCHIVED"}},
+			mockBehavior: func(_ *mocks.MockConcertRepository, _ *mocks.MockConcertCacheRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidFilter,
+		},
+		{
+			name:         "price range inverted",
+			filter:       models.ConcertFilter{PriceMin: &priceMin, PriceMax: &priceMax},
+			mockBehavior: func(_ *mocks.MockConcertRepository, _ *mocks.MockConcertCacheRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidFilter,
+		},
+		{
+			name: "repository error",
+			mockBehavior: func(repo *mocks.MockConcertRepository, cache *mocks.MockConcertCacheRepository) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(models.ConcertPage{}, false, nil)
 				repo.EXPECT().
-					GetAll(gomock.Any()).
+					List(gomock.Any(), gomock.Any(), nil, defaultConcertPageSize+1).
 					Return(nil, assert.AnError)
 			},
 			wantErr: true,
@@ -109,58 +246,140 @@ func TestConcertService_GetAll(t *testing.T) {
 			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
 			tt.mockBehavior(concertRepo, cacheRepo)
 
-			s := NewConcertService(testLogger(), concertRepo, cacheRepo)
+			s := NewConcertService(testLogger(), concertRepo, nil, nil, nil, cacheRepo, nil, nil, testAccessCodeKey)
 
-			got, err := s.GetAll(context.Background())
+			got, err := s.List(context.Background(), tt.filter)
 			if tt.wantErr {
 				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
 				return
 			}
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			}
+			if tt.checkResult != nil {
+				tt.checkResult(t, got)
+			}
 		})
 	}
 }
 
+func TestConcertCacheKey(t *testing.T) {
+	from := time.Date(2026, 11, 1, 18, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	priceMin := int64(1050)
+	venueID := uuid.MustParse("7f3c2a1e-0000-4000-8000-000000000001")
+
+	a, _, err := normalizeConcertFilter(models.ConcertFilter{
+		DateFrom: &from,
+		VenueID:  &venueID,
+		PriceMin: &priceMin,
+		Currency: "rub",
+		Search:   "Rock",
+	})
+	require.NoError(t, err)
+
+	fromUTC := from.UTC()
+	b, _, err := normalizeConcertFilter(models.ConcertFilter{
+		DateFrom: &fromUTC,
+		VenueID:  &venueID,
+		PriceMin: &priceMin,
+		Currency: "RUB",
+		Search:   "rock",
+		Sort:     models.ConcertSortDate,
+		Limit:    defaultConcertPageSize,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, concertCacheKey(a), concertCacheKey(b))
+	assert.Equal(
+		t,
+		"currency=RUB&date_from=2026-11-01T15%3A00%3A00Z&limit=20&price_min=1050&q=rock&sort=date"+
+			"&status=ON_SALE&status=SOLD_OUT&venue_id=7f3c2a1e-0000-4000-8000-000000000001",
+		concertCacheKey(a),
+	)
+}
+
 func TestConcertService_GetByID(t *testing.T) {
 	concertID := uuid.New()
+	saleStartsAt := time.Now().Add(time.Hour)
 
-	concert := &models.Concert{
+	concert := models.Concert{
 		ID:             concertID,
 		Name:           "Rock Festival",
-		Place:          "Stadium",
+		VenueName:      "Stadium",
+		VenueTimezone:  "Europe/Moscow",
 		Date:           time.Now().Add(24 * time.Hour),
-		Price:          100.0,
+		Price:          models.NewMoney(10000, "RUB"),
 		TotalSeats:     1000,
 		AvailableSeats: 500,
 	}
+	scheduled := concert
+	scheduled.SaleStartsAt = &saleStartsAt
 
-	type mockBehavior func(repo *mocks.MockConcertRepository)
+	type mockBehavior func(repo *mocks.MockConcertRepository, presaleRepo *mocks.MockPresaleRepository)
 
 	tests := []struct {
 		name         string
 		id           uuid.UUID
 		mockBehavior mockBehavior
-		want         *models.Concert
+		wantStatus   models.SaleStatus
 		wantErr      bool
 		wantErrType  error
 	}{
 		{
 			name: "success",
 			id:   concertID,
-			mockBehavior: func(repo *mocks.MockConcertRepository) {
+			mockBehavior: func(repo *mocks.MockConcertRepository, _ *mocks.MockPresaleRepository) {
+				c := concert
 				repo.EXPECT().
 					GetByID(gomock.Any(), concertID).
-					Return(concert, nil)
+					Return(&c, nil)
 			},
-			want:    concert,
-			wantErr: false,
+			wantStatus: models.SaleStatusOnSale,
+			wantErr:    false,
+		},
+		{
+			name: "sale scheduled",
+			id:   concertID,
+			mockBehavior: func(repo *mocks.MockConcertRepository, presaleRepo *mocks.MockPresaleRepository) {
+				c := scheduled
+				repo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&c, nil)
+				presaleRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.Presale{{
+						StartsAt: time.Now().Add(10 * time.Minute),
+						EndsAt:   saleStartsAt,
+					}}, nil)
+			},
+			wantStatus: models.SaleStatusScheduled,
+		},
+		{
+			name: "presale running",
+			id:   concertID,
+			mockBehavior: func(repo *mocks.MockConcertRepository, presaleRepo *mocks.MockPresaleRepository) {
+				c := scheduled
+				repo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&c, nil)
+				presaleRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.Presale{{
+						StartsAt: time.Now().Add(-10 * time.Minute),
+						EndsAt:   saleStartsAt,
+					}}, nil)
+			},
+			wantStatus: models.SaleStatusPresale,
 		},
 		{
 			name: "not found",
 			id:   uuid.New(),
-			mockBehavior: func(repo *mocks.MockConcertRepository) {
+			mockBehavior: func(repo *mocks.MockConcertRepository, _ *mocks.MockPresaleRepository) {
 				repo.EXPECT().
 					GetByID(gomock.Any(), gomock.Any()).
 					Return(nil, models.ErrNotFound)
@@ -171,7 +390,7 @@ func TestConcertService_GetByID(t *testing.T) {
 		{
 			name: "repository error",
 			id:   concertID,
-			mockBehavior: func(repo *mocks.MockConcertRepository) {
+			mockBehavior: func(repo *mocks.MockConcertRepository, _ *mocks.MockPresaleRepository) {
 				repo.EXPECT().
 					GetByID(gomock.Any(), concertID).
 					Return(nil, assert.AnError)
@@ -186,10 +405,21 @@ func TestConcertService_GetByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			presaleRepo := mocks.NewMockPresaleRepository(ctrl)
 			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
-			tt.mockBehavior(concertRepo)
+			tt.mockBehavior(concertRepo, presaleRepo)
 
-			s := NewConcertService(testLogger(), concertRepo, cacheRepo)
+			s := NewConcertService(
+				testLogger(),
+				concertRepo,
+				nil,
+				nil,
+				presaleRepo,
+				cacheRepo,
+				nil,
+				nil,
+				testAccessCodeKey,
+			)
 
 			got, err := s.GetByID(context.Background(), tt.id)
 			if tt.wantErr {
@@ -201,7 +431,1512 @@ func TestConcertService_GetByID(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
+			assert.Equal(t, concertID, got.ID)
+			assert.Equal(t, tt.wantStatus, got.SaleStatus)
+			assert.Equal(t, "Europe/Moscow", got.Date.Location().String())
+		})
+	}
+}
+
+func TestConcertService_Create(t *testing.T) {
+	venueID := uuid.New()
+	venue := &models.Venue{ID: venueID, Name: "Stadium", Capacity: 1000}
+
+	valid := models.Concert{
+		Name:       "Rock Festival",
+		VenueID:    venueID,
+		Date:       time.Now().Add(24 * time.Hour),
+		Price:      models.NewMoney(10000, "RUB"),
+		TotalSeats: 1000,
+	}
+
+	type mockBehavior func(
+		repo *mocks.MockConcertRepository,
+		venueRepo *mocks.MockVenueRepository,
+		cache *mocks.MockConcertCacheRepository,
+	)
+
+	tests := []struct {
+		name         string
+		input        func() models.Concert
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name:  "success",
+			input: func() models.Concert { return valid },
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				cache *mocks.MockConcertCacheRepository,
+			) {
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *models.Concert) error {
+						assert.Equal(t, models.ConcertStatusDraft, c.Status)
+						c.ID = uuid.New()
+						c.AvailableSeats = c.TotalSeats
+						return nil
+					})
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "date in the past",
+			input: func() models.Concert {
+				c := valid
+				c.Date = time.Now().Add(-time.Hour)
+				return c
+			},
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockVenueRepository,
+				_ *mocks.MockConcertCacheRepository,
+			) {
+			},
+			wantErr:     true,
+			wantErrType: models.ErrConcertInPast,
+		},
+		{
+			name: "non-positive price",
+			input: func() models.Concert {
+				c := valid
+				c.Price.Amount = 0
+				return c
+			},
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockVenueRepository,
+				_ *mocks.MockConcertCacheRepository,
+			) {
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidConcert,
+		},
+		{
+			name: "invalid currency",
+			input: func() models.Concert {
+				c := valid
+				c.Price.Currency = "rub"
+				return c
+			},
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockVenueRepository,
+				_ *mocks.MockConcertCacheRepository,
+			) {
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidCurrency,
+		},
+		{
+			name:  "unknown venue",
+			input: func() models.Concert { return valid },
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockConcertCacheRepository,
+			) {
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrVenueNotFound,
+		},
+		{
+			name: "more seats than venue capacity",
+			input: func() models.Concert {
+				c := valid
+				c.TotalSeats = venue.Capacity + 1
+				return c
+			},
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockConcertCacheRepository,
+			) {
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrVenueCapacityExceeded,
+		},
+		{
+			name:  "repository error",
+			input: func() models.Concert { return valid },
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockConcertCacheRepository,
+			) {
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			venueRepo := mocks.NewMockVenueRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			tt.mockBehavior(concertRepo, venueRepo, cacheRepo)
+
+			s := NewConcertService(
+				testLogger(),
+				concertRepo,
+				venueRepo,
+				nil,
+				nil,
+				cacheRepo,
+				nil,
+				nil,
+				testAccessCodeKey,
+			)
+
+			got, err := s.Create(context.Background(), tt.input())
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, got.ID)
+			assert.Equal(t, got.TotalSeats, got.AvailableSeats)
+			assert.Equal(t, "Stadium", got.VenueName)
+		})
+	}
+}
+
+func TestConcertService_Patch(t *testing.T) {
+	concertID := uuid.New()
+	venueID := uuid.New()
+	otherVenueID := uuid.New()
+	venue := &models.Venue{ID: venueID, Name: "Stadium", Capacity: 1000}
+	club := &models.Venue{ID: otherVenueID, Name: "Club", Capacity: 2000}
+
+	existing := func() *models.Concert {
+		return &models.Concert{
+			ID:             concertID,
+			Name:           "Rock Festival",
+			VenueID:        venueID,
+			VenueName:      "Stadium",
+			Date:           time.Now().Add(24 * time.Hour),
+			Price:          models.NewMoney(10000, "RUB"),
+			TotalSeats:     1000,
+			AvailableSeats: 400,
+		}
+	}
+
+	newName := "Rock Festival 2"
+	newTotal := 500
+	tiers := []models.TicketTier{{ID: uuid.New(), ConcertID: concertID, Quota: 300}, {ID: uuid.New(), Quota: 200}}
+	pastDate := time.Now().Add(-time.Hour)
+
+	type mockBehavior func(
+		repo *mocks.MockConcertRepository,
+		venueRepo *mocks.MockVenueRepository,
+		tierRepo *mocks.MockTicketTierRepository,
+		cache *mocks.MockConcertCacheRepository,
+		seatMap *mocks.MockSeatMapRepository,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		patch        models.ConcertPatch
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+		checkResult  func(t *testing.T, concert *models.Concert)
+	}{
+		{
+			name:  "only provided fields change",
+			patch: models.ConcertPatch{Name: &newName},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockTicketTierRepository,
+				cache *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, concert *models.Concert) {
+				t.Helper()
+				assert.Equal(t, newName, concert.Name)
+				assert.Equal(t, venueID, concert.VenueID)
+				assert.Equal(t, 1000, concert.TotalSeats)
+			},
+		},
+		{
+			name:  "sale window cleared",
+			patch: models.ConcertPatch{ClearSaleStartsAt: true, ClearSaleEndsAt: true},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockTicketTierRepository,
+				cache *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				concert := existing()
+				saleStartsAt := time.Now().Add(time.Hour)
+				saleEndsAt := time.Now().Add(2 * time.Hour)
+				concert.SaleStartsAt = &saleStartsAt
+				concert.SaleEndsAt = &saleEndsAt
+
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concert, nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, concert *models.Concert) {
+				t.Helper()
+				assert.Nil(t, concert.SaleStartsAt)
+				assert.Nil(t, concert.SaleEndsAt)
+				assert.Equal(t, "Rock Festival", concert.Name)
+			},
+		},
+		{
+			name:  "total seats change drops seat map",
+			patch: models.ConcertPatch{TotalSeats: &newTotal},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cache *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(tiers, nil)
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					Delete(gomock.Any(), concertID).
+					Return(nil)
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, concert *models.Concert) {
+				t.Helper()
+				assert.Equal(t, newTotal, concert.TotalSeats)
+			},
+		},
+		{
+			name:  "total seats below booked",
+			patch: models.ConcertPatch{TotalSeats: &newTotal},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(tiers, nil)
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(models.ErrSeatsBelowBooked)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrSeatsBelowBooked,
+		},
+		{
+			name:  "total seats below tier quotas",
+			patch: models.ConcertPatch{TotalSeats: &newTotal},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(append(tiers, models.TicketTier{ID: uuid.New(), Quota: 100}), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrTierQuotaExceeded,
+		},
+		{
+			name:  "venue change with bookings",
+			patch: models.ConcertPatch{VenueID: &otherVenueID},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), otherVenueID).
+					Return(club, nil)
+				repo.EXPECT().
+					HasBookings(gomock.Any(), concertID).
+					Return(true, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrConcertHasBookings,
+		},
+		{
+			name:  "venue change without bookings",
+			patch: models.ConcertPatch{VenueID: &otherVenueID},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cache *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), otherVenueID).
+					Return(club, nil)
+				repo.EXPECT().
+					HasBookings(gomock.Any(), concertID).
+					Return(false, nil)
+				tierRepo.EXPECT().
+					DeleteByConcertID(gomock.Any(), concertID).
+					Return(nil)
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, concert *models.Concert) {
+				t.Helper()
+				assert.Equal(t, otherVenueID, concert.VenueID)
+				assert.Equal(t, "Club", concert.VenueName)
+			},
+		},
+		{
+			name:  "unknown venue keeps tiers",
+			patch: models.ConcertPatch{VenueID: &otherVenueID},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), otherVenueID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrVenueNotFound,
+		},
+		{
+			name:  "venue change rejected once on sale",
+			patch: models.ConcertPatch{VenueID: &otherVenueID},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				onSale := existing()
+				onSale.Status = models.ConcertStatusOnSale
+
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(onSale, nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), otherVenueID).
+					Return(club, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrConcertPublished,
+		},
+		{
+			name:  "failed update rolls back tier removal",
+			patch: models.ConcertPatch{VenueID: &otherVenueID, TotalSeats: &newTotal},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						err := fn(ctx)
+						assert.ErrorIs(t, err, models.ErrSeatsBelowBooked)
+						return err
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), otherVenueID).
+					Return(club, nil)
+				repo.EXPECT().
+					HasBookings(gomock.Any(), concertID).
+					Return(false, nil)
+				tierRepo.EXPECT().
+					DeleteByConcertID(gomock.Any(), concertID).
+					Return(nil)
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(models.ErrSeatsBelowBooked)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrSeatsBelowBooked,
+		},
+		{
+			name:  "past concert can be renamed",
+			patch: models.ConcertPatch{Name: &newName},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				venueRepo *mocks.MockVenueRepository,
+				_ *mocks.MockTicketTierRepository,
+				cache *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				past := existing()
+				past.Date = pastDate
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(past, nil)
+				venueRepo.EXPECT().
+					GetByID(gomock.Any(), venueID).
+					Return(venue, nil)
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, concert *models.Concert) {
+				t.Helper()
+				assert.Equal(t, newName, concert.Name)
+			},
+		},
+		{
+			name:  "date moved to the past",
+			patch: models.ConcertPatch{Date: &pastDate},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockVenueRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(existing(), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrConcertInPast,
+		},
+		{
+			name:  "not found",
+			patch: models.ConcertPatch{Name: &newName},
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockVenueRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			venueRepo := mocks.NewMockVenueRepository(ctrl)
+			tierRepo := mocks.NewMockTicketTierRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(concertRepo, venueRepo, tierRepo, cacheRepo, seatMap, txManager)
+
+			s := NewConcertService(
+				testLogger(),
+				concertRepo,
+				venueRepo,
+				tierRepo,
+				nil,
+				cacheRepo,
+				seatMap,
+				txManager,
+				testAccessCodeKey,
+			)
+
+			got, err := s.Patch(context.Background(), concertID, tt.patch)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.checkResult != nil {
+				tt.checkResult(t, got)
+			}
+		})
+	}
+}
+
+func TestConcertService_Delete(t *testing.T) {
+	concertID := uuid.New()
+
+	type mockBehavior func(
+		repo *mocks.MockConcertRepository,
+		cache *mocks.MockConcertCacheRepository,
+		seatMap *mocks.MockSeatMapRepository,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				cache *mocks.MockConcertCacheRepository,
+				seatMap *mocks.MockSeatMapRepository,
+			) {
+				repo.EXPECT().
+					Delete(gomock.Any(), concertID).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+				seatMap.EXPECT().
+					Delete(gomock.Any(), concertID).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "concert has bookings",
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockSeatMapRepository,
+			) {
+				repo.EXPECT().
+					Delete(gomock.Any(), concertID).
+					Return(models.ErrConcertHasBookings)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrConcertHasBookings,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			seatMap := mocks.NewMockSeatMapRepository(ctrl)
+			tt.mockBehavior(concertRepo, cacheRepo, seatMap)
+
+			s := NewConcertService(testLogger(), concertRepo, nil, nil, nil, cacheRepo, seatMap, nil, testAccessCodeKey)
+
+			err := s.Delete(context.Background(), concertID)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConcertService_ChangeStatus(t *testing.T) {
+	concertID := uuid.New()
+
+	concertIn := func(status models.ConcertStatus, date time.Time, available int) *models.Concert {
+		return &models.Concert{
+			ID:             concertID,
+			Date:           date,
+			TotalSeats:     100,
+			AvailableSeats: available,
+			Status:         status,
+		}
+	}
+	upcoming := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	covering := []models.TicketTier{{ID: uuid.New(), ConcertID: concertID, Quota: 60}, {ID: uuid.New(), Quota: 40}}
+
+	type mockBehavior func(
+		repo *mocks.MockConcertRepository,
+		tierRepo *mocks.MockTicketTierRepository,
+		cache *mocks.MockConcertCacheRepository,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		status       models.ConcertStatus
+		mockBehavior mockBehavior
+		wantStatus   models.ConcertStatus
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name:   "publish draft",
+			status: models.ConcertStatusOnSale,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cache *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusDraft, upcoming, 100), nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(covering, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), concertID, models.ConcertStatusDraft, models.ConcertStatusOnSale).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+			wantStatus: models.ConcertStatusOnSale,
+		},
+		{
+			name:   "publish draft without seats goes sold out",
+			status: models.ConcertStatusOnSale,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				cache *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusDraft, upcoming, 0), nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(covering, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), concertID, models.ConcertStatusDraft, models.ConcertStatusSoldOut).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+			wantStatus: models.ConcertStatusSoldOut,
+		},
+		{
+			name:   "publish draft without tiers",
+			status: models.ConcertStatusOnSale,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusDraft, upcoming, 100), nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(nil, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "publish draft with uncovered seats",
+			status: models.ConcertStatusOnSale,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusDraft, upcoming, 100), nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(covering[:1], nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "cancel goes through the cancel action",
+			status: models.ConcertStatusCancelled,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusOnSale, upcoming, 10), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "complete past concert",
+			status: models.ConcertStatusCompleted,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				cache *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusOnSale, past, 10), nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), concertID, models.ConcertStatusOnSale, models.ConcertStatusCompleted).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any()).
+					Return(nil)
+			},
+			wantStatus: models.ConcertStatusCompleted,
+		},
+		{
+			name:   "complete upcoming concert",
+			status: models.ConcertStatusCompleted,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusOnSale, upcoming, 10), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "reopen cancelled concert",
+			status: models.ConcertStatusOnSale,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusCancelled, upcoming, 10), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "sold out is not set manually",
+			status: models.ConcertStatusSoldOut,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusOnSale, upcoming, 10), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "concurrent status change",
+			status: models.ConcertStatusCompleted,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concertIn(models.ConcertStatusOnSale, past, 10), nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), concertID, models.ConcertStatusOnSale, models.ConcertStatusCompleted).
+					Return(models.ErrInvalidStatusTransition)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "unknown status",
+			status: "ARCHIVED",
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				_ *mocks.MockTxManager,
+			) {
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidConcertStatus,
+		},
+		{
+			name:   "concert not found",
+			status: models.ConcertStatusOnSale,
+			mockBehavior: func(
+				repo *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockConcertCacheRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			tierRepo := mocks.NewMockTicketTierRepository(ctrl)
+			cacheRepo := mocks.NewMockConcertCacheRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(concertRepo, tierRepo, cacheRepo, txManager)
+
+			s := NewConcertService(
+				testLogger(),
+				concertRepo,
+				nil,
+				tierRepo,
+				nil,
+				cacheRepo,
+				nil,
+				txManager,
+				testAccessCodeKey,
+			)
+
+			got, err := s.ChangeStatus(context.Background(), concertID, tt.status)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+		})
+	}
+}
+
+func TestConcertService_GetTiers(t *testing.T) {
+	concertID := uuid.New()
+	tiers := []models.TicketTier{{ID: uuid.New(), ConcertID: concertID, Name: "VIP"}}
+
+	tests := []struct {
+		name        string
+		status      models.ConcertStatus
+		showDrafts  bool
+		wantTiers   bool
+		wantErrType error
+	}{
+		{name: "on sale concert", status: models.ConcertStatusOnSale, wantTiers: true},
+		{name: "draft concert hidden", status: models.ConcertStatusDraft, wantErrType: models.ErrNotFound},
+		{name: "draft concert shown to admin", status: models.ConcertStatusDraft, showDrafts: true, wantTiers: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			tierRepo := mocks.NewMockTicketTierRepository(ctrl)
+			concertRepo.EXPECT().
+				GetByID(gomock.Any(), concertID).
+				Return(&models.Concert{ID: concertID, Status: tt.status}, nil)
+			if tt.wantTiers {
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return(tiers, nil)
+			}
+
+			s := NewConcertService(testLogger(), concertRepo, nil, tierRepo, nil, nil, nil, nil, testAccessCodeKey)
+
+			got, err := s.GetTiers(context.Background(), concertID, tt.showDrafts)
+			if tt.wantErrType != nil {
+				require.ErrorIs(t, err, tt.wantErrType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tiers, got)
+		})
+	}
+}
+
+func TestConcertService_AddTier(t *testing.T) {
+	concertID := uuid.New()
+	venueID := uuid.New()
+	sectionID := uuid.New()
+
+	concert := &models.Concert{
+		ID:         concertID,
+		VenueID:    venueID,
+		Price:      models.NewMoney(10000, "RUB"),
+		TotalSeats: 500,
+	}
+
+	validTier := models.TicketTier{
+		Name:       " VIP ",
+		Price:      models.Money{Amount: 25000},
+		Quota:      100,
+		SectionIDs: []uuid.UUID{sectionID},
+	}
+
+	type mockBehavior func(
+		concertRepo *mocks.MockConcertRepository,
+		tierRepo *mocks.MockTicketTierRepository,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		tier         models.TicketTier
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "success",
+			tier: validTier,
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					CountSectionSeats(gomock.Any(), venueID, []uuid.UUID{sectionID}).
+					Return(120, nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.TicketTier{{Quota: 300}}, nil)
+				tierRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, tier *models.TicketTier) error {
+						assert.Equal(t, "VIP", tier.Name)
+						assert.Equal(t, concertID, tier.ConcertID)
+						assert.Equal(t, models.NewMoney(25000, "RUB"), tier.Price)
+						tier.ID = uuid.New()
+						tier.Available = tier.Quota
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid input",
+			tier: models.TicketTier{Name: "VIP", Quota: 10, SectionIDs: []uuid.UUID{sectionID}},
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockTicketTierRepository,
+				_ *mocks.MockTxManager,
+			) {
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidTier,
+		},
+		{
+			name: "quota above section seats",
+			tier: validTier,
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					CountSectionSeats(gomock.Any(), venueID, []uuid.UUID{sectionID}).
+					Return(50, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrTierQuotaExceeded,
+		},
+		{
+			name: "quotas above total seats",
+			tier: validTier,
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					CountSectionSeats(gomock.Any(), venueID, []uuid.UUID{sectionID}).
+					Return(120, nil)
+				tierRepo.EXPECT().
+					GetByConcertID(gomock.Any(), concertID).
+					Return([]models.TicketTier{{Quota: 450}}, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrTierQuotaExceeded,
+		},
+		{
+			name: "section of another venue",
+			tier: validTier,
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				tierRepo *mocks.MockTicketTierRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), concertID).
+					Return(concert, nil)
+				tierRepo.EXPECT().
+					CountSectionSeats(gomock.Any(), venueID, []uuid.UUID{sectionID}).
+					Return(0, models.ErrInvalidTier)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidTier,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			tierRepo := mocks.NewMockTicketTierRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(concertRepo, tierRepo, txManager)
+
+			s := NewConcertService(
+				testLogger(),
+				concertRepo,
+				nil,
+				tierRepo,
+				nil,
+				nil,
+				nil,
+				txManager,
+				testAccessCodeKey,
+			)
+
+			got, err := s.AddTier(context.Background(), concertID, tt.tier)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, got.ID)
+			assert.Equal(t, got.Quota, got.Available)
+		})
+	}
+}
+
+func TestConcertService_AddPresale(t *testing.T) {
+	concertID := uuid.New()
+	startsAt := time.Now().Add(time.Hour)
+	endsAt := startsAt.Add(24 * time.Hour)
+	concert := &models.Concert{ID: concertID, Date: time.Now().Add(7 * 24 * time.Hour)}
+
+	type mockBehavior func(
+		concertRepo *mocks.MockConcertRepository,
+		presaleRepo *mocks.MockPresaleRepository,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		presale      models.Presale
+		accessCode   string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+		checkResult  func(t *testing.T, presale *models.Presale)
+	}{
+		{
+			name:       "success with access code",
+			presale:    models.Presale{Name: " Fan club ", StartsAt: startsAt, EndsAt: endsAt},
+			accessCode: " fanclub2026 ",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				presaleRepo *mocks.MockPresaleRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				presaleRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			checkResult: func(t *testing.T, presale *models.Presale) {
+				t.Helper()
+				assert.Equal(t, "Fan club", presale.Name)
+				assert.Equal(t, concertID, presale.ConcertID)
+				assert.Equal(t, hashAccessCode([]byte(testAccessCodeKey), "FANCLUB2026"), presale.AccessCodeHash)
+			},
+		},
+		{
+			name:    "neither code nor allowlist",
+			presale: models.Presale{Name: "Fan club", StartsAt: startsAt, EndsAt: endsAt},
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockPresaleRepository,
+				_ *mocks.MockTxManager,
+			) {
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidPresale,
+		},
+		{
+			name:       "window ends before start",
+			presale:    models.Presale{Name: "Fan club", StartsAt: endsAt, EndsAt: startsAt},
+			accessCode: "fanclub",
+			mockBehavior: func(
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockPresaleRepository,
+				_ *mocks.MockTxManager,
+			) {
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidPresale,
+		},
+		{
+			name: "presale ends after concert",
+			presale: models.Presale{
+				Name:     "Fan club",
+				StartsAt: startsAt,
+				EndsAt:   concert.Date.Add(time.Hour),
+				UserIDs:  []uuid.UUID{uuid.New()},
+			},
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockPresaleRepository,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidPresale,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			presaleRepo := mocks.NewMockPresaleRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(concertRepo, presaleRepo, txManager)
+
+			s := NewConcertService(
+				testLogger(),
+				concertRepo,
+				nil,
+				nil,
+				presaleRepo,
+				nil,
+				nil,
+				txManager,
+				testAccessCodeKey,
+			)
+
+			got, err := s.AddPresale(context.Background(), concertID, tt.presale, tt.accessCode)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.checkResult != nil {
+				tt.checkResult(t, got)
+			}
 		})
 	}
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/google/uuid"
 
@@ -10,18 +11,99 @@ import (
 
 type Auth interface {
 	Register(ctx context.Context, email, password string) (*models.User, error)
-	Login(ctx context.Context, email, password string) (string, error)
-	ParseToken(tokenString string) (uuid.UUID, error)
+	Login(ctx context.Context, email, password string) (*models.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.TokenPair, error)
+	Logout(ctx context.Context, claims *models.TokenClaims, refreshToken string) error
+	ParseToken(tokenString string) (*models.TokenClaims, error)
+	ValidateToken(ctx context.Context, tokenString string) (*models.TokenClaims, error)
 }
 
 type Concert interface {
-	GetAll(ctx context.Context) ([]models.Concert, error)
+	List(ctx context.Context, filter models.ConcertFilter) (*models.ConcertPage, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Concert, error)
+	Create(ctx context.Context, concert models.Concert) (*models.Concert, error)
+	Update(ctx context.Context, id uuid.UUID, concert models.Concert) (*models.Concert, error)
+	Patch(ctx context.Context, id uuid.UUID, patch models.ConcertPatch) (*models.Concert, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ChangeStatus(ctx context.Context, id uuid.UUID, status models.ConcertStatus) (*models.Concert, error)
+	GetTiers(ctx context.Context, concertID uuid.UUID, showDrafts bool) ([]models.TicketTier, error)
+	AddTier(ctx context.Context, concertID uuid.UUID, tier models.TicketTier) (*models.TicketTier, error)
+	GetPresales(ctx context.Context, concertID uuid.UUID) ([]models.Presale, error)
+	AddPresale(
+		ctx context.Context,
+		concertID uuid.UUID,
+		presale models.Presale,
+		accessCode string,
+	) (*models.Presale, error)
+}
+
+type Venue interface {
+	Create(ctx context.Context, venue models.Venue) (*models.Venue, error)
+	GetAll(ctx context.Context) ([]models.Venue, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Venue, error)
+	AddSection(
+		ctx context.Context,
+		venueID uuid.UUID,
+		name string,
+		rows []models.VenueRowSpec,
+	) (*models.VenueSection, error)
 }
 
 type Booking interface {
-	CreateBooking(ctx context.Context, userID, concertID uuid.UUID, seat int) (*models.Booking, error)
+	CreateBookings(ctx context.Context, userID uuid.UUID, req models.BookingRequest) ([]models.Booking, error)
 	GetUserBookings(ctx context.Context, userID uuid.UUID) ([]models.Booking, error)
+	CancelBooking(ctx context.Context, userID, bookingID uuid.UUID) (*models.Booking, error)
+	GetSeatMap(ctx context.Context, concertID uuid.UUID, showDrafts bool) (*models.SeatAvailability, error)
+}
+
+type BookingSettler interface {
+	ConfirmBooking(ctx context.Context, userID, bookingID uuid.UUID) (*models.Booking, error)
+	ReleaseBooking(ctx context.Context, userID, bookingID uuid.UUID) (*models.Booking, error)
+}
+
+type Payment interface {
+	CreateIntent(ctx context.Context, userID, bookingID uuid.UUID) (*models.PaymentIntent, error)
+	GetIntent(ctx context.Context, userID, intentID uuid.UUID) (*models.PaymentIntent, error)
+	HandleWebhook(ctx context.Context, payload []byte, headers http.Header) error
+}
+
+type ConcertCancellation interface {
+	Cancel(ctx context.Context, concertID uuid.UUID, reason string) (*models.ConcertCancellation, error)
+	Get(ctx context.Context, concertID uuid.UUID) (*models.ConcertCancellation, error)
+}
+
+type WaitingRoom interface {
+	Open(ctx context.Context, concertID uuid.UUID, admitRate int) (*models.WaitingRoom, error)
+	Close(ctx context.Context, concertID uuid.UUID) error
+	Join(ctx context.Context, userID, concertID uuid.UUID) (*models.QueueTicket, error)
+	Status(ctx context.Context, userID, concertID uuid.UUID) (*models.QueueTicket, error)
+}
+
+type Waitlist interface {
+	Join(ctx context.Context, userID, concertID uuid.UUID) (*models.WaitlistEntry, error)
+	Get(ctx context.Context, userID, concertID uuid.UUID) (*models.WaitlistEntry, error)
+	Leave(ctx context.Context, userID, concertID uuid.UUID) error
+}
+
+type PromoCode interface {
+	Create(ctx context.Context, promo models.PromoCode) (*models.PromoCode, error)
+	GetAll(ctx context.Context) ([]models.PromoCode, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PromoCode, error)
+	Deactivate(ctx context.Context, id uuid.UUID) error
+}
+
+type Refund interface {
+	Request(ctx context.Context, userID, bookingID uuid.UUID) (*models.Refund, error)
+	Issue(ctx context.Context, bookingID uuid.UUID) (*models.Refund, error)
+	Get(ctx context.Context, userID, refundID uuid.UUID) (*models.Refund, error)
+	GetUserRefunds(ctx context.Context, userID uuid.UUID) ([]models.Refund, error)
+	GetConcertRefunds(ctx context.Context, concertID uuid.UUID) ([]models.Refund, error)
+	Retry(ctx context.Context, refundID uuid.UUID) (*models.Refund, error)
+}
+
+type Ledger interface {
+	ConcertReport(ctx context.Context, concertID uuid.UUID) (*models.LedgerReport, error)
+	Check(ctx context.Context) (*models.LedgerCheck, error)
 }
 
 type TxManager interface {
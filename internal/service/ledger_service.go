@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+type LedgerService struct {
+	logger     *slog.Logger
+	ledgerRepo repository.LedgerRepository
+}
+
+func NewLedgerService(logger *slog.Logger, ledgerRepo repository.LedgerRepository) *LedgerService {
+	return &LedgerService{
+		logger:     logger,
+		ledgerRepo: ledgerRepo,
+	}
+}
+
+func (s *LedgerService) ConcertReport(ctx context.Context, concertID uuid.UUID) (*models.LedgerReport, error) {
+	balances, err := s.ledgerRepo.ConcertBalances(ctx, concertID)
+	if err != nil {
+		return nil, err
+	}
+
+	net := make(map[string]int64)
+	for _, b := range balances {
+		net[b.Currency] += b.Balance
+	}
+
+	report := &models.LedgerReport{
+		ConcertID: concertID,
+		Balances:  balances,
+		Balanced:  true,
+	}
+	for _, sum := range net {
+		if sum != 0 {
+			report.Balanced = false
+		}
+	}
+
+	return report, nil
+}
+
+func (s *LedgerService) Check(ctx context.Context) (*models.LedgerCheck, error) {
+	totals, err := s.ledgerRepo.Totals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unbalanced, err := s.ledgerRepo.UnbalancedTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &models.LedgerCheck{
+		Totals:                 totals,
+		UnbalancedTransactions: unbalanced,
+		Balanced:               len(unbalanced) == 0,
+	}
+	for _, t := range totals {
+		if t.Debit != t.Credit {
+			check.Balanced = false
+		}
+	}
+
+	if !check.Balanced {
+		s.logger.ErrorContext(ctx, "ledger invariant violated", "unbalanced_transactions", len(unbalanced))
+	}
+
+	return check, nil
+}
+
+func postLedger(ctx context.Context, ledgerRepo repository.LedgerRepository, txs ...models.LedgerTransaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+
+	for i := range txs {
+		if err := txs[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := ledgerRepo.Post(ctx, txs); err != nil {
+		return fmt.Errorf("failed to post ledger transactions: %w", err)
+	}
+	return nil
+}
+
+func saleTransaction(booking *models.Booking) models.LedgerTransaction {
+	revenue := models.Money{
+		Amount:   booking.FaceValue.Amount - booking.Discount.Amount,
+		Currency: booking.FaceValue.Currency,
+	}
+
+	entries := []models.LedgerEntry{
+		{Account: models.LedgerAccountCustomer, Direction: models.LedgerDebit, Amount: booking.Price},
+		{Account: models.LedgerAccountOrganizerRevenue, Direction: models.LedgerCredit, Amount: revenue},
+	}
+	if booking.ServiceFee.IsPositive() {
+		entries = append(entries, models.LedgerEntry{
+			Account: models.LedgerAccountPlatformFees, Direction: models.LedgerCredit, Amount: booking.ServiceFee,
+		})
+	}
+	if booking.VAT.IsPositive() {
+		entries = append(entries, models.LedgerEntry{
+			Account: models.LedgerAccountVATPayable, Direction: models.LedgerCredit, Amount: booking.VAT,
+		})
+	}
+
+	return models.LedgerTransaction{
+		Type:      models.LedgerTransactionSale,
+		ConcertID: booking.ConcertID,
+		BookingID: &booking.ID,
+		Entries:   entries,
+	}
+}
+
+func saleTransactions(bookings []models.Booking) []models.LedgerTransaction {
+	var txs []models.LedgerTransaction
+	for i := range bookings {
+		if bookings[i].Price.IsPositive() {
+			txs = append(txs, saleTransaction(&bookings[i]))
+		}
+	}
+	return txs
+}
+
+func saleReversalTransactions(bookings []models.Booking) []models.LedgerTransaction {
+	txs := saleTransactions(bookings)
+	for i := range txs {
+		txs[i].Type = models.LedgerTransactionSaleReversal
+		for j := range txs[i].Entries {
+			if txs[i].Entries[j].Direction == models.LedgerDebit {
+				txs[i].Entries[j].Direction = models.LedgerCredit
+			} else {
+				txs[i].Entries[j].Direction = models.LedgerDebit
+			}
+		}
+	}
+	return txs
+}
+
+func paymentTransaction(concertID uuid.UUID, intent *models.PaymentIntent) models.LedgerTransaction {
+	return models.LedgerTransaction{
+		Type:        models.LedgerTransactionPayment,
+		ConcertID:   concertID,
+		BookingID:   &intent.BookingID,
+		ReferenceID: &intent.ID,
+		Entries: []models.LedgerEntry{
+			{Account: models.LedgerAccountPaymentProvider, Direction: models.LedgerDebit, Amount: intent.Amount},
+			{Account: models.LedgerAccountCustomer, Direction: models.LedgerCredit, Amount: intent.Amount},
+		},
+	}
+}
+
+func refundTransaction(refund *models.Refund, booking *models.Booking) models.LedgerTransaction {
+	entries := []models.LedgerEntry{
+		{Account: models.LedgerAccountRefunds, Direction: models.LedgerDebit, Amount: refund.Amount},
+	}
+	if refund.Amount == booking.Price {
+		entries[0].Amount = models.Money{
+			Amount:   booking.Price.Amount - booking.ServiceFee.Amount - booking.VAT.Amount,
+			Currency: booking.Price.Currency,
+		}
+		if booking.ServiceFee.IsPositive() {
+			entries = append(entries, models.LedgerEntry{
+				Account: models.LedgerAccountPlatformFees, Direction: models.LedgerDebit, Amount: booking.ServiceFee,
+			})
+		}
+		if booking.VAT.IsPositive() {
+			entries = append(entries, models.LedgerEntry{
+				Account: models.LedgerAccountVATPayable, Direction: models.LedgerDebit, Amount: booking.VAT,
+			})
+		}
+	}
+	entries = append(entries, models.LedgerEntry{
+		Account: models.LedgerAccountCustomer, Direction: models.LedgerCredit, Amount: refund.Amount,
+	})
+
+	return models.LedgerTransaction{
+		Type:        models.LedgerTransactionRefund,
+		ConcertID:   refund.ConcertID,
+		BookingID:   &refund.BookingID,
+		ReferenceID: &refund.ID,
+		Entries:     entries,
+	}
+}
+
+func refundPayoutTransaction(refund *models.Refund) models.LedgerTransaction {
+	return models.LedgerTransaction{
+		Type:        models.LedgerTransactionRefundPayout,
+		ConcertID:   refund.ConcertID,
+		BookingID:   &refund.BookingID,
+		ReferenceID: &refund.ID,
+		Entries: []models.LedgerEntry{
+			{Account: models.LedgerAccountCustomer, Direction: models.LedgerDebit, Amount: refund.Amount},
+			{Account: models.LedgerAccountPaymentProvider, Direction: models.LedgerCredit, Amount: refund.Amount},
+		},
+	}
+}
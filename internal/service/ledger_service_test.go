@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestLedgerService_ConcertReport(t *testing.T) {
+	concertID := uuid.New()
+
+	tests := []struct {
+		name         string
+		balances     []models.LedgerBalance
+		repoErr      error
+		wantBalanced bool
+		wantErr      bool
+	}{
+		{
+			name: "balanced",
+			balances: []models.LedgerBalance{
+				{Account: models.LedgerAccountPaymentProvider, Currency: "RUB", Debit: 500000, Balance: 500000},
+				{Account: models.LedgerAccountOrganizerRevenue, Currency: "RUB", Credit: 500000, Balance: -500000},
+			},
+			wantBalanced: true,
+		},
+		{
+			name: "unbalanced",
+			balances: []models.LedgerBalance{
+				{Account: models.LedgerAccountPaymentProvider, Currency: "RUB", Debit: 500000, Balance: 500000},
+				{Account: models.LedgerAccountOrganizerRevenue, Currency: "RUB", Credit: 300000, Balance: -300000},
+			},
+			wantBalanced: false,
+		},
+		{
+			name:         "empty ledger",
+			wantBalanced: true,
+		},
+		{
+			name:    "repository error",
+			repoErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			ledgerRepo.EXPECT().
+				ConcertBalances(gomock.Any(), concertID).
+				Return(tt.balances, tt.repoErr)
+
+			s := NewLedgerService(testLogger(), ledgerRepo)
+
+			got, err := s.ConcertReport(context.Background(), concertID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, concertID, got.ConcertID)
+			assert.Equal(t, tt.wantBalanced, got.Balanced)
+		})
+	}
+}
+
+func TestLedgerService_Check(t *testing.T) {
+	txID := uuid.New()
+
+	tests := []struct {
+		name         string
+		totals       []models.LedgerTotal
+		unbalanced   []uuid.UUID
+		wantBalanced bool
+	}{
+		{
+			name:         "balanced",
+			totals:       []models.LedgerTotal{{Currency: "RUB", Debit: 900000, Credit: 900000}},
+			wantBalanced: true,
+		},
+		{
+			name:         "totals differ",
+			totals:       []models.LedgerTotal{{Currency: "RUB", Debit: 900000, Credit: 800000}},
+			wantBalanced: false,
+		},
+		{
+			name:         "unbalanced transaction",
+			totals:       []models.LedgerTotal{{Currency: "RUB", Debit: 900000, Credit: 900000}},
+			unbalanced:   []uuid.UUID{txID},
+			wantBalanced: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			ledgerRepo.EXPECT().
+				Totals(gomock.Any()).
+				Return(tt.totals, nil)
+			ledgerRepo.EXPECT().
+				UnbalancedTransactions(gomock.Any()).
+				Return(tt.unbalanced, nil)
+
+			s := NewLedgerService(testLogger(), ledgerRepo)
+
+			got, err := s.Check(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBalanced, got.Balanced)
+			assert.Equal(t, tt.unbalanced, got.UnbalancedTransactions)
+		})
+	}
+}
+
+func TestLedgerTransaction_Validate(t *testing.T) {
+	refund := &models.Refund{
+		ID:        uuid.New(),
+		BookingID: uuid.New(),
+		ConcertID: uuid.New(),
+		Amount:    models.NewMoney(150000, "RUB"),
+	}
+	entry := func(
+		account models.LedgerAccount,
+		direction models.LedgerDirection,
+		amount models.Money,
+	) models.LedgerEntry {
+		return models.LedgerEntry{Account: account, Direction: direction, Amount: amount}
+	}
+
+	tests := []struct {
+		name    string
+		tx      models.LedgerTransaction
+		wantErr error
+	}{
+		{
+			name: "refund is balanced",
+			tx:   refundTransaction(refund, &models.Booking{Price: models.NewMoney(200000, "RUB")}),
+		},
+		{
+			name: "debits and credits differ",
+			tx: models.LedgerTransaction{
+				Type: models.LedgerTransactionSale,
+				Entries: []models.LedgerEntry{
+					entry(models.LedgerAccountCustomer, models.LedgerDebit, models.NewMoney(100, "RUB")),
+					entry(models.LedgerAccountOrganizerRevenue, models.LedgerCredit, models.NewMoney(90, "RUB")),
+				},
+			},
+			wantErr: models.ErrUnbalancedLedger,
+		},
+		{
+			name: "single entry",
+			tx: models.LedgerTransaction{
+				Type: models.LedgerTransactionSale,
+				Entries: []models.LedgerEntry{
+					entry(models.LedgerAccountCustomer, models.LedgerDebit, models.NewMoney(100, "RUB")),
+				},
+			},
+			wantErr: models.ErrUnbalancedLedger,
+		},
+		{
+			name: "mixed currencies",
+			tx: models.LedgerTransaction{
+				Type: models.LedgerTransactionSale,
+				Entries: []models.LedgerEntry{
+					entry(models.LedgerAccountCustomer, models.LedgerDebit, models.NewMoney(100, "RUB")),
+					entry(models.LedgerAccountOrganizerRevenue, models.LedgerCredit, models.NewMoney(100, "USD")),
+				},
+			},
+			wantErr: models.ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tx.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSaleTransactions(t *testing.T) {
+	booking := models.Booking{
+		ID:         uuid.New(),
+		ConcertID:  uuid.New(),
+		FaceValue:  models.NewMoney(200000, "RUB"),
+		Discount:   models.NewMoney(20000, "RUB"),
+		ServiceFee: models.NewMoney(36000, "RUB"),
+		VAT:        models.NewMoney(7200, "RUB"),
+		Price:      models.NewMoney(223200, "RUB"),
+	}
+	free := models.Booking{ID: uuid.New(), ConcertID: booking.ConcertID}
+
+	sales := saleTransactions([]models.Booking{booking, free})
+	require.Len(t, sales, 1)
+	assert.Equal(t, models.LedgerTransactionSale, sales[0].Type)
+	assert.Equal(t, &booking.ID, sales[0].BookingID)
+	assert.Equal(t, []models.LedgerEntry{
+		{Account: models.LedgerAccountCustomer, Direction: models.LedgerDebit, Amount: booking.Price},
+		{
+			Account:   models.LedgerAccountOrganizerRevenue,
+			Direction: models.LedgerCredit,
+			Amount:    models.NewMoney(180000, "RUB"),
+		},
+		{Account: models.LedgerAccountPlatformFees, Direction: models.LedgerCredit, Amount: booking.ServiceFee},
+		{Account: models.LedgerAccountVATPayable, Direction: models.LedgerCredit, Amount: booking.VAT},
+	}, sales[0].Entries)
+	require.NoError(t, sales[0].Validate())
+
+	reversals := saleReversalTransactions([]models.Booking{booking, free})
+	require.Len(t, reversals, 1)
+	assert.Equal(t, models.LedgerTransactionSaleReversal, reversals[0].Type)
+	require.NoError(t, reversals[0].Validate())
+	for i, e := range reversals[0].Entries {
+		assert.Equal(t, sales[0].Entries[i].Account, e.Account)
+		assert.Equal(t, sales[0].Entries[i].Amount, e.Amount)
+		assert.NotEqual(t, sales[0].Entries[i].Direction, e.Direction)
+	}
+}
+
+func TestRefundTransaction(t *testing.T) {
+	booking := &models.Booking{
+		ID:         uuid.New(),
+		ConcertID:  uuid.New(),
+		FaceValue:  models.NewMoney(200000, "RUB"),
+		Discount:   models.NewMoney(20000, "RUB"),
+		ServiceFee: models.NewMoney(18000, "RUB"),
+		VAT:        models.NewMoney(3600, "RUB"),
+		Price:      models.NewMoney(201600, "RUB"),
+	}
+	partial := models.NewMoney(100800, "RUB")
+	refund := func(amount int64) *models.Refund {
+		return &models.Refund{
+			ID:        uuid.New(),
+			BookingID: booking.ID,
+			ConcertID: booking.ConcertID,
+			Amount:    models.NewMoney(amount, "RUB"),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		refund *models.Refund
+		want   []models.LedgerEntry
+	}{
+		{
+			name:   "full refund reverses fee and vat",
+			refund: refund(201600),
+			want: []models.LedgerEntry{
+				{
+					Account:   models.LedgerAccountRefunds,
+					Direction: models.LedgerDebit,
+					Amount:    models.NewMoney(180000, "RUB"),
+				},
+				{Account: models.LedgerAccountPlatformFees, Direction: models.LedgerDebit, Amount: booking.ServiceFee},
+				{Account: models.LedgerAccountVATPayable, Direction: models.LedgerDebit, Amount: booking.VAT},
+				{Account: models.LedgerAccountCustomer, Direction: models.LedgerCredit, Amount: booking.Price},
+			},
+		},
+		{
+			name:   "partial refund charged to refunds",
+			refund: refund(100800),
+			want: []models.LedgerEntry{
+				{Account: models.LedgerAccountRefunds, Direction: models.LedgerDebit, Amount: partial},
+				{Account: models.LedgerAccountCustomer, Direction: models.LedgerCredit, Amount: partial},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := refundTransaction(tt.refund, booking)
+			assert.Equal(t, models.LedgerTransactionRefund, tx.Type)
+			assert.Equal(t, tt.want, tx.Entries)
+			assert.NoError(t, tx.Validate())
+		})
+	}
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/yohnnn/booking_service/internal/cache (interfaces: ConcertCacheRepository)
+// Source: github.com/yohnnn/booking_service/internal/cache (interfaces: ConcertCacheRepository,IdempotencyCacheRepository,TokenDenylistRepository,SeatMapRepository,WaitingRoomRepository)
 //
 // Generated by this command:
 //
-//	mockgen -destination=internal/service/mocks/mock_cache.go -package=mocks github.com/yohnnn/booking_service/internal/cache ConcertCacheRepository
+//	mockgen -destination=internal/service/mocks/mock_cache.go -package=mocks github.com/yohnnn/booking_service/internal/cache ConcertCacheRepository,IdempotencyCacheRepository,TokenDenylistRepository,SeatMapRepository,WaitingRoomRepository
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,7 +12,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	uuid "github.com/google/uuid"
+	cache "github.com/yohnnn/booking_service/internal/cache"
 	models "github.com/yohnnn/booking_service/internal/models"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -56,30 +59,423 @@ func (mr *MockConcertCacheRepositoryMockRecorder) Delete(ctx any) *gomock.Call {
 }
 
 // Get mocks base method.
-func (m *MockConcertCacheRepository) Get(ctx context.Context) ([]models.Concert, error) {
+func (m *MockConcertCacheRepository) Get(ctx context.Context, query string) (models.ConcertPage, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx)
-	ret0, _ := ret[0].([]models.Concert)
+	ret := m.ctrl.Call(m, "Get", ctx, query)
+	ret0, _ := ret[0].(models.ConcertPage)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockConcertCacheRepositoryMockRecorder) Get(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConcertCacheRepository)(nil).Get), ctx, query)
+}
+
+// Set mocks base method.
+func (m *MockConcertCacheRepository) Set(ctx context.Context, query string, page *models.ConcertPage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, query, page)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockConcertCacheRepositoryMockRecorder) Set(ctx, query, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockConcertCacheRepository)(nil).Set), ctx, query, page)
+}
+
+// MockIdempotencyCacheRepository is a mock of IdempotencyCacheRepository interface.
+type MockIdempotencyCacheRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyCacheRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyCacheRepositoryMockRecorder is the mock recorder for MockIdempotencyCacheRepository.
+type MockIdempotencyCacheRepositoryMockRecorder struct {
+	mock *MockIdempotencyCacheRepository
+}
+
+// NewMockIdempotencyCacheRepository creates a new mock instance.
+func NewMockIdempotencyCacheRepository(ctrl *gomock.Controller) *MockIdempotencyCacheRepository {
+	mock := &MockIdempotencyCacheRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyCacheRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyCacheRepository) EXPECT() *MockIdempotencyCacheRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyCacheRepository) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyCacheRepositoryMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyCacheRepository)(nil).Delete), ctx, key)
+}
+
+// Extend mocks base method.
+func (m *MockIdempotencyCacheRepository) Extend(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockIdempotencyCacheRepositoryMockRecorder) Extend(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockIdempotencyCacheRepository)(nil).Extend), ctx, key)
+}
+
+// Reserve mocks base method.
+func (m *MockIdempotencyCacheRepository) Reserve(ctx context.Context, key, requestHash string) (cache.IdempotencyRecord, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, key, requestHash)
+	ret0, _ := ret[0].(cache.IdempotencyRecord)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIdempotencyCacheRepositoryMockRecorder) Reserve(ctx, key, requestHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIdempotencyCacheRepository)(nil).Reserve), ctx, key, requestHash)
+}
+
+// Save mocks base method.
+func (m *MockIdempotencyCacheRepository) Save(ctx context.Context, key string, record *cache.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, key, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIdempotencyCacheRepositoryMockRecorder) Save(ctx, key, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIdempotencyCacheRepository)(nil).Save), ctx, key, record)
+}
+
+// MockTokenDenylistRepository is a mock of TokenDenylistRepository interface.
+type MockTokenDenylistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenDenylistRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenDenylistRepositoryMockRecorder is the mock recorder for MockTokenDenylistRepository.
+type MockTokenDenylistRepositoryMockRecorder struct {
+	mock *MockTokenDenylistRepository
+}
+
+// NewMockTokenDenylistRepository creates a new mock instance.
+func NewMockTokenDenylistRepository(ctrl *gomock.Controller) *MockTokenDenylistRepository {
+	mock := &MockTokenDenylistRepository{ctrl: ctrl}
+	mock.recorder = &MockTokenDenylistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenDenylistRepository) EXPECT() *MockTokenDenylistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockTokenDenylistRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockTokenDenylistRepositoryMockRecorder) IsRevoked(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockTokenDenylistRepository)(nil).IsRevoked), ctx, tokenID)
+}
+
+// Revoke mocks base method.
+func (m *MockTokenDenylistRepository) Revoke(ctx context.Context, tokenID string, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tokenID, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockTokenDenylistRepositoryMockRecorder) Revoke(ctx, tokenID, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockTokenDenylistRepository)(nil).Revoke), ctx, tokenID, ttl)
+}
+
+// MockSeatMapRepository is a mock of SeatMapRepository interface.
+type MockSeatMapRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeatMapRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSeatMapRepositoryMockRecorder is the mock recorder for MockSeatMapRepository.
+type MockSeatMapRepositoryMockRecorder struct {
+	mock *MockSeatMapRepository
+}
+
+// NewMockSeatMapRepository creates a new mock instance.
+func NewMockSeatMapRepository(ctrl *gomock.Controller) *MockSeatMapRepository {
+	mock := &MockSeatMapRepository{ctrl: ctrl}
+	mock.recorder = &MockSeatMapRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeatMapRepository) EXPECT() *MockSeatMapRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSeatMapRepository) Delete(ctx context.Context, concertID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, concertID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeatMapRepositoryMockRecorder) Delete(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeatMapRepository)(nil).Delete), ctx, concertID)
+}
+
+// Get mocks base method.
+func (m *MockSeatMapRepository) Get(ctx context.Context, concertID uuid.UUID) (models.SeatMap, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, concertID)
+	ret0, _ := ret[0].(models.SeatMap)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Get indicates an expected call of Get.
-func (mr *MockConcertCacheRepositoryMockRecorder) Get(ctx any) *gomock.Call {
+func (mr *MockSeatMapRepositoryMockRecorder) Get(ctx, concertID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConcertCacheRepository)(nil).Get), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSeatMapRepository)(nil).Get), ctx, concertID)
 }
 
 // Set mocks base method.
-func (m *MockConcertCacheRepository) Set(ctx context.Context, concerts []models.Concert) error {
+func (m *MockSeatMapRepository) Set(ctx context.Context, concertID uuid.UUID, seats models.SeatMap, version int64) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Set", ctx, concerts)
+	ret := m.ctrl.Call(m, "Set", ctx, concertID, seats, version)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockSeatMapRepositoryMockRecorder) Set(ctx, concertID, seats, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockSeatMapRepository)(nil).Set), ctx, concertID, seats, version)
+}
+
+// SetStates mocks base method.
+func (m *MockSeatMapRepository) SetStates(ctx context.Context, concertID uuid.UUID, seats []int, state models.SeatState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStates", ctx, concertID, seats, state)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Set indicates an expected call of Set.
-func (mr *MockConcertCacheRepositoryMockRecorder) Set(ctx, concerts any) *gomock.Call {
+// SetStates indicates an expected call of SetStates.
+func (mr *MockSeatMapRepositoryMockRecorder) SetStates(ctx, concertID, seats, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStates", reflect.TypeOf((*MockSeatMapRepository)(nil).SetStates), ctx, concertID, seats, state)
+}
+
+// Version mocks base method.
+func (m *MockSeatMapRepository) Version(ctx context.Context, concertID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", ctx, concertID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockSeatMapRepositoryMockRecorder) Version(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockSeatMapRepository)(nil).Version), ctx, concertID)
+}
+
+// MockWaitingRoomRepository is a mock of WaitingRoomRepository interface.
+type MockWaitingRoomRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWaitingRoomRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWaitingRoomRepositoryMockRecorder is the mock recorder for MockWaitingRoomRepository.
+type MockWaitingRoomRepositoryMockRecorder struct {
+	mock *MockWaitingRoomRepository
+}
+
+// NewMockWaitingRoomRepository creates a new mock instance.
+func NewMockWaitingRoomRepository(ctrl *gomock.Controller) *MockWaitingRoomRepository {
+	mock := &MockWaitingRoomRepository{ctrl: ctrl}
+	mock.recorder = &MockWaitingRoomRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWaitingRoomRepository) EXPECT() *MockWaitingRoomRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Active mocks base method.
+func (m *MockWaitingRoomRepository) Active(ctx context.Context) ([]models.WaitingRoom, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Active", ctx)
+	ret0, _ := ret[0].([]models.WaitingRoom)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Active indicates an expected call of Active.
+func (mr *MockWaitingRoomRepositoryMockRecorder) Active(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Active", reflect.TypeOf((*MockWaitingRoomRepository)(nil).Active), ctx)
+}
+
+// Admit mocks base method.
+func (m *MockWaitingRoomRepository) Admit(ctx context.Context, concertID uuid.UUID, count int, expiresAt, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Admit", ctx, concertID, count, expiresAt, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Admit indicates an expected call of Admit.
+func (mr *MockWaitingRoomRepositoryMockRecorder) Admit(ctx, concertID, count, expiresAt, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Admit", reflect.TypeOf((*MockWaitingRoomRepository)(nil).Admit), ctx, concertID, count, expiresAt, now)
+}
+
+// Close mocks base method.
+func (m *MockWaitingRoomRepository) Close(ctx context.Context, concertID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, concertID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockWaitingRoomRepositoryMockRecorder) Close(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockWaitingRoomRepository)(nil).Close), ctx, concertID)
+}
+
+// Consume mocks base method.
+func (m *MockWaitingRoomRepository) Consume(ctx context.Context, concertID, userID uuid.UUID, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, concertID, userID, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockWaitingRoomRepositoryMockRecorder) Consume(ctx, concertID, userID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockWaitingRoomRepository)(nil).Consume), ctx, concertID, userID, token)
+}
+
+// IsActive mocks base method.
+func (m *MockWaitingRoomRepository) IsActive(ctx context.Context, concertID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsActive", ctx, concertID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsActive indicates an expected call of IsActive.
+func (mr *MockWaitingRoomRepositoryMockRecorder) IsActive(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MockWaitingRoomRepository)(nil).IsActive), ctx, concertID)
+}
+
+// IsAdmitted mocks base method.
+func (m *MockWaitingRoomRepository) IsAdmitted(ctx context.Context, concertID, userID uuid.UUID, token string, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdmitted", ctx, concertID, userID, token, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAdmitted indicates an expected call of IsAdmitted.
+func (mr *MockWaitingRoomRepositoryMockRecorder) IsAdmitted(ctx, concertID, userID, token, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmitted", reflect.TypeOf((*MockWaitingRoomRepository)(nil).IsAdmitted), ctx, concertID, userID, token, now)
+}
+
+// Join mocks base method.
+func (m *MockWaitingRoomRepository) Join(ctx context.Context, concertID, userID uuid.UUID, token string, now time.Time) (models.QueueTicket, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Join", ctx, concertID, userID, token, now)
+	ret0, _ := ret[0].(models.QueueTicket)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Join indicates an expected call of Join.
+func (mr *MockWaitingRoomRepositoryMockRecorder) Join(ctx, concertID, userID, token, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Join", reflect.TypeOf((*MockWaitingRoomRepository)(nil).Join), ctx, concertID, userID, token, now)
+}
+
+// Open mocks base method.
+func (m *MockWaitingRoomRepository) Open(ctx context.Context, room models.WaitingRoom) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, room)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockWaitingRoomRepositoryMockRecorder) Open(ctx, room any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockWaitingRoomRepository)(nil).Open), ctx, room)
+}
+
+// Status mocks base method.
+func (m *MockWaitingRoomRepository) Status(ctx context.Context, concertID, userID uuid.UUID, now time.Time) (models.QueueTicket, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx, concertID, userID, now)
+	ret0, _ := ret[0].(models.QueueTicket)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockWaitingRoomRepositoryMockRecorder) Status(ctx, concertID, userID, now any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockConcertCacheRepository)(nil).Set), ctx, concerts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockWaitingRoomRepository)(nil).Status), ctx, concertID, userID, now)
 }
@@ -13,7 +13,6 @@ import (
 	context "context"
 	reflect "reflect"
 
-	event "github.com/yohnnn/booking_service/internal/event"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -55,16 +54,16 @@ func (mr *MockEventProducerMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockEventProducer)(nil).Close))
 }
 
-// SendBookingCreated mocks base method.
-func (m *MockEventProducer) SendBookingCreated(ctx context.Context, arg1 event.BookingCreatedEvent) error {
+// Publish mocks base method.
+func (m *MockEventProducer) Publish(ctx context.Context, eventType, key string, payload []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendBookingCreated", ctx, arg1)
+	ret := m.ctrl.Call(m, "Publish", ctx, eventType, key, payload)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendBookingCreated indicates an expected call of SendBookingCreated.
-func (mr *MockEventProducerMockRecorder) SendBookingCreated(ctx, arg1 any) *gomock.Call {
+// Publish indicates an expected call of Publish.
+func (mr *MockEventProducerMockRecorder) Publish(ctx, eventType, key, payload any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBookingCreated", reflect.TypeOf((*MockEventProducer)(nil).SendBookingCreated), ctx, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventProducer)(nil).Publish), ctx, eventType, key, payload)
 }
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/yohnnn/booking_service/internal/payment (interfaces: PaymentProvider)
+//
+// Generated by this command:
+//
+//	mockgen -destination=internal/service/mocks/mock_payment.go -package=mocks github.com/yohnnn/booking_service/internal/payment PaymentProvider
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	payment "github.com/yohnnn/booking_service/internal/payment"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentProvider is a mock of PaymentProvider interface.
+type MockPaymentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentProviderMockRecorder is the mock recorder for MockPaymentProvider.
+type MockPaymentProviderMockRecorder struct {
+	mock *MockPaymentProvider
+}
+
+// NewMockPaymentProvider creates a new mock instance.
+func NewMockPaymentProvider(ctrl *gomock.Controller) *MockPaymentProvider {
+	mock := &MockPaymentProvider{ctrl: ctrl}
+	mock.recorder = &MockPaymentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentProvider) EXPECT() *MockPaymentProviderMockRecorder {
+	return m.recorder
+}
+
+// CreateIntent mocks base method.
+func (m *MockPaymentProvider) CreateIntent(ctx context.Context, req payment.IntentRequest) (*payment.Intent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIntent", ctx, req)
+	ret0, _ := ret[0].(*payment.Intent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIntent indicates an expected call of CreateIntent.
+func (mr *MockPaymentProviderMockRecorder) CreateIntent(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIntent", reflect.TypeOf((*MockPaymentProvider)(nil).CreateIntent), ctx, req)
+}
+
+// ParseWebhook mocks base method.
+func (m *MockPaymentProvider) ParseWebhook(payload []byte, headers http.Header) (*payment.WebhookEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseWebhook", payload, headers)
+	ret0, _ := ret[0].(*payment.WebhookEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseWebhook indicates an expected call of ParseWebhook.
+func (mr *MockPaymentProviderMockRecorder) ParseWebhook(payload, headers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseWebhook", reflect.TypeOf((*MockPaymentProvider)(nil).ParseWebhook), payload, headers)
+}
+
+// Refund mocks base method.
+func (m *MockPaymentProvider) Refund(ctx context.Context, req payment.RefundRequest) (*payment.RefundResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, req)
+	ret0, _ := ret[0].(*payment.RefundResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentProviderMockRecorder) Refund(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentProvider)(nil).Refund), ctx, req)
+}
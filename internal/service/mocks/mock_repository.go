@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/yohnnn/booking_service/internal/repository (interfaces: UserRepository,ConcertRepository,BookingRepository)
+// Source: github.com/yohnnn/booking_service/internal/repository (interfaces: UserRepository,ConcertRepository,BookingRepository,OutboxRepository,RefreshTokenRepository,VenueRepository,TicketTierRepository,PresaleRepository,CancellationRepository,RefundRepository,WaitlistRepository,PaymentRepository,LedgerRepository,PromoCodeRepository)
 //
 // Generated by this command:
 //
-//	mockgen -destination=internal/service/mocks/mock_repository.go -package=mocks github.com/yohnnn/booking_service/internal/repository UserRepository,ConcertRepository,BookingRepository
+//	mockgen -destination=internal/service/mocks/mock_repository.go -package=mocks github.com/yohnnn/booking_service/internal/repository UserRepository,ConcertRepository,BookingRepository,OutboxRepository,RefreshTokenRepository,VenueRepository,TicketTierRepository,PresaleRepository,CancellationRepository,RefundRepository,WaitlistRepository,PaymentRepository,LedgerRepository,PromoCodeRepository
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	models "github.com/yohnnn/booking_service/internal/models"
@@ -86,6 +87,20 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, id)
 }
 
+// UpdateRole mocks base method.
+func (m *MockUserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockUserRepositoryMockRecorder) UpdateRole(ctx, id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockUserRepository)(nil).UpdateRole), ctx, id, role)
+}
+
 // MockConcertRepository is a mock of ConcertRepository interface.
 type MockConcertRepository struct {
 	ctrl     *gomock.Controller
@@ -110,33 +125,61 @@ func (m *MockConcertRepository) EXPECT() *MockConcertRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CompletePast mocks base method.
+func (m *MockConcertRepository) CompletePast(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletePast", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompletePast indicates an expected call of CompletePast.
+func (mr *MockConcertRepositoryMockRecorder) CompletePast(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletePast", reflect.TypeOf((*MockConcertRepository)(nil).CompletePast), ctx, limit)
+}
+
+// Create mocks base method.
+func (m *MockConcertRepository) Create(ctx context.Context, concert *models.Concert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, concert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConcertRepositoryMockRecorder) Create(ctx, concert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConcertRepository)(nil).Create), ctx, concert)
+}
+
 // DecrementSeats mocks base method.
-func (m *MockConcertRepository) DecrementSeats(ctx context.Context, id uuid.UUID) error {
+func (m *MockConcertRepository) DecrementSeats(ctx context.Context, id uuid.UUID, count int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DecrementSeats", ctx, id)
+	ret := m.ctrl.Call(m, "DecrementSeats", ctx, id, count)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DecrementSeats indicates an expected call of DecrementSeats.
-func (mr *MockConcertRepositoryMockRecorder) DecrementSeats(ctx, id any) *gomock.Call {
+func (mr *MockConcertRepositoryMockRecorder) DecrementSeats(ctx, id, count any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementSeats", reflect.TypeOf((*MockConcertRepository)(nil).DecrementSeats), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementSeats", reflect.TypeOf((*MockConcertRepository)(nil).DecrementSeats), ctx, id, count)
 }
 
-// GetAll mocks base method.
-func (m *MockConcertRepository) GetAll(ctx context.Context) ([]models.Concert, error) {
+// Delete mocks base method.
+func (m *MockConcertRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
-	ret0, _ := ret[0].([]models.Concert)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAll indicates an expected call of GetAll.
-func (mr *MockConcertRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockConcertRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockConcertRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockConcertRepository)(nil).Delete), ctx, id)
 }
 
 // GetByID mocks base method.
@@ -154,6 +197,93 @@ func (mr *MockConcertRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockConcertRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDForUpdate mocks base method.
+func (m *MockConcertRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Concert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*models.Concert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockConcertRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockConcertRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// HasBookings mocks base method.
+func (m *MockConcertRepository) HasBookings(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasBookings", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasBookings indicates an expected call of HasBookings.
+func (mr *MockConcertRepositoryMockRecorder) HasBookings(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasBookings", reflect.TypeOf((*MockConcertRepository)(nil).HasBookings), ctx, id)
+}
+
+// IncrementSeats mocks base method.
+func (m *MockConcertRepository) IncrementSeats(ctx context.Context, id uuid.UUID, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementSeats", ctx, id, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementSeats indicates an expected call of IncrementSeats.
+func (mr *MockConcertRepositoryMockRecorder) IncrementSeats(ctx, id, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementSeats", reflect.TypeOf((*MockConcertRepository)(nil).IncrementSeats), ctx, id, count)
+}
+
+// List mocks base method.
+func (m *MockConcertRepository) List(ctx context.Context, filter models.ConcertFilter, after *models.ConcertCursor, limit int) ([]models.Concert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, after, limit)
+	ret0, _ := ret[0].([]models.Concert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockConcertRepositoryMockRecorder) List(ctx, filter, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockConcertRepository)(nil).List), ctx, filter, after, limit)
+}
+
+// Update mocks base method.
+func (m *MockConcertRepository) Update(ctx context.Context, concert *models.Concert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, concert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockConcertRepositoryMockRecorder) Update(ctx, concert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockConcertRepository)(nil).Update), ctx, concert)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockConcertRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.ConcertStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockConcertRepositoryMockRecorder) UpdateStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockConcertRepository)(nil).UpdateStatus), ctx, id, from, to)
+}
+
 // MockBookingRepository is a mock of BookingRepository interface.
 type MockBookingRepository struct {
 	ctrl     *gomock.Controller
@@ -178,6 +308,49 @@ func (m *MockBookingRepository) EXPECT() *MockBookingRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockBookingRepository) Cancel(ctx context.Context, id uuid.UUID, from models.BookingStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockBookingRepositoryMockRecorder) Cancel(ctx, id, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockBookingRepository)(nil).Cancel), ctx, id, from)
+}
+
+// CancelActiveByConcert mocks base method.
+func (m *MockBookingRepository) CancelActiveByConcert(ctx context.Context, concertID uuid.UUID, userLimit int) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelActiveByConcert", ctx, concertID, userLimit)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelActiveByConcert indicates an expected call of CancelActiveByConcert.
+func (mr *MockBookingRepositoryMockRecorder) CancelActiveByConcert(ctx, concertID, userLimit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelActiveByConcert", reflect.TypeOf((*MockBookingRepository)(nil).CancelActiveByConcert), ctx, concertID, userLimit)
+}
+
+// Confirm mocks base method.
+func (m *MockBookingRepository) Confirm(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockBookingRepositoryMockRecorder) Confirm(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockBookingRepository)(nil).Confirm), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockBookingRepository) Create(ctx context.Context, booking *models.Booking) error {
 	m.ctrl.T.Helper()
@@ -192,6 +365,51 @@ func (mr *MockBookingRepositoryMockRecorder) Create(ctx, booking any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookingRepository)(nil).Create), ctx, booking)
 }
 
+// ExpireHolds mocks base method.
+func (m *MockBookingRepository) ExpireHolds(ctx context.Context, limit int) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx, limit)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockBookingRepositoryMockRecorder) ExpireHolds(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockBookingRepository)(nil).ExpireHolds), ctx, limit)
+}
+
+// GetActiveByConcertID mocks base method.
+func (m *MockBookingRepository) GetActiveByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByConcertID", ctx, concertID)
+	ret0, _ := ret[0].([]models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByConcertID indicates an expected call of GetActiveByConcertID.
+func (mr *MockBookingRepositoryMockRecorder) GetActiveByConcertID(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByConcertID", reflect.TypeOf((*MockBookingRepository)(nil).GetActiveByConcertID), ctx, concertID)
+}
+
+// GetByID mocks base method.
+func (m *MockBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBookingRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBookingRepository)(nil).GetByID), ctx, id)
+}
+
 // GetByUserID mocks base method.
 func (m *MockBookingRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.Booking, error) {
 	m.ctrl.T.Helper()
@@ -206,3 +424,1224 @@ func (mr *MockBookingRepositoryMockRecorder) GetByUserID(ctx, userID any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockBookingRepository)(nil).GetByUserID), ctx, userID)
 }
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockOutboxRepository) Claim(ctx context.Context, limit int, leaseUntil time.Time) ([]models.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit, leaseUntil)
+	ret0, _ := ret[0].([]models.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockOutboxRepositoryMockRecorder) Claim(ctx, limit, leaseUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockOutboxRepository)(nil).Claim), ctx, limit, leaseUntil)
+}
+
+// Create mocks base method.
+func (m *MockOutboxRepository) Create(ctx context.Context, msg *models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxRepositoryMockRecorder) Create(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutboxRepository)(nil).Create), ctx, msg)
+}
+
+// MarkFailed mocks base method.
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string, nextAttemptAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, reason, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkFailed(ctx, id, reason, nextAttemptAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkFailed), ctx, id, reason, nextAttemptAt)
+}
+
+// MarkSent mocks base method.
+func (m *MockOutboxRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockOutboxRepositoryMockRecorder) MarkSent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockOutboxRepository)(nil).MarkSent), ctx, id)
+}
+
+// MockRefreshTokenRepository is a mock of RefreshTokenRepository interface.
+type MockRefreshTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenRepositoryMockRecorder is the mock recorder for MockRefreshTokenRepository.
+type MockRefreshTokenRepositoryMockRecorder struct {
+	mock *MockRefreshTokenRepository
+}
+
+// NewMockRefreshTokenRepository creates a new mock instance.
+func NewMockRefreshTokenRepository(ctrl *gomock.Controller) *MockRefreshTokenRepository {
+	mock := &MockRefreshTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenRepository) EXPECT() *MockRefreshTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenRepository)(nil).Create), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockRefreshTokenRepositoryMockRecorder) GetByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockRefreshTokenRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockRefreshTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockRefreshTokenRepositoryMockRecorder) MarkUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockRefreshTokenRepository)(nil).MarkUsed), ctx, id)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeFamily), ctx, familyID)
+}
+
+// MockVenueRepository is a mock of VenueRepository interface.
+type MockVenueRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVenueRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVenueRepositoryMockRecorder is the mock recorder for MockVenueRepository.
+type MockVenueRepositoryMockRecorder struct {
+	mock *MockVenueRepository
+}
+
+// NewMockVenueRepository creates a new mock instance.
+func NewMockVenueRepository(ctrl *gomock.Controller) *MockVenueRepository {
+	mock := &MockVenueRepository{ctrl: ctrl}
+	mock.recorder = &MockVenueRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVenueRepository) EXPECT() *MockVenueRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVenueRepository) Create(ctx context.Context, venue *models.Venue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, venue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVenueRepositoryMockRecorder) Create(ctx, venue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVenueRepository)(nil).Create), ctx, venue)
+}
+
+// CreateSection mocks base method.
+func (m *MockVenueRepository) CreateSection(ctx context.Context, section *models.VenueSection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSection", ctx, section)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSection indicates an expected call of CreateSection.
+func (mr *MockVenueRepositoryMockRecorder) CreateSection(ctx, section any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSection", reflect.TypeOf((*MockVenueRepository)(nil).CreateSection), ctx, section)
+}
+
+// GetAll mocks base method.
+func (m *MockVenueRepository) GetAll(ctx context.Context) ([]models.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockVenueRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockVenueRepository)(nil).GetAll), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockVenueRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVenueRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVenueRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockVenueRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*models.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockVenueRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockVenueRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// GetLayout mocks base method.
+func (m *MockVenueRepository) GetLayout(ctx context.Context, venueID uuid.UUID) ([]models.VenueSection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayout", ctx, venueID)
+	ret0, _ := ret[0].([]models.VenueSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayout indicates an expected call of GetLayout.
+func (mr *MockVenueRepositoryMockRecorder) GetLayout(ctx, venueID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayout", reflect.TypeOf((*MockVenueRepository)(nil).GetLayout), ctx, venueID)
+}
+
+// MockTicketTierRepository is a mock of TicketTierRepository interface.
+type MockTicketTierRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTicketTierRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTicketTierRepositoryMockRecorder is the mock recorder for MockTicketTierRepository.
+type MockTicketTierRepositoryMockRecorder struct {
+	mock *MockTicketTierRepository
+}
+
+// NewMockTicketTierRepository creates a new mock instance.
+func NewMockTicketTierRepository(ctrl *gomock.Controller) *MockTicketTierRepository {
+	mock := &MockTicketTierRepository{ctrl: ctrl}
+	mock.recorder = &MockTicketTierRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTicketTierRepository) EXPECT() *MockTicketTierRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountSectionSeats mocks base method.
+func (m *MockTicketTierRepository) CountSectionSeats(ctx context.Context, venueID uuid.UUID, sectionIDs []uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSectionSeats", ctx, venueID, sectionIDs)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSectionSeats indicates an expected call of CountSectionSeats.
+func (mr *MockTicketTierRepositoryMockRecorder) CountSectionSeats(ctx, venueID, sectionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSectionSeats", reflect.TypeOf((*MockTicketTierRepository)(nil).CountSectionSeats), ctx, venueID, sectionIDs)
+}
+
+// Create mocks base method.
+func (m *MockTicketTierRepository) Create(ctx context.Context, tier *models.TicketTier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, tier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTicketTierRepositoryMockRecorder) Create(ctx, tier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTicketTierRepository)(nil).Create), ctx, tier)
+}
+
+// Decrement mocks base method.
+func (m *MockTicketTierRepository) Decrement(ctx context.Context, id uuid.UUID, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrement", ctx, id, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decrement indicates an expected call of Decrement.
+func (mr *MockTicketTierRepositoryMockRecorder) Decrement(ctx, id, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrement", reflect.TypeOf((*MockTicketTierRepository)(nil).Decrement), ctx, id, count)
+}
+
+// DeleteByConcertID mocks base method.
+func (m *MockTicketTierRepository) DeleteByConcertID(ctx context.Context, concertID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByConcertID", ctx, concertID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByConcertID indicates an expected call of DeleteByConcertID.
+func (mr *MockTicketTierRepositoryMockRecorder) DeleteByConcertID(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByConcertID", reflect.TypeOf((*MockTicketTierRepository)(nil).DeleteByConcertID), ctx, concertID)
+}
+
+// FindSeatCandidates mocks base method.
+func (m *MockTicketTierRepository) FindSeatCandidates(ctx context.Context, concertID uuid.UUID, tierID *uuid.UUID, limit int) ([]models.SeatCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSeatCandidates", ctx, concertID, tierID, limit)
+	ret0, _ := ret[0].([]models.SeatCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSeatCandidates indicates an expected call of FindSeatCandidates.
+func (mr *MockTicketTierRepositoryMockRecorder) FindSeatCandidates(ctx, concertID, tierID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSeatCandidates", reflect.TypeOf((*MockTicketTierRepository)(nil).FindSeatCandidates), ctx, concertID, tierID, limit)
+}
+
+// GetByConcertID mocks base method.
+func (m *MockTicketTierRepository) GetByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.TicketTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConcertID", ctx, concertID)
+	ret0, _ := ret[0].([]models.TicketTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConcertID indicates an expected call of GetByConcertID.
+func (mr *MockTicketTierRepositoryMockRecorder) GetByConcertID(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConcertID", reflect.TypeOf((*MockTicketTierRepository)(nil).GetByConcertID), ctx, concertID)
+}
+
+// GetByID mocks base method.
+func (m *MockTicketTierRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.TicketTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.TicketTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTicketTierRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTicketTierRepository)(nil).GetByID), ctx, id)
+}
+
+// Increment mocks base method.
+func (m *MockTicketTierRepository) Increment(ctx context.Context, id uuid.UUID, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, id, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockTicketTierRepositoryMockRecorder) Increment(ctx, id, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockTicketTierRepository)(nil).Increment), ctx, id, count)
+}
+
+// LockFreeSeats mocks base method.
+func (m *MockTicketTierRepository) LockFreeSeats(ctx context.Context, concertID uuid.UUID, seatIDs []uuid.UUID) ([]models.SeatAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockFreeSeats", ctx, concertID, seatIDs)
+	ret0, _ := ret[0].([]models.SeatAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockFreeSeats indicates an expected call of LockFreeSeats.
+func (mr *MockTicketTierRepositoryMockRecorder) LockFreeSeats(ctx, concertID, seatIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockFreeSeats", reflect.TypeOf((*MockTicketTierRepository)(nil).LockFreeSeats), ctx, concertID, seatIDs)
+}
+
+// ResolveSeatIDs mocks base method.
+func (m *MockTicketTierRepository) ResolveSeatIDs(ctx context.Context, concertID uuid.UUID, seatIDs []uuid.UUID) ([]models.SeatAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveSeatIDs", ctx, concertID, seatIDs)
+	ret0, _ := ret[0].([]models.SeatAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveSeatIDs indicates an expected call of ResolveSeatIDs.
+func (mr *MockTicketTierRepositoryMockRecorder) ResolveSeatIDs(ctx, concertID, seatIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveSeatIDs", reflect.TypeOf((*MockTicketTierRepository)(nil).ResolveSeatIDs), ctx, concertID, seatIDs)
+}
+
+// ResolveSeats mocks base method.
+func (m *MockTicketTierRepository) ResolveSeats(ctx context.Context, concertID uuid.UUID, seats []int) ([]models.SeatAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveSeats", ctx, concertID, seats)
+	ret0, _ := ret[0].([]models.SeatAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveSeats indicates an expected call of ResolveSeats.
+func (mr *MockTicketTierRepositoryMockRecorder) ResolveSeats(ctx, concertID, seats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveSeats", reflect.TypeOf((*MockTicketTierRepository)(nil).ResolveSeats), ctx, concertID, seats)
+}
+
+// MockPresaleRepository is a mock of PresaleRepository interface.
+type MockPresaleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresaleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPresaleRepositoryMockRecorder is the mock recorder for MockPresaleRepository.
+type MockPresaleRepositoryMockRecorder struct {
+	mock *MockPresaleRepository
+}
+
+// NewMockPresaleRepository creates a new mock instance.
+func NewMockPresaleRepository(ctrl *gomock.Controller) *MockPresaleRepository {
+	mock := &MockPresaleRepository{ctrl: ctrl}
+	mock.recorder = &MockPresaleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresaleRepository) EXPECT() *MockPresaleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPresaleRepository) Create(ctx context.Context, presale *models.Presale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, presale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPresaleRepositoryMockRecorder) Create(ctx, presale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPresaleRepository)(nil).Create), ctx, presale)
+}
+
+// GetByConcertID mocks base method.
+func (m *MockPresaleRepository) GetByConcertID(ctx context.Context, concertID uuid.UUID) ([]models.Presale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConcertID", ctx, concertID)
+	ret0, _ := ret[0].([]models.Presale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConcertID indicates an expected call of GetByConcertID.
+func (mr *MockPresaleRepositoryMockRecorder) GetByConcertID(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConcertID", reflect.TypeOf((*MockPresaleRepository)(nil).GetByConcertID), ctx, concertID)
+}
+
+// MockCancellationRepository is a mock of CancellationRepository interface.
+type MockCancellationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCancellationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCancellationRepositoryMockRecorder is the mock recorder for MockCancellationRepository.
+type MockCancellationRepositoryMockRecorder struct {
+	mock *MockCancellationRepository
+}
+
+// NewMockCancellationRepository creates a new mock instance.
+func NewMockCancellationRepository(ctrl *gomock.Controller) *MockCancellationRepository {
+	mock := &MockCancellationRepository{ctrl: ctrl}
+	mock.recorder = &MockCancellationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCancellationRepository) EXPECT() *MockCancellationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockCancellationRepository) Complete(ctx context.Context, concertID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, concertID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockCancellationRepositoryMockRecorder) Complete(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockCancellationRepository)(nil).Complete), ctx, concertID)
+}
+
+// Create mocks base method.
+func (m *MockCancellationRepository) Create(ctx context.Context, cancellation *models.ConcertCancellation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, cancellation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCancellationRepositoryMockRecorder) Create(ctx, cancellation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCancellationRepository)(nil).Create), ctx, cancellation)
+}
+
+// GetByConcertID mocks base method.
+func (m *MockCancellationRepository) GetByConcertID(ctx context.Context, concertID uuid.UUID) (*models.ConcertCancellation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConcertID", ctx, concertID)
+	ret0, _ := ret[0].(*models.ConcertCancellation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConcertID indicates an expected call of GetByConcertID.
+func (mr *MockCancellationRepositoryMockRecorder) GetByConcertID(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConcertID", reflect.TypeOf((*MockCancellationRepository)(nil).GetByConcertID), ctx, concertID)
+}
+
+// NextRunning mocks base method.
+func (m *MockCancellationRepository) NextRunning(ctx context.Context) (*models.ConcertCancellation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextRunning", ctx)
+	ret0, _ := ret[0].(*models.ConcertCancellation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextRunning indicates an expected call of NextRunning.
+func (mr *MockCancellationRepositoryMockRecorder) NextRunning(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextRunning", reflect.TypeOf((*MockCancellationRepository)(nil).NextRunning), ctx)
+}
+
+// RecordProgress mocks base method.
+func (m *MockCancellationRepository) RecordProgress(ctx context.Context, concertID uuid.UUID, bookings, refunds, users int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordProgress", ctx, concertID, bookings, refunds, users)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordProgress indicates an expected call of RecordProgress.
+func (mr *MockCancellationRepositoryMockRecorder) RecordProgress(ctx, concertID, bookings, refunds, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordProgress", reflect.TypeOf((*MockCancellationRepository)(nil).RecordProgress), ctx, concertID, bookings, refunds, users)
+}
+
+// MockRefundRepository is a mock of RefundRepository interface.
+type MockRefundRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRefundRepositoryMockRecorder is the mock recorder for MockRefundRepository.
+type MockRefundRepositoryMockRecorder struct {
+	mock *MockRefundRepository
+}
+
+// NewMockRefundRepository creates a new mock instance.
+func NewMockRefundRepository(ctrl *gomock.Controller) *MockRefundRepository {
+	mock := &MockRefundRepository{ctrl: ctrl}
+	mock.recorder = &MockRefundRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundRepository) EXPECT() *MockRefundRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockRefundRepository) Claim(ctx context.Context, limit int, staleBefore time.Time) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit, staleBefore)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockRefundRepositoryMockRecorder) Claim(ctx, limit, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockRefundRepository)(nil).Claim), ctx, limit, staleBefore)
+}
+
+// Create mocks base method.
+func (m *MockRefundRepository) Create(ctx context.Context, refund *models.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, refund)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefundRepositoryMockRecorder) Create(ctx, refund any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefundRepository)(nil).Create), ctx, refund)
+}
+
+// CreateBatch mocks base method.
+func (m *MockRefundRepository) CreateBatch(ctx context.Context, refunds []models.Refund) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, refunds)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockRefundRepositoryMockRecorder) CreateBatch(ctx, refunds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockRefundRepository)(nil).CreateBatch), ctx, refunds)
+}
+
+// Finish mocks base method.
+func (m *MockRefundRepository) Finish(ctx context.Context, id uuid.UUID, status models.RefundStatus, providerRef, failureReason *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, id, status, providerRef, failureReason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockRefundRepositoryMockRecorder) Finish(ctx, id, status, providerRef, failureReason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockRefundRepository)(nil).Finish), ctx, id, status, providerRef, failureReason)
+}
+
+// GetByConcert mocks base method.
+func (m *MockRefundRepository) GetByConcert(ctx context.Context, concertID uuid.UUID) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConcert", ctx, concertID)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConcert indicates an expected call of GetByConcert.
+func (mr *MockRefundRepositoryMockRecorder) GetByConcert(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConcert", reflect.TypeOf((*MockRefundRepository)(nil).GetByConcert), ctx, concertID)
+}
+
+// GetByID mocks base method.
+func (m *MockRefundRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRefundRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRefundRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByUser mocks base method.
+func (m *MockRefundRepository) GetByUser(ctx context.Context, userID uuid.UUID) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockRefundRepositoryMockRecorder) GetByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockRefundRepository)(nil).GetByUser), ctx, userID)
+}
+
+// Retry mocks base method.
+func (m *MockRefundRepository) Retry(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockRefundRepositoryMockRecorder) Retry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockRefundRepository)(nil).Retry), ctx, id)
+}
+
+// MockWaitlistRepository is a mock of WaitlistRepository interface.
+type MockWaitlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWaitlistRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWaitlistRepositoryMockRecorder is the mock recorder for MockWaitlistRepository.
+type MockWaitlistRepositoryMockRecorder struct {
+	mock *MockWaitlistRepository
+}
+
+// NewMockWaitlistRepository creates a new mock instance.
+func NewMockWaitlistRepository(ctrl *gomock.Controller) *MockWaitlistRepository {
+	mock := &MockWaitlistRepository{ctrl: ctrl}
+	mock.recorder = &MockWaitlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWaitlistRepository) EXPECT() *MockWaitlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWaitlistRepository) Create(ctx context.Context, entry *models.WaitlistEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWaitlistRepositoryMockRecorder) Create(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWaitlistRepository)(nil).Create), ctx, entry)
+}
+
+// ExpireOffers mocks base method.
+func (m *MockWaitlistRepository) ExpireOffers(ctx context.Context, bookingIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireOffers", ctx, bookingIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireOffers indicates an expected call of ExpireOffers.
+func (mr *MockWaitlistRepositoryMockRecorder) ExpireOffers(ctx, bookingIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireOffers", reflect.TypeOf((*MockWaitlistRepository)(nil).ExpireOffers), ctx, bookingIDs)
+}
+
+// ExpireWaiting mocks base method.
+func (m *MockWaitlistRepository) ExpireWaiting(ctx context.Context, concertID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireWaiting", ctx, concertID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireWaiting indicates an expected call of ExpireWaiting.
+func (mr *MockWaitlistRepositoryMockRecorder) ExpireWaiting(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireWaiting", reflect.TypeOf((*MockWaitlistRepository)(nil).ExpireWaiting), ctx, concertID)
+}
+
+// GetActive mocks base method.
+func (m *MockWaitlistRepository) GetActive(ctx context.Context, concertID, userID uuid.UUID) (*models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, concertID, userID)
+	ret0, _ := ret[0].(*models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockWaitlistRepositoryMockRecorder) GetActive(ctx, concertID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockWaitlistRepository)(nil).GetActive), ctx, concertID, userID)
+}
+
+// Leave mocks base method.
+func (m *MockWaitlistRepository) Leave(ctx context.Context, concertID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Leave", ctx, concertID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Leave indicates an expected call of Leave.
+func (mr *MockWaitlistRepositoryMockRecorder) Leave(ctx, concertID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leave", reflect.TypeOf((*MockWaitlistRepository)(nil).Leave), ctx, concertID, userID)
+}
+
+// MarkClaimed mocks base method.
+func (m *MockWaitlistRepository) MarkClaimed(ctx context.Context, bookingID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkClaimed", ctx, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkClaimed indicates an expected call of MarkClaimed.
+func (mr *MockWaitlistRepositoryMockRecorder) MarkClaimed(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkClaimed", reflect.TypeOf((*MockWaitlistRepository)(nil).MarkClaimed), ctx, bookingID)
+}
+
+// MarkOffered mocks base method.
+func (m *MockWaitlistRepository) MarkOffered(ctx context.Context, id, bookingID uuid.UUID, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOffered", ctx, id, bookingID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOffered indicates an expected call of MarkOffered.
+func (mr *MockWaitlistRepositoryMockRecorder) MarkOffered(ctx, id, bookingID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOffered", reflect.TypeOf((*MockWaitlistRepository)(nil).MarkOffered), ctx, id, bookingID, expiresAt)
+}
+
+// NextWaiting mocks base method.
+func (m *MockWaitlistRepository) NextWaiting(ctx context.Context, concertID uuid.UUID, limit int) ([]models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextWaiting", ctx, concertID, limit)
+	ret0, _ := ret[0].([]models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextWaiting indicates an expected call of NextWaiting.
+func (mr *MockWaitlistRepositoryMockRecorder) NextWaiting(ctx, concertID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextWaiting", reflect.TypeOf((*MockWaitlistRepository)(nil).NextWaiting), ctx, concertID, limit)
+}
+
+// MockPaymentRepository is a mock of PaymentRepository interface.
+type MockPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentRepositoryMockRecorder is the mock recorder for MockPaymentRepository.
+type MockPaymentRepositoryMockRecorder struct {
+	mock *MockPaymentRepository
+}
+
+// NewMockPaymentRepository creates a new mock instance.
+func NewMockPaymentRepository(ctrl *gomock.Controller) *MockPaymentRepository {
+	mock := &MockPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPaymentRepository) Create(ctx context.Context, intent *models.PaymentIntent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, intent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRepositoryMockRecorder) Create(ctx, intent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRepository)(nil).Create), ctx, intent)
+}
+
+// Finish mocks base method.
+func (m *MockPaymentRepository) Finish(ctx context.Context, id uuid.UUID, status models.PaymentStatus, failureReason *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, id, status, failureReason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockPaymentRepositoryMockRecorder) Finish(ctx, id, status, failureReason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockPaymentRepository)(nil).Finish), ctx, id, status, failureReason)
+}
+
+// GetActiveByBooking mocks base method.
+func (m *MockPaymentRepository) GetActiveByBooking(ctx context.Context, bookingID uuid.UUID) (*models.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByBooking", ctx, bookingID)
+	ret0, _ := ret[0].(*models.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByBooking indicates an expected call of GetActiveByBooking.
+func (mr *MockPaymentRepositoryMockRecorder) GetActiveByBooking(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByBooking", reflect.TypeOf((*MockPaymentRepository)(nil).GetActiveByBooking), ctx, bookingID)
+}
+
+// GetByID mocks base method.
+func (m *MockPaymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPaymentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPaymentRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockPaymentRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*models.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockPaymentRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockPaymentRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// SetProviderRef mocks base method.
+func (m *MockPaymentRepository) SetProviderRef(ctx context.Context, id uuid.UUID, providerRef string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProviderRef", ctx, id, providerRef)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProviderRef indicates an expected call of SetProviderRef.
+func (mr *MockPaymentRepositoryMockRecorder) SetProviderRef(ctx, id, providerRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderRef", reflect.TypeOf((*MockPaymentRepository)(nil).SetProviderRef), ctx, id, providerRef)
+}
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConcertBalances mocks base method.
+func (m *MockLedgerRepository) ConcertBalances(ctx context.Context, concertID uuid.UUID) ([]models.LedgerBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConcertBalances", ctx, concertID)
+	ret0, _ := ret[0].([]models.LedgerBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConcertBalances indicates an expected call of ConcertBalances.
+func (mr *MockLedgerRepositoryMockRecorder) ConcertBalances(ctx, concertID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConcertBalances", reflect.TypeOf((*MockLedgerRepository)(nil).ConcertBalances), ctx, concertID)
+}
+
+// Post mocks base method.
+func (m *MockLedgerRepository) Post(ctx context.Context, txs []models.LedgerTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, txs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockLedgerRepositoryMockRecorder) Post(ctx, txs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockLedgerRepository)(nil).Post), ctx, txs)
+}
+
+// Totals mocks base method.
+func (m *MockLedgerRepository) Totals(ctx context.Context) ([]models.LedgerTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Totals", ctx)
+	ret0, _ := ret[0].([]models.LedgerTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Totals indicates an expected call of Totals.
+func (mr *MockLedgerRepositoryMockRecorder) Totals(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Totals", reflect.TypeOf((*MockLedgerRepository)(nil).Totals), ctx)
+}
+
+// UnbalancedTransactions mocks base method.
+func (m *MockLedgerRepository) UnbalancedTransactions(ctx context.Context) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbalancedTransactions", ctx)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbalancedTransactions indicates an expected call of UnbalancedTransactions.
+func (mr *MockLedgerRepositoryMockRecorder) UnbalancedTransactions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbalancedTransactions", reflect.TypeOf((*MockLedgerRepository)(nil).UnbalancedTransactions), ctx)
+}
+
+// MockPromoCodeRepository is a mock of PromoCodeRepository interface.
+type MockPromoCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoCodeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPromoCodeRepositoryMockRecorder is the mock recorder for MockPromoCodeRepository.
+type MockPromoCodeRepositoryMockRecorder struct {
+	mock *MockPromoCodeRepository
+}
+
+// NewMockPromoCodeRepository creates a new mock instance.
+func NewMockPromoCodeRepository(ctrl *gomock.Controller) *MockPromoCodeRepository {
+	mock := &MockPromoCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockPromoCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromoCodeRepository) EXPECT() *MockPromoCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountRedemptions mocks base method.
+func (m *MockPromoCodeRepository) CountRedemptions(ctx context.Context, promoID, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRedemptions", ctx, promoID, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRedemptions indicates an expected call of CountRedemptions.
+func (mr *MockPromoCodeRepositoryMockRecorder) CountRedemptions(ctx, promoID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRedemptions", reflect.TypeOf((*MockPromoCodeRepository)(nil).CountRedemptions), ctx, promoID, userID)
+}
+
+// Create mocks base method.
+func (m *MockPromoCodeRepository) Create(ctx context.Context, promo *models.PromoCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromoCodeRepositoryMockRecorder) Create(ctx, promo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromoCodeRepository)(nil).Create), ctx, promo)
+}
+
+// Deactivate mocks base method.
+func (m *MockPromoCodeRepository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockPromoCodeRepositoryMockRecorder) Deactivate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockPromoCodeRepository)(nil).Deactivate), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockPromoCodeRepository) GetAll(ctx context.Context) ([]models.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPromoCodeRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromoCodeRepository)(nil).GetAll), ctx)
+}
+
+// GetByCodeForUpdate mocks base method.
+func (m *MockPromoCodeRepository) GetByCodeForUpdate(ctx context.Context, code string) (*models.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCodeForUpdate", ctx, code)
+	ret0, _ := ret[0].(*models.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCodeForUpdate indicates an expected call of GetByCodeForUpdate.
+func (mr *MockPromoCodeRepositoryMockRecorder) GetByCodeForUpdate(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCodeForUpdate", reflect.TypeOf((*MockPromoCodeRepository)(nil).GetByCodeForUpdate), ctx, code)
+}
+
+// GetByID mocks base method.
+func (m *MockPromoCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPromoCodeRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPromoCodeRepository)(nil).GetByID), ctx, id)
+}
+
+// Redeem mocks base method.
+func (m *MockPromoCodeRepository) Redeem(ctx context.Context, promoID, userID uuid.UUID, bookingIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, promoID, userID, bookingIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockPromoCodeRepositoryMockRecorder) Redeem(ctx, promoID, userID, bookingIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockPromoCodeRepository)(nil).Redeem), ctx, promoID, userID, bookingIDs)
+}
+
+// Release mocks base method.
+func (m *MockPromoCodeRepository) Release(ctx context.Context, bookingIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, bookingIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockPromoCodeRepositoryMockRecorder) Release(ctx, bookingIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockPromoCodeRepository)(nil).Release), ctx, bookingIDs)
+}
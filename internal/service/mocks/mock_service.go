@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/yohnnn/booking_service/internal/service (interfaces: BookingSettler,Auth)
+//
+// Generated by this command:
+//
+//	mockgen -destination=internal/service/mocks/mock_service.go -package=mocks github.com/yohnnn/booking_service/internal/service BookingSettler,Auth
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/yohnnn/booking_service/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBookingSettler is a mock of BookingSettler interface.
+type MockBookingSettler struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingSettlerMockRecorder
+	isgomock struct{}
+}
+
+// MockBookingSettlerMockRecorder is the mock recorder for MockBookingSettler.
+type MockBookingSettlerMockRecorder struct {
+	mock *MockBookingSettler
+}
+
+// NewMockBookingSettler creates a new mock instance.
+func NewMockBookingSettler(ctrl *gomock.Controller) *MockBookingSettler {
+	mock := &MockBookingSettler{ctrl: ctrl}
+	mock.recorder = &MockBookingSettlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingSettler) EXPECT() *MockBookingSettlerMockRecorder {
+	return m.recorder
+}
+
+// ConfirmBooking mocks base method.
+func (m *MockBookingSettler) ConfirmBooking(ctx context.Context, userID, bookingID uuid.UUID) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmBooking", ctx, userID, bookingID)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmBooking indicates an expected call of ConfirmBooking.
+func (mr *MockBookingSettlerMockRecorder) ConfirmBooking(ctx, userID, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmBooking", reflect.TypeOf((*MockBookingSettler)(nil).ConfirmBooking), ctx, userID, bookingID)
+}
+
+// ReleaseBooking mocks base method.
+func (m *MockBookingSettler) ReleaseBooking(ctx context.Context, userID, bookingID uuid.UUID) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseBooking", ctx, userID, bookingID)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseBooking indicates an expected call of ReleaseBooking.
+func (mr *MockBookingSettlerMockRecorder) ReleaseBooking(ctx, userID, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseBooking", reflect.TypeOf((*MockBookingSettler)(nil).ReleaseBooking), ctx, userID, bookingID)
+}
+
+// MockAuth is a mock of Auth interface.
+type MockAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthMockRecorder is the mock recorder for MockAuth.
+type MockAuthMockRecorder struct {
+	mock *MockAuth
+}
+
+// NewMockAuth creates a new mock instance.
+func NewMockAuth(ctrl *gomock.Controller) *MockAuth {
+	mock := &MockAuth{ctrl: ctrl}
+	mock.recorder = &MockAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuth) EXPECT() *MockAuthMockRecorder {
+	return m.recorder
+}
+
+// Login mocks base method.
+func (m *MockAuth) Login(ctx context.Context, email, password string) (*models.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, email, password)
+	ret0, _ := ret[0].(*models.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAuthMockRecorder) Login(ctx, email, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuth)(nil).Login), ctx, email, password)
+}
+
+// Logout mocks base method.
+func (m *MockAuth) Logout(ctx context.Context, claims *models.TokenClaims, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, claims, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthMockRecorder) Logout(ctx, claims, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuth)(nil).Logout), ctx, claims, refreshToken)
+}
+
+// ParseToken mocks base method.
+func (m *MockAuth) ParseToken(tokenString string) (*models.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", tokenString)
+	ret0, _ := ret[0].(*models.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockAuthMockRecorder) ParseToken(tokenString any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuth)(nil).ParseToken), tokenString)
+}
+
+// Refresh mocks base method.
+func (m *MockAuth) Refresh(ctx context.Context, refreshToken string) (*models.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(*models.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthMockRecorder) Refresh(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuth)(nil).Refresh), ctx, refreshToken)
+}
+
+// Register mocks base method.
+func (m *MockAuth) Register(ctx context.Context, email, password string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, email, password)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockAuthMockRecorder) Register(ctx, email, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuth)(nil).Register), ctx, email, password)
+}
+
+// ValidateToken mocks base method.
+func (m *MockAuth) ValidateToken(ctx context.Context, tokenString string) (*models.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateToken", ctx, tokenString)
+	ret0, _ := ret[0].(*models.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateToken indicates an expected call of ValidateToken.
+func (mr *MockAuthMockRecorder) ValidateToken(ctx, tokenString any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockAuth)(nil).ValidateToken), ctx, tokenString)
+}
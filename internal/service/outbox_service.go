@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/yohnnn/booking_service/internal/event"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+type OutboxService struct {
+	logger       *slog.Logger
+	outboxRepo   repository.OutboxRepository
+	producer     event.EventProducer
+	retryBase    time.Duration
+	retryMax     time.Duration
+	claimTimeout time.Duration
+}
+
+func NewOutboxService(
+	logger *slog.Logger,
+	outboxRepo repository.OutboxRepository,
+	producer event.EventProducer,
+	retryBase, retryMax, claimTimeout time.Duration,
+) *OutboxService {
+	return &OutboxService{
+		logger:       logger,
+		outboxRepo:   outboxRepo,
+		producer:     producer,
+		retryBase:    retryBase,
+		retryMax:     retryMax,
+		claimTimeout: claimTimeout,
+	}
+}
+
+func (s *OutboxService) RelayPending(ctx context.Context, limit int) (int, error) {
+	messages, err := s.outboxRepo.Claim(ctx, limit, time.Now().Add(s.claimTimeout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	sent := 0
+	for _, msg := range messages {
+		if err := s.producer.Publish(ctx, msg.EventType, msg.EventKey, msg.Payload); err != nil {
+			s.logger.WarnContext(ctx, "failed to publish outbox message",
+				"id", msg.ID,
+				"event_type", msg.EventType,
+				"attempts", msg.Attempts+1,
+				"error", err,
+			)
+
+			nextAttemptAt := time.Now().Add(s.backoff(msg.Attempts))
+			if err := s.outboxRepo.MarkFailed(ctx, msg.ID, err.Error(), nextAttemptAt); err != nil {
+				s.logger.ErrorContext(ctx, "failed to record outbox failure", "id", msg.ID, "error", err)
+			}
+			continue
+		}
+
+		if err := s.outboxRepo.MarkSent(ctx, msg.ID); err != nil {
+			s.logger.ErrorContext(ctx, "failed to mark outbox message sent", "id", msg.ID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (s *OutboxService) backoff(attempts int) time.Duration {
+	delay := s.retryBase
+	for range attempts {
+		delay *= 2
+		if delay >= s.retryMax {
+			return s.retryMax
+		}
+	}
+	return delay
+}
+
+func enqueueEvent(
+	ctx context.Context,
+	outboxRepo repository.OutboxRepository,
+	eventType, key string,
+	evt any,
+) error {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+
+	msg := &models.OutboxMessage{
+		EventType: eventType,
+		EventKey:  key,
+		Payload:   payload,
+	}
+
+	if err := outboxRepo.Create(ctx, msg); err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", eventType, err)
+	}
+
+	return nil
+}
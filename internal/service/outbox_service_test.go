@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/event"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestOutboxService_RelayPending(t *testing.T) {
+	first := models.OutboxMessage{
+		ID:        uuid.New(),
+		EventType: event.TypeBookingCreated,
+		EventKey:  "booking-1",
+		Payload:   []byte(`{"booking_id":"booking-1"}`),
+	}
+	second := models.OutboxMessage{
+		ID:        uuid.New(),
+		EventType: event.TypeBookingCancelled,
+		EventKey:  "booking-2",
+		Payload:   []byte(`{"booking_id":"booking-2"}`),
+		Attempts:  2,
+	}
+
+	type mockBehavior func(outboxRepo *mocks.MockOutboxRepository, producer *mocks.MockEventProducer)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "all messages sent",
+			mockBehavior: func(outboxRepo *mocks.MockOutboxRepository, producer *mocks.MockEventProducer) {
+				outboxRepo.EXPECT().
+					Claim(gomock.Any(), 10, gomock.Any()).
+					Return([]models.OutboxMessage{first, second}, nil)
+				producer.EXPECT().
+					Publish(gomock.Any(), first.EventType, first.EventKey, first.Payload).
+					Return(nil)
+				outboxRepo.EXPECT().
+					MarkSent(gomock.Any(), first.ID).
+					Return(nil)
+				producer.EXPECT().
+					Publish(gomock.Any(), second.EventType, second.EventKey, second.Payload).
+					Return(nil)
+				outboxRepo.EXPECT().
+					MarkSent(gomock.Any(), second.ID).
+					Return(nil)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "publish failure schedules retry with backoff",
+			mockBehavior: func(outboxRepo *mocks.MockOutboxRepository, producer *mocks.MockEventProducer) {
+				outboxRepo.EXPECT().
+					Claim(gomock.Any(), 10, gomock.Any()).
+					Return([]models.OutboxMessage{first, second}, nil)
+				producer.EXPECT().
+					Publish(gomock.Any(), first.EventType, first.EventKey, first.Payload).
+					Return(nil)
+				outboxRepo.EXPECT().
+					MarkSent(gomock.Any(), first.ID).
+					Return(nil)
+				producer.EXPECT().
+					Publish(gomock.Any(), second.EventType, second.EventKey, second.Payload).
+					Return(assert.AnError)
+				outboxRepo.EXPECT().
+					MarkFailed(gomock.Any(), second.ID, assert.AnError.Error(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, next time.Time) error {
+						assert.WithinDuration(t, time.Now().Add(4*time.Second), next, time.Second)
+						return nil
+					})
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "mark sent failure does not stop the batch",
+			mockBehavior: func(outboxRepo *mocks.MockOutboxRepository, producer *mocks.MockEventProducer) {
+				outboxRepo.EXPECT().
+					Claim(gomock.Any(), 10, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, leaseUntil time.Time) ([]models.OutboxMessage, error) {
+						assert.WithinDuration(t, time.Now().Add(time.Minute), leaseUntil, time.Second)
+						return []models.OutboxMessage{first, second}, nil
+					})
+				producer.EXPECT().
+					Publish(gomock.Any(), first.EventType, first.EventKey, first.Payload).
+					Return(nil)
+				outboxRepo.EXPECT().
+					MarkSent(gomock.Any(), first.ID).
+					Return(assert.AnError)
+				producer.EXPECT().
+					Publish(gomock.Any(), second.EventType, second.EventKey, second.Payload).
+					Return(nil)
+				outboxRepo.EXPECT().
+					MarkSent(gomock.Any(), second.ID).
+					Return(nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "nothing pending",
+			mockBehavior: func(outboxRepo *mocks.MockOutboxRepository, _ *mocks.MockEventProducer) {
+				outboxRepo.EXPECT().
+					Claim(gomock.Any(), 10, gomock.Any()).
+					Return(nil, nil)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "claim error",
+			mockBehavior: func(outboxRepo *mocks.MockOutboxRepository, _ *mocks.MockEventProducer) {
+				outboxRepo.EXPECT().
+					Claim(gomock.Any(), 10, gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+			producer := mocks.NewMockEventProducer(ctrl)
+			tt.mockBehavior(outboxRepo, producer)
+
+			s := NewOutboxService(testLogger(), outboxRepo, producer, time.Second, time.Minute, time.Minute)
+
+			got, err := s.RelayPending(context.Background(), 10)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOutboxService_Backoff(t *testing.T) {
+	s := NewOutboxService(testLogger(), nil, nil, time.Second, 10*time.Second, time.Minute)
+
+	assert.Equal(t, time.Second, s.backoff(0))
+	assert.Equal(t, 2*time.Second, s.backoff(1))
+	assert.Equal(t, 8*time.Second, s.backoff(3))
+	assert.Equal(t, 10*time.Second, s.backoff(4))
+	assert.Equal(t, 10*time.Second, s.backoff(50))
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/payment"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+type PaymentService struct {
+	logger      *slog.Logger
+	bookingRepo repository.BookingRepository
+	paymentRepo repository.PaymentRepository
+	refundRepo  repository.RefundRepository
+	ledgerRepo  repository.LedgerRepository
+	bookings    BookingSettler
+	provider    payment.PaymentProvider
+	manager     TxManager
+}
+
+func NewPaymentService(
+	logger *slog.Logger,
+	bookingRepo repository.BookingRepository,
+	paymentRepo repository.PaymentRepository,
+	refundRepo repository.RefundRepository,
+	ledgerRepo repository.LedgerRepository,
+	bookings BookingSettler,
+	provider payment.PaymentProvider,
+	manager TxManager,
+) *PaymentService {
+	return &PaymentService{
+		logger:      logger,
+		bookingRepo: bookingRepo,
+		paymentRepo: paymentRepo,
+		refundRepo:  refundRepo,
+		ledgerRepo:  ledgerRepo,
+		bookings:    bookings,
+		provider:    provider,
+		manager:     manager,
+	}
+}
+
+func (s *PaymentService) CreateIntent(
+	ctx context.Context,
+	userID, bookingID uuid.UUID,
+) (*models.PaymentIntent, error) {
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	if booking.UserID != userID {
+		return nil, models.ErrForbidden
+	}
+
+	if booking.Status != models.BookingStatusPending {
+		return nil, models.ErrNotPending
+	}
+
+	if booking.ExpiresAt != nil && !booking.ExpiresAt.After(time.Now()) {
+		return nil, models.ErrHoldExpired
+	}
+
+	existing, err := s.paymentRepo.GetActiveByBooking(ctx, bookingID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, models.ErrNotFound) {
+		return nil, err
+	}
+
+	intent := &models.PaymentIntent{
+		BookingID: booking.ID,
+		UserID:    userID,
+		Amount:    booking.Price,
+		Status:    models.PaymentStatusPending,
+	}
+	if err := s.paymentRepo.Create(ctx, intent); err != nil {
+		return nil, err
+	}
+
+	created, err := s.provider.CreateIntent(ctx, payment.IntentRequest{
+		Reference: intent.ID.String(),
+		Amount:    intent.Amount,
+	})
+	if err != nil {
+		reason := err.Error()
+		if ferr := s.paymentRepo.Finish(ctx, intent.ID, models.PaymentStatusFailed, &reason); ferr != nil {
+			s.logger.WarnContext(ctx, "failed to mark payment intent failed", "intent_id", intent.ID, "error", ferr)
+		}
+		return nil, fmt.Errorf("failed to create provider intent: %w", err)
+	}
+
+	if err := s.paymentRepo.SetProviderRef(ctx, intent.ID, created.ProviderRef); err != nil {
+		return nil, err
+	}
+	intent.ProviderRef = &created.ProviderRef
+
+	s.logger.InfoContext(ctx, "payment intent created", "intent_id", intent.ID, "booking_id", booking.ID)
+
+	return intent, nil
+}
+
+func (s *PaymentService) GetIntent(ctx context.Context, userID, intentID uuid.UUID) (*models.PaymentIntent, error) {
+	intent, err := s.paymentRepo.GetByID(ctx, intentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if intent.UserID != userID {
+		return nil, models.ErrForbidden
+	}
+
+	return intent, nil
+}
+
+func (s *PaymentService) HandleWebhook(ctx context.Context, payload []byte, headers http.Header) error {
+	evt, err := s.provider.ParseWebhook(payload, headers)
+	if err != nil {
+		return err
+	}
+
+	intentID, err := uuid.Parse(evt.Reference)
+	if err != nil {
+		return fmt.Errorf("%w: bad reference %q", models.ErrInvalidWebhook, evt.Reference)
+	}
+
+	return withTx(ctx, s.manager, func(ctx context.Context) error {
+		intent, err := s.paymentRepo.GetByIDForUpdate(ctx, intentID)
+		if err != nil {
+			return err
+		}
+
+		if intent.Status.IsFinal() {
+			s.logger.InfoContext(ctx, "payment webhook already processed", "intent_id", intent.ID)
+			return nil
+		}
+
+		if evt.Amount != intent.Amount {
+			s.logger.ErrorContext(ctx, "payment webhook amount mismatch",
+				"intent_id", intent.ID,
+				"expected", intent.Amount.Amount,
+				"expected_currency", intent.Amount.Currency,
+				"got", evt.Amount.Amount,
+				"got_currency", evt.Amount.Currency,
+			)
+			return fmt.Errorf("%w: amount does not match payment intent", models.ErrInvalidWebhook)
+		}
+
+		var reason *string
+		if evt.Status == models.PaymentStatusFailed && evt.FailureReason != "" {
+			reason = &evt.FailureReason
+		}
+
+		if err := s.paymentRepo.Finish(ctx, intent.ID, evt.Status, reason); err != nil {
+			return err
+		}
+
+		if evt.Status == models.PaymentStatusFailed {
+			return s.failBooking(ctx, intent)
+		}
+		return s.settleBooking(ctx, intent)
+	})
+}
+
+func (s *PaymentService) settleBooking(ctx context.Context, intent *models.PaymentIntent) error {
+	booking, err := s.bookings.ConfirmBooking(ctx, intent.UserID, intent.BookingID)
+	if err == nil {
+		if err := postLedger(ctx, s.ledgerRepo, paymentTransaction(booking.ConcertID, intent)); err != nil {
+			return err
+		}
+
+		s.logger.InfoContext(ctx, "booking paid", "booking_id", intent.BookingID, "intent_id", intent.ID)
+		return nil
+	}
+	if !errors.Is(err, models.ErrNotPending) && !errors.Is(err, models.ErrHoldExpired) {
+		return err
+	}
+
+	booking, err = s.bookingRepo.GetByID(ctx, intent.BookingID)
+	if err != nil {
+		return fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	s.logger.WarnContext(ctx, "payment succeeded after booking was released",
+		"booking_id", booking.ID,
+		"intent_id", intent.ID,
+	)
+
+	if err := postLedger(ctx, s.ledgerRepo, paymentTransaction(booking.ConcertID, intent)); err != nil {
+		return err
+	}
+
+	return s.refundRepo.Create(ctx, &models.Refund{
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		ConcertID: booking.ConcertID,
+		Amount:    intent.Amount,
+		Reason:    models.RefundReasonPaymentAfterRelease,
+		Status:    models.RefundStatusPending,
+	})
+}
+
+func (s *PaymentService) failBooking(ctx context.Context, intent *models.PaymentIntent) error {
+	_, err := s.bookings.ReleaseBooking(ctx, intent.UserID, intent.BookingID)
+	if err != nil && !errors.Is(err, models.ErrBookingCancelled) {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "payment failed, booking released",
+		"booking_id", intent.BookingID,
+		"intent_id", intent.ID,
+	)
+	return nil
+}
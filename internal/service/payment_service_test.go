@@ -0,0 +1,601 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/payment"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestPaymentService_CreateIntent(t *testing.T) {
+	userID := uuid.New()
+	bookingID := uuid.New()
+	intentID := uuid.New()
+
+	booking := func() *models.Booking {
+		expiresAt := time.Now().Add(10 * time.Minute)
+		return &models.Booking{
+			ID:        bookingID,
+			UserID:    userID,
+			Price:     models.NewMoney(250000, "RUB"),
+			Status:    models.BookingStatusPending,
+			ExpiresAt: &expiresAt,
+		}
+	}
+
+	type mockBehavior func(
+		bookingRepo *mocks.MockBookingRepository,
+		paymentRepo *mocks.MockPaymentRepository,
+		provider *mocks.MockPaymentProvider,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				provider *mocks.MockPaymentProvider,
+			) {
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				paymentRepo.EXPECT().
+					GetActiveByBooking(gomock.Any(), bookingID).
+					Return(nil, models.ErrNotFound)
+				paymentRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, intent *models.PaymentIntent) error {
+						assert.Equal(t, models.NewMoney(250000, "RUB"), intent.Amount)
+						assert.Equal(t, models.PaymentStatusPending, intent.Status)
+						intent.ID = intentID
+						return nil
+					})
+				provider.EXPECT().
+					CreateIntent(gomock.Any(), payment.IntentRequest{
+						Reference: intentID.String(),
+						Amount:    models.NewMoney(250000, "RUB"),
+					}).
+					Return(&payment.Intent{ProviderRef: "pi_1"}, nil)
+				paymentRepo.EXPECT().
+					SetProviderRef(gomock.Any(), intentID, "pi_1").
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns active intent",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockPaymentProvider,
+			) {
+				ref := "pi_1"
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				paymentRepo.EXPECT().
+					GetActiveByBooking(gomock.Any(), bookingID).
+					Return(&models.PaymentIntent{
+						ID:          intentID,
+						BookingID:   bookingID,
+						UserID:      userID,
+						Status:      models.PaymentStatusPending,
+						ProviderRef: &ref,
+					}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "booking of another user",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockPaymentRepository,
+				_ *mocks.MockPaymentProvider,
+			) {
+				b := booking()
+				b.UserID = uuid.New()
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(b, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrForbidden,
+		},
+		{
+			name: "booking already confirmed",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockPaymentRepository,
+				_ *mocks.MockPaymentProvider,
+			) {
+				b := booking()
+				b.Status = models.BookingStatusConfirmed
+				b.ExpiresAt = nil
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(b, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotPending,
+		},
+		{
+			name: "hold expired",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockPaymentRepository,
+				_ *mocks.MockPaymentProvider,
+			) {
+				b := booking()
+				expiresAt := time.Now().Add(-time.Minute)
+				b.ExpiresAt = &expiresAt
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(b, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrHoldExpired,
+		},
+		{
+			name: "provider error fails intent",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				provider *mocks.MockPaymentProvider,
+			) {
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				paymentRepo.EXPECT().
+					GetActiveByBooking(gomock.Any(), bookingID).
+					Return(nil, models.ErrNotFound)
+				paymentRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, intent *models.PaymentIntent) error {
+						intent.ID = intentID
+						return nil
+					})
+				provider.EXPECT().
+					CreateIntent(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrPaymentProvider)
+				paymentRepo.EXPECT().
+					Finish(gomock.Any(), intentID, models.PaymentStatusFailed, gomock.Any()).
+					Return(nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrPaymentProvider,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			paymentRepo := mocks.NewMockPaymentRepository(ctrl)
+			provider := mocks.NewMockPaymentProvider(ctrl)
+			tt.mockBehavior(bookingRepo, paymentRepo, provider)
+
+			s := NewPaymentService(
+				testLogger(),
+				bookingRepo,
+				paymentRepo,
+				mocks.NewMockRefundRepository(ctrl),
+				mocks.NewMockLedgerRepository(ctrl),
+				mocks.NewMockBookingSettler(ctrl),
+				provider,
+				mocks.NewMockTxManager(ctrl),
+			)
+
+			got, err := s.CreateIntent(context.Background(), userID, bookingID)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, intentID, got.ID)
+			require.NotNil(t, got.ProviderRef)
+			assert.Equal(t, "pi_1", *got.ProviderRef)
+		})
+	}
+}
+
+func TestPaymentService_HandleWebhook(t *testing.T) {
+	userID := uuid.New()
+	bookingID := uuid.New()
+	concertID := uuid.New()
+	intentID := uuid.New()
+
+	intent := func(status models.PaymentStatus) *models.PaymentIntent {
+		return &models.PaymentIntent{
+			ID:        intentID,
+			BookingID: bookingID,
+			UserID:    userID,
+			Amount:    models.NewMoney(250000, "RUB"),
+			Status:    status,
+		}
+	}
+
+	succeeded := &payment.WebhookEvent{
+		ProviderRef: "pi_1",
+		Reference:   intentID.String(),
+		Status:      models.PaymentStatusSucceeded,
+		Amount:      models.NewMoney(250000, "RUB"),
+	}
+	declined := &payment.WebhookEvent{
+		ProviderRef:   "pi_1",
+		Reference:     intentID.String(),
+		Status:        models.PaymentStatusFailed,
+		Amount:        models.NewMoney(250000, "RUB"),
+		FailureReason: "card_declined",
+	}
+	underpaid := &payment.WebhookEvent{
+		ProviderRef: "pi_1",
+		Reference:   intentID.String(),
+		Status:      models.PaymentStatusSucceeded,
+		Amount:      models.NewMoney(100, "RUB"),
+	}
+
+	type mockBehavior func(
+		bookingRepo *mocks.MockBookingRepository,
+		paymentRepo *mocks.MockPaymentRepository,
+		refundRepo *mocks.MockRefundRepository,
+		ledgerRepo *mocks.MockLedgerRepository,
+		bookings *mocks.MockBookingSettler,
+		provider *mocks.MockPaymentProvider,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "success confirms booking and posts sale",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockRefundRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				bookings *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(succeeded, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				paymentRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), intentID).
+					Return(intent(models.PaymentStatusPending), nil)
+				paymentRepo.EXPECT().
+					Finish(gomock.Any(), intentID, models.PaymentStatusSucceeded, nil).
+					Return(nil)
+				bookings.EXPECT().
+					ConfirmBooking(gomock.Any(), userID, bookingID).
+					Return(&models.Booking{
+						ID:         bookingID,
+						ConcertID:  concertID,
+						FaceValue:  models.NewMoney(200000, "RUB"),
+						ServiceFee: models.NewMoney(40000, "RUB"),
+						VAT:        models.NewMoney(10000, "RUB"),
+						Price:      models.NewMoney(250000, "RUB"),
+						Status:     models.BookingStatusConfirmed,
+					}, nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionPayment, txs[0].Type)
+						assert.Equal(t, concertID, txs[0].ConcertID)
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "decline releases booking",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				bookings *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(declined, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				paymentRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), intentID).
+					Return(intent(models.PaymentStatusPending), nil)
+				paymentRepo.EXPECT().
+					Finish(gomock.Any(), intentID, models.PaymentStatusFailed, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ models.PaymentStatus, reason *string) error {
+						require.NotNil(t, reason)
+						assert.Equal(t, "card_declined", *reason)
+						return nil
+					})
+				bookings.EXPECT().
+					ReleaseBooking(gomock.Any(), userID, bookingID).
+					Return(&models.Booking{ID: bookingID, Status: models.BookingStatusCancelled}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "decline after hold was already released",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				bookings *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(declined, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				paymentRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), intentID).
+					Return(intent(models.PaymentStatusPending), nil)
+				paymentRepo.EXPECT().
+					Finish(gomock.Any(), intentID, models.PaymentStatusFailed, gomock.Any()).
+					Return(nil)
+				bookings.EXPECT().
+					ReleaseBooking(gomock.Any(), userID, bookingID).
+					Return(nil, models.ErrBookingCancelled)
+			},
+			wantErr: false,
+		},
+		{
+			name: "late success is refunded",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				refundRepo *mocks.MockRefundRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				bookings *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(succeeded, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				paymentRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), intentID).
+					Return(intent(models.PaymentStatusPending), nil)
+				paymentRepo.EXPECT().
+					Finish(gomock.Any(), intentID, models.PaymentStatusSucceeded, nil).
+					Return(nil)
+				bookings.EXPECT().
+					ConfirmBooking(gomock.Any(), userID, bookingID).
+					Return(nil, models.ErrHoldExpired)
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(&models.Booking{
+						ID:        bookingID,
+						UserID:    userID,
+						ConcertID: concertID,
+						Status:    models.BookingStatusCancelled,
+					}, nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionPayment, txs[0].Type)
+						return nil
+					})
+				refundRepo.EXPECT().
+					Create(gomock.Any(), &models.Refund{
+						BookingID: bookingID,
+						UserID:    userID,
+						ConcertID: concertID,
+						Amount:    models.NewMoney(250000, "RUB"),
+						Reason:    models.RefundReasonPaymentAfterRelease,
+						Status:    models.RefundStatusPending,
+					}).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate delivery is ignored",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(succeeded, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				paymentRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), intentID).
+					Return(intent(models.PaymentStatusSucceeded), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid signature",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				_ *mocks.MockPaymentRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				_ *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrInvalidWebhook)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidWebhook,
+		},
+		{
+			name: "amount mismatch is rejected",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(underpaid, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				paymentRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), intentID).
+					Return(intent(models.PaymentStatusPending), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidWebhook,
+		},
+		{
+			name: "invalid reference",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				_ *mocks.MockPaymentRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				_ *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(&payment.WebhookEvent{Reference: "order-1", Status: models.PaymentStatusSucceeded}, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrInvalidWebhook,
+		},
+		{
+			name: "confirm error rolls back",
+			mockBehavior: func(
+				_ *mocks.MockBookingRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				bookings *mocks.MockBookingSettler,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				provider.EXPECT().
+					ParseWebhook(gomock.Any(), gomock.Any()).
+					Return(succeeded, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				paymentRepo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), intentID).
+					Return(intent(models.PaymentStatusPending), nil)
+				paymentRepo.EXPECT().
+					Finish(gomock.Any(), intentID, models.PaymentStatusSucceeded, nil).
+					Return(nil)
+				bookings.EXPECT().
+					ConfirmBooking(gomock.Any(), userID, bookingID).
+					Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			paymentRepo := mocks.NewMockPaymentRepository(ctrl)
+			refundRepo := mocks.NewMockRefundRepository(ctrl)
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			bookings := mocks.NewMockBookingSettler(ctrl)
+			provider := mocks.NewMockPaymentProvider(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(bookingRepo, paymentRepo, refundRepo, ledgerRepo, bookings, provider, txManager)
+
+			s := NewPaymentService(
+				testLogger(),
+				bookingRepo,
+				paymentRepo,
+				refundRepo,
+				ledgerRepo,
+				bookings,
+				provider,
+				txManager,
+			)
+
+			err := s.HandleWebhook(context.Background(), []byte(`{}`), http.Header{})
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
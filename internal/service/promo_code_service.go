@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+type PromoCodeService struct {
+	logger      *slog.Logger
+	concertRepo repository.ConcertRepository
+	promoRepo   repository.PromoCodeRepository
+}
+
+func NewPromoCodeService(
+	logger *slog.Logger,
+	concertRepo repository.ConcertRepository,
+	promoRepo repository.PromoCodeRepository,
+) *PromoCodeService {
+	return &PromoCodeService{
+		logger:      logger,
+		concertRepo: concertRepo,
+		promoRepo:   promoRepo,
+	}
+}
+
+func (s *PromoCodeService) Create(ctx context.Context, promo models.PromoCode) (*models.PromoCode, error) {
+	promo.Code = normalizePromoCode(promo.Code)
+	promo.Currency = strings.ToUpper(strings.TrimSpace(promo.Currency))
+	if err := validatePromoCode(&promo); err != nil {
+		return nil, err
+	}
+
+	if promo.ConcertID != nil {
+		concert, err := s.concertRepo.GetByID(ctx, *promo.ConcertID)
+		if err != nil {
+			return nil, err
+		}
+		if promo.DiscountType == models.PromoDiscountFixed && promo.Currency != concert.Price.Currency {
+			return nil, fmt.Errorf("%w: concert is sold in %s", models.ErrInvalidPromoCode, concert.Price.Currency)
+		}
+	}
+
+	if err := s.promoRepo.Create(ctx, &promo); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "promo code created", "promo_code_id", promo.ID, "code", promo.Code)
+
+	return &promo, nil
+}
+
+func (s *PromoCodeService) GetAll(ctx context.Context) ([]models.PromoCode, error) {
+	return s.promoRepo.GetAll(ctx)
+}
+
+func (s *PromoCodeService) GetByID(ctx context.Context, id uuid.UUID) (*models.PromoCode, error) {
+	return s.promoRepo.GetByID(ctx, id)
+}
+
+func (s *PromoCodeService) Deactivate(ctx context.Context, id uuid.UUID) error {
+	if err := s.promoRepo.Deactivate(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "promo code deactivated", "promo_code_id", id)
+	return nil
+}
+
+func validatePromoCode(promo *models.PromoCode) error {
+	if promo.Code == "" {
+		return fmt.Errorf("%w: code is required", models.ErrInvalidPromoCode)
+	}
+
+	switch promo.DiscountType {
+	case models.PromoDiscountPercent:
+		if promo.Value < 1 || promo.Value > 99 {
+			return fmt.Errorf("%w: percentage must be between 1 and 99", models.ErrInvalidPromoCode)
+		}
+		promo.Currency = ""
+	case models.PromoDiscountFixed:
+		if promo.Value < 1 {
+			return fmt.Errorf("%w: fixed discount must be positive", models.ErrInvalidPromoCode)
+		}
+		if !models.ValidCurrency(promo.Currency) {
+			return fmt.Errorf("%w: fixed discount requires a currency", models.ErrInvalidPromoCode)
+		}
+	default:
+		return fmt.Errorf("%w: unknown discount type %q", models.ErrInvalidPromoCode, promo.DiscountType)
+	}
+
+	if promo.MaxUses != nil && *promo.MaxUses < 1 {
+		return fmt.Errorf("%w: max_uses must be positive", models.ErrInvalidPromoCode)
+	}
+	if promo.MaxUsesPerUser != nil && *promo.MaxUsesPerUser < 1 {
+		return fmt.Errorf("%w: max_uses_per_user must be positive", models.ErrInvalidPromoCode)
+	}
+	if promo.StartsAt != nil && promo.EndsAt != nil && !promo.StartsAt.Before(*promo.EndsAt) {
+		return fmt.Errorf("%w: starts_at must be before ends_at", models.ErrInvalidPromoCode)
+	}
+
+	return nil
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
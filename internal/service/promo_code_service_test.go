@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestPromoCodeService_Create(t *testing.T) {
+	concertID := uuid.New()
+	startsAt := time.Now().Add(time.Hour)
+	endsAt := startsAt.Add(-time.Minute)
+	zero := 0
+
+	type mockBehavior func(concertRepo *mocks.MockConcertRepository, promoRepo *mocks.MockPromoCodeRepository)
+
+	tests := []struct {
+		name         string
+		promo        models.PromoCode
+		mockBehavior mockBehavior
+		wantCode     string
+		wantErrType  error
+	}{
+		{
+			name: "global percentage code",
+			promo: models.PromoCode{
+				Code:         " spring25 ",
+				DiscountType: models.PromoDiscountPercent,
+				Value:        25,
+				Currency:     "RUB",
+			},
+			mockBehavior: func(_ *mocks.MockConcertRepository, promoRepo *mocks.MockPromoCodeRepository) {
+				promoRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p *models.PromoCode) error {
+						assert.Empty(t, p.Currency)
+						p.ID = uuid.New()
+						p.Active = true
+						return nil
+					})
+			},
+			wantCode: "SPRING25",
+		},
+		{
+			name: "fixed code for a concert",
+			promo: models.PromoCode{
+				Code:         "VIP",
+				ConcertID:    &concertID,
+				DiscountType: models.PromoDiscountFixed,
+				Value:        50000,
+				Currency:     "rub",
+			},
+			mockBehavior: func(concertRepo *mocks.MockConcertRepository, promoRepo *mocks.MockPromoCodeRepository) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID, Price: models.NewMoney(300000, "RUB")}, nil)
+				promoRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantCode: "VIP",
+		},
+		{
+			name: "fixed code in another currency than the concert",
+			promo: models.PromoCode{
+				Code:         "VIP",
+				ConcertID:    &concertID,
+				DiscountType: models.PromoDiscountFixed,
+				Value:        500,
+				Currency:     "USD",
+			},
+			mockBehavior: func(concertRepo *mocks.MockConcertRepository, _ *mocks.MockPromoCodeRepository) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID, Price: models.NewMoney(300000, "RUB")}, nil)
+			},
+			wantErrType: models.ErrInvalidPromoCode,
+		},
+		{
+			name:        "full percentage discount",
+			promo:       models.PromoCode{Code: "FREE", DiscountType: models.PromoDiscountPercent, Value: 100},
+			wantErrType: models.ErrInvalidPromoCode,
+		},
+		{
+			name:        "fixed discount without currency",
+			promo:       models.PromoCode{Code: "MINUS", DiscountType: models.PromoDiscountFixed, Value: 1000},
+			wantErrType: models.ErrInvalidPromoCode,
+		},
+		{
+			name: "non-positive usage cap",
+			promo: models.PromoCode{
+				Code:         "SPRING",
+				DiscountType: models.PromoDiscountPercent,
+				Value:        10,
+				MaxUses:      &zero,
+			},
+			wantErrType: models.ErrInvalidPromoCode,
+		},
+		{
+			name: "validity window ends before it starts",
+			promo: models.PromoCode{
+				Code:         "SPRING",
+				DiscountType: models.PromoDiscountPercent,
+				Value:        10,
+				StartsAt:     &startsAt,
+				EndsAt:       &endsAt,
+			},
+			wantErrType: models.ErrInvalidPromoCode,
+		},
+		{
+			name: "concert not found",
+			promo: models.PromoCode{
+				Code:         "SPRING",
+				ConcertID:    &concertID,
+				DiscountType: models.PromoDiscountPercent,
+				Value:        10,
+			},
+			mockBehavior: func(concertRepo *mocks.MockConcertRepository, _ *mocks.MockPromoCodeRepository) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErrType: models.ErrNotFound,
+		},
+		{
+			name:  "duplicate code",
+			promo: models.PromoCode{Code: "SPRING", DiscountType: models.PromoDiscountPercent, Value: 10},
+			mockBehavior: func(_ *mocks.MockConcertRepository, promoRepo *mocks.MockPromoCodeRepository) {
+				promoRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(models.ErrAlreadyExists)
+			},
+			wantErrType: models.ErrAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			promoRepo := mocks.NewMockPromoCodeRepository(ctrl)
+			if tt.mockBehavior != nil {
+				tt.mockBehavior(concertRepo, promoRepo)
+			}
+
+			s := NewPromoCodeService(testLogger(), concertRepo, promoRepo)
+
+			got, err := s.Create(context.Background(), tt.promo)
+			if tt.wantErrType != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantErrType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, got.Code)
+		})
+	}
+}
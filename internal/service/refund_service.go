@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/payment"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+type RefundService struct {
+	logger       *slog.Logger
+	bookingRepo  repository.BookingRepository
+	concertRepo  repository.ConcertRepository
+	refundRepo   repository.RefundRepository
+	paymentRepo  repository.PaymentRepository
+	ledgerRepo   repository.LedgerRepository
+	bookings     BookingSettler
+	provider     payment.PaymentProvider
+	manager      TxManager
+	policy       models.RefundPolicy
+	claimTimeout time.Duration
+}
+
+func NewRefundService(
+	logger *slog.Logger,
+	bookingRepo repository.BookingRepository,
+	concertRepo repository.ConcertRepository,
+	refundRepo repository.RefundRepository,
+	paymentRepo repository.PaymentRepository,
+	ledgerRepo repository.LedgerRepository,
+	bookings BookingSettler,
+	provider payment.PaymentProvider,
+	manager TxManager,
+	policy models.RefundPolicy,
+	claimTimeout time.Duration,
+) *RefundService {
+	return &RefundService{
+		logger:       logger,
+		bookingRepo:  bookingRepo,
+		concertRepo:  concertRepo,
+		refundRepo:   refundRepo,
+		paymentRepo:  paymentRepo,
+		ledgerRepo:   ledgerRepo,
+		bookings:     bookings,
+		provider:     provider,
+		manager:      manager,
+		policy:       policy,
+		claimTimeout: claimTimeout,
+	}
+}
+
+func (s *RefundService) Request(ctx context.Context, userID, bookingID uuid.UUID) (*models.Refund, error) {
+	return s.create(ctx, userID, bookingID, false)
+}
+
+func (s *RefundService) Issue(ctx context.Context, bookingID uuid.UUID) (*models.Refund, error) {
+	return s.create(ctx, uuid.Nil, bookingID, true)
+}
+
+func (s *RefundService) create(ctx context.Context, userID, bookingID uuid.UUID, admin bool) (*models.Refund, error) {
+	var refund *models.Refund
+
+	err := withTx(ctx, s.manager, func(ctx context.Context) error {
+		booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+
+		if !admin && booking.UserID != userID {
+			return models.ErrForbidden
+		}
+
+		if booking.Status != models.BookingStatusConfirmed {
+			return models.ErrNotRefundable
+		}
+
+		concert, err := s.concertRepo.GetByID(ctx, booking.ConcertID)
+		if err != nil {
+			return fmt.Errorf("failed to get concert: %w", err)
+		}
+
+		amount, reason := booking.Price, models.RefundReasonAdminIssued
+		switch {
+		case concert.Status == models.ConcertStatusCancelled:
+			reason = models.RefundReasonConcertCancelled
+		case !admin:
+			reason = models.RefundReasonCustomerRequest
+			amount, err = s.policy.Amount(booking.Price, concert.Date, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		refund = &models.Refund{
+			BookingID: booking.ID,
+			UserID:    booking.UserID,
+			ConcertID: booking.ConcertID,
+			Amount:    amount,
+			Reason:    reason,
+			Status:    models.RefundStatusPending,
+		}
+		if err := s.refundRepo.Create(ctx, refund); err != nil {
+			return err
+		}
+
+		if err := postLedger(ctx, s.ledgerRepo, refundTransaction(refund, booking)); err != nil {
+			return err
+		}
+
+		_, err = s.bookings.ReleaseBooking(ctx, booking.UserID, booking.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "refund requested",
+		"refund_id", refund.ID,
+		"booking_id", refund.BookingID,
+		"amount", refund.Amount.Amount,
+		"reason", refund.Reason,
+	)
+
+	return refund, nil
+}
+
+func (s *RefundService) Get(ctx context.Context, userID, refundID uuid.UUID) (*models.Refund, error) {
+	refund, err := s.refundRepo.GetByID(ctx, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.UserID != userID {
+		return nil, models.ErrForbidden
+	}
+
+	return refund, nil
+}
+
+func (s *RefundService) GetUserRefunds(ctx context.Context, userID uuid.UUID) ([]models.Refund, error) {
+	return s.refundRepo.GetByUser(ctx, userID)
+}
+
+func (s *RefundService) GetConcertRefunds(ctx context.Context, concertID uuid.UUID) ([]models.Refund, error) {
+	return s.refundRepo.GetByConcert(ctx, concertID)
+}
+
+func (s *RefundService) Retry(ctx context.Context, refundID uuid.UUID) (*models.Refund, error) {
+	refund, err := s.refundRepo.GetByID(ctx, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.Status != models.RefundStatusFailed {
+		return nil, models.ErrRefundNotFailed
+	}
+
+	if err := s.refundRepo.Retry(ctx, refundID); err != nil {
+		return nil, err
+	}
+
+	refund.Status = models.RefundStatusPending
+	refund.FailureReason = nil
+	refund.ProcessedAt = nil
+
+	s.logger.InfoContext(ctx, "refund scheduled for retry", "refund_id", refundID)
+
+	return refund, nil
+}
+
+func (s *RefundService) ProcessPending(ctx context.Context, limit int) (int, error) {
+	refunds, err := s.refundRepo.Claim(ctx, limit, time.Now().Add(-s.claimTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range refunds {
+		if err := s.process(ctx, &refunds[i]); err != nil {
+			s.logger.ErrorContext(ctx, "failed to process refund", "refund_id", refunds[i].ID, "error", err)
+		}
+	}
+
+	return len(refunds), nil
+}
+
+func (s *RefundService) process(ctx context.Context, refund *models.Refund) error {
+	intent, err := s.paymentRepo.GetActiveByBooking(ctx, refund.BookingID)
+	if err != nil {
+		if !errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+		return s.fail(ctx, refund, "no payment found for booking")
+	}
+
+	if intent.Status != models.PaymentStatusSucceeded || intent.ProviderRef == nil {
+		return s.fail(ctx, refund, "payment is not settled")
+	}
+
+	result, err := s.provider.Refund(ctx, payment.RefundRequest{
+		Reference:  refund.ID.String(),
+		PaymentRef: *intent.ProviderRef,
+		Amount:     refund.Amount,
+	})
+	if err != nil {
+		return s.fail(ctx, refund, err.Error())
+	}
+
+	err = s.manager.WithTx(ctx, func(ctx context.Context) error {
+		err := s.refundRepo.Finish(ctx, refund.ID, models.RefundStatusSucceeded, &result.ProviderRef, nil)
+		if err != nil {
+			return err
+		}
+
+		return postLedger(ctx, s.ledgerRepo, refundPayoutTransaction(refund))
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "refund completed", "refund_id", refund.ID, "booking_id", refund.BookingID)
+	return nil
+}
+
+func (s *RefundService) fail(ctx context.Context, refund *models.Refund, reason string) error {
+	s.logger.WarnContext(ctx, "refund failed", "refund_id", refund.ID, "reason", reason)
+	return s.refundRepo.Finish(ctx, refund.ID, models.RefundStatusFailed, nil, &reason)
+}
@@ -0,0 +1,717 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/payment"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+var testRefundPolicy = models.RefundPolicy{
+	FullWindow:     7 * 24 * time.Hour,
+	PartialWindow:  48 * time.Hour,
+	PartialPercent: 50,
+}
+
+const testRefundClaimTimeout = 5 * time.Minute
+
+func TestRefundService_Request(t *testing.T) {
+	userID := uuid.New()
+	bookingID := uuid.New()
+	concertID := uuid.New()
+
+	booking := func() *models.Booking {
+		return &models.Booking{
+			ID:        bookingID,
+			UserID:    userID,
+			ConcertID: concertID,
+			Price:     models.NewMoney(300000, "RUB"),
+			Status:    models.BookingStatusConfirmed,
+		}
+	}
+	concertIn := func(left time.Duration) *models.Concert {
+		return &models.Concert{ID: concertID, Date: time.Now().Add(left), Status: models.ConcertStatusOnSale}
+	}
+
+	type mockBehavior func(
+		bookingRepo *mocks.MockBookingRepository,
+		concertRepo *mocks.MockConcertRepository,
+		refundRepo *mocks.MockRefundRepository,
+		ledgerRepo *mocks.MockLedgerRepository,
+		bookings *mocks.MockBookingSettler,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantAmount   int64
+		wantReason   string
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "full refund before full window",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				refundRepo *mocks.MockRefundRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				bookings *mocks.MockBookingSettler,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concertIn(30*24*time.Hour), nil)
+				refundRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, refund *models.Refund) error {
+						assert.Equal(t, models.RefundStatusPending, refund.Status)
+						refund.ID = uuid.New()
+						return nil
+					})
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionRefund, txs[0].Type)
+						assert.NoError(t, txs[0].Validate())
+						return nil
+					})
+				bookings.EXPECT().
+					ReleaseBooking(gomock.Any(), userID, bookingID).
+					Return(&models.Booking{ID: bookingID, Status: models.BookingStatusCancelled}, nil)
+			},
+			wantAmount: 300000,
+			wantReason: models.RefundReasonCustomerRequest,
+			wantErr:    false,
+		},
+		{
+			name: "partial refund inside partial window",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				refundRepo *mocks.MockRefundRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				bookings *mocks.MockBookingSettler,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concertIn(72*time.Hour), nil)
+				refundRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionRefund, txs[0].Type)
+						assert.NoError(t, txs[0].Validate())
+						return nil
+					})
+				bookings.EXPECT().
+					ReleaseBooking(gomock.Any(), userID, bookingID).
+					Return(&models.Booking{ID: bookingID, Status: models.BookingStatusCancelled}, nil)
+			},
+			wantAmount: 150000,
+			wantReason: models.RefundReasonCustomerRequest,
+			wantErr:    false,
+		},
+		{
+			name: "cancelled concert is refunded in full",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				refundRepo *mocks.MockRefundRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				bookings *mocks.MockBookingSettler,
+				txManager *mocks.MockTxManager,
+			) {
+				concert := concertIn(time.Hour)
+				concert.Status = models.ConcertStatusCancelled
+
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concert, nil)
+				refundRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionRefund, txs[0].Type)
+						assert.NoError(t, txs[0].Validate())
+						return nil
+					})
+				bookings.EXPECT().
+					ReleaseBooking(gomock.Any(), userID, bookingID).
+					Return(&models.Booking{ID: bookingID, Status: models.BookingStatusCancelled}, nil)
+			},
+			wantAmount: 300000,
+			wantReason: models.RefundReasonConcertCancelled,
+			wantErr:    false,
+		},
+		{
+			name: "refund window closed",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concertIn(24*time.Hour), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrRefundWindowClosed,
+		},
+		{
+			name: "concert already passed",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concertIn(-time.Hour), nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrConcertPassed,
+		},
+		{
+			name: "booking of another user",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				txManager *mocks.MockTxManager,
+			) {
+				b := booking()
+				b.UserID = uuid.New()
+
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(b, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrForbidden,
+		},
+		{
+			name: "pending booking is not refundable",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				_ *mocks.MockConcertRepository,
+				_ *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				txManager *mocks.MockTxManager,
+			) {
+				b := booking()
+				b.Status = models.BookingStatusPending
+
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(b, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotRefundable,
+		},
+		{
+			name: "already refunded",
+			mockBehavior: func(
+				bookingRepo *mocks.MockBookingRepository,
+				concertRepo *mocks.MockConcertRepository,
+				refundRepo *mocks.MockRefundRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockBookingSettler,
+				txManager *mocks.MockTxManager,
+			) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				bookingRepo.EXPECT().
+					GetByID(gomock.Any(), bookingID).
+					Return(booking(), nil)
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(concertIn(30*24*time.Hour), nil)
+				refundRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(models.ErrAlreadyExists)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bookingRepo := mocks.NewMockBookingRepository(ctrl)
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			refundRepo := mocks.NewMockRefundRepository(ctrl)
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			bookings := mocks.NewMockBookingSettler(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(bookingRepo, concertRepo, refundRepo, ledgerRepo, bookings, txManager)
+
+			s := NewRefundService(
+				testLogger(),
+				bookingRepo,
+				concertRepo,
+				refundRepo,
+				mocks.NewMockPaymentRepository(ctrl),
+				ledgerRepo,
+				bookings,
+				mocks.NewMockPaymentProvider(ctrl),
+				txManager,
+				testRefundPolicy,
+				testRefundClaimTimeout,
+			)
+
+			got, err := s.Request(context.Background(), userID, bookingID)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, models.NewMoney(tt.wantAmount, "RUB"), got.Amount)
+			assert.Equal(t, tt.wantReason, got.Reason)
+			assert.Equal(t, userID, got.UserID)
+		})
+	}
+}
+
+func TestRefundService_Issue(t *testing.T) {
+	userID := uuid.New()
+	bookingID := uuid.New()
+	concertID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bookingRepo := mocks.NewMockBookingRepository(ctrl)
+	concertRepo := mocks.NewMockConcertRepository(ctrl)
+	refundRepo := mocks.NewMockRefundRepository(ctrl)
+	ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+	bookings := mocks.NewMockBookingSettler(ctrl)
+	txManager := mocks.NewMockTxManager(ctrl)
+
+	txManager.EXPECT().
+		WithTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	bookingRepo.EXPECT().
+		GetByID(gomock.Any(), bookingID).
+		Return(&models.Booking{
+			ID:        bookingID,
+			UserID:    userID,
+			ConcertID: concertID,
+			Price:     models.NewMoney(300000, "RUB"),
+			Status:    models.BookingStatusConfirmed,
+		}, nil)
+	concertRepo.EXPECT().
+		GetByID(gomock.Any(), concertID).
+		Return(&models.Concert{ID: concertID, Date: time.Now().Add(time.Hour), Status: models.ConcertStatusOnSale}, nil)
+	refundRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(nil)
+	ledgerRepo.EXPECT().
+		Post(gomock.Any(), gomock.Any()).
+		Return(nil)
+	bookings.EXPECT().
+		ReleaseBooking(gomock.Any(), userID, bookingID).
+		Return(&models.Booking{ID: bookingID, Status: models.BookingStatusCancelled}, nil)
+
+	s := NewRefundService(
+		testLogger(),
+		bookingRepo,
+		concertRepo,
+		refundRepo,
+		mocks.NewMockPaymentRepository(ctrl),
+		ledgerRepo,
+		bookings,
+		mocks.NewMockPaymentProvider(ctrl),
+		txManager,
+		testRefundPolicy,
+		testRefundClaimTimeout,
+	)
+
+	got, err := s.Issue(context.Background(), bookingID)
+	require.NoError(t, err)
+	assert.Equal(t, models.NewMoney(300000, "RUB"), got.Amount)
+	assert.Equal(t, models.RefundReasonAdminIssued, got.Reason)
+}
+
+func TestRefundService_ProcessPending(t *testing.T) {
+	refundID := uuid.New()
+	bookingID := uuid.New()
+	paymentRef := "pi_1"
+
+	claimed := func() []models.Refund {
+		return []models.Refund{{
+			ID:        refundID,
+			BookingID: bookingID,
+			Amount:    models.NewMoney(150000, "RUB"),
+			Status:    models.RefundStatusProcessing,
+		}}
+	}
+	settled := &models.PaymentIntent{Status: models.PaymentStatusSucceeded, ProviderRef: &paymentRef}
+
+	type mockBehavior func(
+		refundRepo *mocks.MockRefundRepository,
+		paymentRepo *mocks.MockPaymentRepository,
+		ledgerRepo *mocks.MockLedgerRepository,
+		provider *mocks.MockPaymentProvider,
+		txManager *mocks.MockTxManager,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "refunds through provider",
+			mockBehavior: func(
+				refundRepo *mocks.MockRefundRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				refundRepo.EXPECT().
+					Claim(gomock.Any(), 50, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, staleBefore time.Time) ([]models.Refund, error) {
+						assert.WithinDuration(t, time.Now().Add(-testRefundClaimTimeout), staleBefore, time.Minute)
+						return claimed(), nil
+					})
+				paymentRepo.EXPECT().
+					GetActiveByBooking(gomock.Any(), bookingID).
+					Return(settled, nil)
+				provider.EXPECT().
+					Refund(gomock.Any(), payment.RefundRequest{
+						Reference:  refundID.String(),
+						PaymentRef: paymentRef,
+						Amount:     models.NewMoney(150000, "RUB"),
+					}).
+					Return(&payment.RefundResult{ProviderRef: "re_1"}, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				refundRepo.EXPECT().
+					Finish(gomock.Any(), refundID, models.RefundStatusSucceeded, gomock.Any(), nil).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ models.RefundStatus, ref, _ *string) error {
+						require.NotNil(t, ref)
+						assert.Equal(t, "re_1", *ref)
+						return nil
+					})
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, txs []models.LedgerTransaction) error {
+						require.Len(t, txs, 1)
+						assert.Equal(t, models.LedgerTransactionRefundPayout, txs[0].Type)
+						return nil
+					})
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "failed finish leaves refund claimed for another attempt",
+			mockBehavior: func(
+				refundRepo *mocks.MockRefundRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				ledgerRepo *mocks.MockLedgerRepository,
+				provider *mocks.MockPaymentProvider,
+				txManager *mocks.MockTxManager,
+			) {
+				refundRepo.EXPECT().
+					Claim(gomock.Any(), 50, gomock.Any()).
+					Return(claimed(), nil)
+				paymentRepo.EXPECT().
+					GetActiveByBooking(gomock.Any(), bookingID).
+					Return(settled, nil)
+				provider.EXPECT().
+					Refund(gomock.Any(), gomock.Any()).
+					Return(&payment.RefundResult{ProviderRef: "re_1"}, nil)
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				refundRepo.EXPECT().
+					Finish(gomock.Any(), refundID, models.RefundStatusSucceeded, gomock.Any(), nil).
+					Return(nil)
+				ledgerRepo.EXPECT().
+					Post(gomock.Any(), gomock.Any()).
+					Return(errors.New("db error"))
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "booking without payment fails refund",
+			mockBehavior: func(
+				refundRepo *mocks.MockRefundRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPaymentProvider,
+				_ *mocks.MockTxManager,
+			) {
+				refundRepo.EXPECT().
+					Claim(gomock.Any(), 50, gomock.Any()).
+					Return(claimed(), nil)
+				paymentRepo.EXPECT().
+					GetActiveByBooking(gomock.Any(), bookingID).
+					Return(nil, models.ErrNotFound)
+				refundRepo.EXPECT().
+					Finish(gomock.Any(), refundID, models.RefundStatusFailed, nil, gomock.Any()).
+					Return(nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "provider error fails refund",
+			mockBehavior: func(
+				refundRepo *mocks.MockRefundRepository,
+				paymentRepo *mocks.MockPaymentRepository,
+				_ *mocks.MockLedgerRepository,
+				provider *mocks.MockPaymentProvider,
+				_ *mocks.MockTxManager,
+			) {
+				refundRepo.EXPECT().
+					Claim(gomock.Any(), 50, gomock.Any()).
+					Return(claimed(), nil)
+				paymentRepo.EXPECT().
+					GetActiveByBooking(gomock.Any(), bookingID).
+					Return(settled, nil)
+				provider.EXPECT().
+					Refund(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrPaymentProvider)
+				refundRepo.EXPECT().
+					Finish(gomock.Any(), refundID, models.RefundStatusFailed, nil, gomock.Any()).
+					Return(nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "nothing pending",
+			mockBehavior: func(
+				refundRepo *mocks.MockRefundRepository,
+				_ *mocks.MockPaymentRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPaymentProvider,
+				_ *mocks.MockTxManager,
+			) {
+				refundRepo.EXPECT().
+					Claim(gomock.Any(), 50, gomock.Any()).
+					Return(nil, nil)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "repository error",
+			mockBehavior: func(
+				refundRepo *mocks.MockRefundRepository,
+				_ *mocks.MockPaymentRepository,
+				_ *mocks.MockLedgerRepository,
+				_ *mocks.MockPaymentProvider,
+				_ *mocks.MockTxManager,
+			) {
+				refundRepo.EXPECT().
+					Claim(gomock.Any(), 50, gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			refundRepo := mocks.NewMockRefundRepository(ctrl)
+			paymentRepo := mocks.NewMockPaymentRepository(ctrl)
+			ledgerRepo := mocks.NewMockLedgerRepository(ctrl)
+			provider := mocks.NewMockPaymentProvider(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(refundRepo, paymentRepo, ledgerRepo, provider, txManager)
+
+			s := NewRefundService(
+				testLogger(),
+				mocks.NewMockBookingRepository(ctrl),
+				mocks.NewMockConcertRepository(ctrl),
+				refundRepo,
+				paymentRepo,
+				ledgerRepo,
+				mocks.NewMockBookingSettler(ctrl),
+				provider,
+				txManager,
+				testRefundPolicy,
+				testRefundClaimTimeout,
+			)
+
+			got, err := s.ProcessPending(context.Background(), 50)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRefundService_Retry(t *testing.T) {
+	refundID := uuid.New()
+
+	tests := []struct {
+		name        string
+		status      models.RefundStatus
+		wantErr     bool
+		wantErrType error
+	}{
+		{
+			name:    "failed refund goes back to pending",
+			status:  models.RefundStatusFailed,
+			wantErr: false,
+		},
+		{
+			name:        "succeeded refund cannot be retried",
+			status:      models.RefundStatusSucceeded,
+			wantErr:     true,
+			wantErrType: models.ErrRefundNotFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reason := "card_declined"
+			refundRepo := mocks.NewMockRefundRepository(ctrl)
+			refundRepo.EXPECT().
+				GetByID(gomock.Any(), refundID).
+				Return(&models.Refund{ID: refundID, Status: tt.status, FailureReason: &reason}, nil)
+			if !tt.wantErr {
+				refundRepo.EXPECT().
+					Retry(gomock.Any(), refundID).
+					Return(nil)
+			}
+
+			s := NewRefundService(
+				testLogger(),
+				mocks.NewMockBookingRepository(ctrl),
+				mocks.NewMockConcertRepository(ctrl),
+				refundRepo,
+				mocks.NewMockPaymentRepository(ctrl),
+				mocks.NewMockLedgerRepository(ctrl),
+				mocks.NewMockBookingSettler(ctrl),
+				mocks.NewMockPaymentProvider(ctrl),
+				mocks.NewMockTxManager(ctrl),
+				testRefundPolicy,
+				testRefundClaimTimeout,
+			)
+
+			got, err := s.Retry(context.Background(), refundID)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantErrType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, models.RefundStatusPending, got.Status)
+			assert.Nil(t, got.FailureReason)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import "context"
+
+type txHooksKey struct{}
+
+type txHooks struct {
+	fns []func(ctx context.Context)
+}
+
+func withTx(ctx context.Context, manager TxManager, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txHooksKey{}).(*txHooks); ok {
+		return manager.WithTx(ctx, fn)
+	}
+
+	hooks := &txHooks{}
+	if err := manager.WithTx(context.WithValue(ctx, txHooksKey{}, hooks), fn); err != nil {
+		return err
+	}
+
+	for _, hook := range hooks.fns {
+		hook(ctx)
+	}
+
+	return nil
+}
+
+func afterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if hooks, ok := ctx.Value(txHooksKey{}).(*txHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+
+	fn(ctx)
+}
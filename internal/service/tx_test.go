@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestWithTx_AfterCommit(t *testing.T) {
+	errRollback := errors.New("rollback")
+
+	tests := []struct {
+		name      string
+		txErr     error
+		wantCalls []string
+		wantErr   error
+	}{
+		{
+			name:      "hooks run after commit",
+			wantCalls: []string{"tx", "hook"},
+		},
+		{
+			name:      "hooks dropped on rollback",
+			txErr:     errRollback,
+			wantCalls: []string{"tx"},
+			wantErr:   errRollback,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var calls []string
+			txManager := mocks.NewMockTxManager(ctrl)
+			txManager.EXPECT().
+				WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					if err := fn(ctx); err != nil {
+						return err
+					}
+					calls = append(calls, "tx")
+					return tt.txErr
+				})
+
+			err := withTx(context.Background(), txManager, func(ctx context.Context) error {
+				afterCommit(ctx, func(context.Context) { calls = append(calls, "hook") })
+				return nil
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestAfterCommit_WithoutTx(t *testing.T) {
+	called := false
+	afterCommit(context.Background(), func(context.Context) { called = true })
+	assert.True(t, called)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+const defaultVenueTimezone = "UTC"
+
+type VenueService struct {
+	logger    *slog.Logger
+	venueRepo repository.VenueRepository
+	manager   TxManager
+}
+
+func NewVenueService(logger *slog.Logger, venueRepo repository.VenueRepository, manager TxManager) *VenueService {
+	return &VenueService{
+		logger:    logger,
+		venueRepo: venueRepo,
+		manager:   manager,
+	}
+}
+
+func (s *VenueService) Create(ctx context.Context, venue models.Venue) (*models.Venue, error) {
+	venue.Name = strings.TrimSpace(venue.Name)
+	venue.Address = strings.TrimSpace(venue.Address)
+	if venue.Timezone == "" {
+		venue.Timezone = defaultVenueTimezone
+	}
+
+	if venue.Name == "" {
+		return nil, models.ErrInvalidVenue
+	}
+
+	if _, err := time.LoadLocation(venue.Timezone); err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", models.ErrInvalidVenue, venue.Timezone)
+	}
+
+	if err := s.venueRepo.Create(ctx, &venue); err != nil {
+		return nil, err
+	}
+
+	return &venue, nil
+}
+
+func (s *VenueService) GetAll(ctx context.Context) ([]models.Venue, error) {
+	return s.venueRepo.GetAll(ctx)
+}
+
+func (s *VenueService) GetByID(ctx context.Context, id uuid.UUID) (*models.Venue, error) {
+	venue, err := s.venueRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	venue.Sections, err = s.venueRepo.GetLayout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (s *VenueService) AddSection(
+	ctx context.Context,
+	venueID uuid.UUID,
+	name string,
+	rows []models.VenueRowSpec,
+) (*models.VenueSection, error) {
+	section := models.VenueSection{
+		VenueID: venueID,
+		Name:    strings.TrimSpace(name),
+		Rows:    make([]models.VenueRow, 0, len(rows)),
+	}
+
+	if section.Name == "" || len(rows) == 0 {
+		return nil, models.ErrInvalidVenue
+	}
+
+	labels := make(map[string]struct{}, len(rows))
+	for _, spec := range rows {
+		label := strings.TrimSpace(spec.Label)
+		if _, dup := labels[label]; label == "" || dup || spec.Seats < 1 {
+			return nil, models.ErrInvalidVenue
+		}
+		labels[label] = struct{}{}
+
+		row := models.VenueRow{Label: label, Seats: make([]models.VenueSeat, spec.Seats)}
+		for i := range row.Seats {
+			row.Seats[i].Number = i + 1
+		}
+		section.Rows = append(section.Rows, row)
+	}
+
+	err := s.manager.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.venueRepo.GetByIDForUpdate(ctx, venueID); err != nil {
+			return err
+		}
+
+		return s.venueRepo.CreateSection(ctx, &section)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &section, nil
+}
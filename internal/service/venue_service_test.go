@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestVenueService_Create(t *testing.T) {
+	type mockBehavior func(repo *mocks.MockVenueRepository)
+
+	tests := []struct {
+		name         string
+		input        models.Venue
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+		checkResult  func(t *testing.T, venue *models.Venue)
+	}{
+		{
+			name:  "success with default timezone",
+			input: models.Venue{Name: " VK Stadium ", Address: "Moscow"},
+			mockBehavior: func(repo *mocks.MockVenueRepository) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, v *models.Venue) error {
+						v.ID = uuid.New()
+						v.CreatedAt = time.Now()
+						return nil
+					})
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, venue *models.Venue) {
+				t.Helper()
+				assert.Equal(t, "VK Stadium", venue.Name)
+				assert.Equal(t, defaultVenueTimezone, venue.Timezone)
+				assert.NotEqual(t, uuid.Nil, venue.ID)
+			},
+		},
+		{
+			name:         "unknown timezone",
+			input:        models.Venue{Name: "Base", Timezone: "Mars/Olympus"},
+			mockBehavior: func(_ *mocks.MockVenueRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidVenue,
+		},
+		{
+			name:         "empty name",
+			input:        models.Venue{Name: "   "},
+			mockBehavior: func(_ *mocks.MockVenueRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidVenue,
+		},
+		{
+			name:  "duplicate name",
+			input: models.Venue{Name: "Base", Timezone: "Europe/Moscow"},
+			mockBehavior: func(repo *mocks.MockVenueRepository) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(models.ErrAlreadyExists)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			venueRepo := mocks.NewMockVenueRepository(ctrl)
+			tt.mockBehavior(venueRepo)
+
+			s := NewVenueService(testLogger(), venueRepo, nil)
+
+			got, err := s.Create(context.Background(), tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.checkResult != nil {
+				tt.checkResult(t, got)
+			}
+		})
+	}
+}
+
+func TestVenueService_AddSection(t *testing.T) {
+	venueID := uuid.New()
+
+	type mockBehavior func(repo *mocks.MockVenueRepository, txManager *mocks.MockTxManager)
+
+	tests := []struct {
+		name         string
+		section      string
+		rows         []models.VenueRowSpec
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+		checkResult  func(t *testing.T, section *models.VenueSection)
+	}{
+		{
+			name:    "success",
+			section: "Parterre",
+			rows:    []models.VenueRowSpec{{Label: "A", Seats: 3}, {Label: "B", Seats: 2}},
+			mockBehavior: func(repo *mocks.MockVenueRepository, txManager *mocks.MockTxManager) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), venueID).
+					Return(&models.Venue{ID: venueID}, nil)
+				repo.EXPECT().
+					CreateSection(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+			checkResult: func(t *testing.T, section *models.VenueSection) {
+				t.Helper()
+				assert.Equal(t, venueID, section.VenueID)
+				require.Len(t, section.Rows, 2)
+				require.Len(t, section.Rows[0].Seats, 3)
+				assert.Equal(t, []int{1, 2, 3}, []int{
+					section.Rows[0].Seats[0].Number,
+					section.Rows[0].Seats[1].Number,
+					section.Rows[0].Seats[2].Number,
+				})
+				assert.Len(t, section.Rows[1].Seats, 2)
+			},
+		},
+		{
+			name:         "duplicate row label",
+			section:      "Parterre",
+			rows:         []models.VenueRowSpec{{Label: "A", Seats: 3}, {Label: "A", Seats: 2}},
+			mockBehavior: func(_ *mocks.MockVenueRepository, _ *mocks.MockTxManager) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidVenue,
+		},
+		{
+			name:         "no rows",
+			section:      "Parterre",
+			mockBehavior: func(_ *mocks.MockVenueRepository, _ *mocks.MockTxManager) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidVenue,
+		},
+		{
+			name:    "venue not found",
+			section: "Parterre",
+			rows:    []models.VenueRowSpec{{Label: "A", Seats: 3}},
+			mockBehavior: func(repo *mocks.MockVenueRepository, txManager *mocks.MockTxManager) {
+				txManager.EXPECT().
+					WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				repo.EXPECT().
+					GetByIDForUpdate(gomock.Any(), venueID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			venueRepo := mocks.NewMockVenueRepository(ctrl)
+			txManager := mocks.NewMockTxManager(ctrl)
+			tt.mockBehavior(venueRepo, txManager)
+
+			s := NewVenueService(testLogger(), venueRepo, txManager)
+
+			got, err := s.AddSection(context.Background(), venueID, tt.section, tt.rows)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.checkResult != nil {
+				tt.checkResult(t, got)
+			}
+		})
+	}
+}
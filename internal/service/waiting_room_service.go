@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/cache"
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+type WaitingRoomService struct {
+	logger        *slog.Logger
+	concertRepo   repository.ConcertRepository
+	queue         cache.WaitingRoomRepository
+	admitInterval time.Duration
+	admissionTTL  time.Duration
+}
+
+func NewWaitingRoomService(
+	logger *slog.Logger,
+	concertRepo repository.ConcertRepository,
+	queue cache.WaitingRoomRepository,
+	admitInterval time.Duration,
+	admissionTTL time.Duration,
+) *WaitingRoomService {
+	return &WaitingRoomService{
+		logger:        logger,
+		concertRepo:   concertRepo,
+		queue:         queue,
+		admitInterval: admitInterval,
+		admissionTTL:  admissionTTL,
+	}
+}
+
+func (s *WaitingRoomService) Open(
+	ctx context.Context,
+	concertID uuid.UUID,
+	admitRate int,
+) (*models.WaitingRoom, error) {
+	if admitRate < 1 {
+		return nil, models.ErrInvalidWaitingRoom
+	}
+
+	if _, err := s.concertRepo.GetByID(ctx, concertID); err != nil {
+		return nil, err
+	}
+
+	room := models.WaitingRoom{ConcertID: concertID, AdmitRate: admitRate}
+	if err := s.queue.Open(ctx, room); err != nil {
+		return nil, fmt.Errorf("failed to open waiting room: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "waiting room opened", "concert_id", concertID, "admit_rate", admitRate)
+	return &room, nil
+}
+
+func (s *WaitingRoomService) Close(ctx context.Context, concertID uuid.UUID) error {
+	closed, err := s.queue.Close(ctx, concertID)
+	if err != nil {
+		return fmt.Errorf("failed to close waiting room: %w", err)
+	}
+	if !closed {
+		return models.ErrQueueNotActive
+	}
+
+	s.logger.InfoContext(ctx, "waiting room closed", "concert_id", concertID)
+	return nil
+}
+
+func (s *WaitingRoomService) Join(ctx context.Context, userID, concertID uuid.UUID) (*models.QueueTicket, error) {
+	ticket, active, err := s.queue.Join(ctx, concertID, userID, uuid.NewString(), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to join waiting room: %w", err)
+	}
+	if !active {
+		return nil, models.ErrQueueNotActive
+	}
+	return &ticket, nil
+}
+
+func (s *WaitingRoomService) Status(ctx context.Context, userID, concertID uuid.UUID) (*models.QueueTicket, error) {
+	ticket, found, err := s.queue.Status(ctx, concertID, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiting room status: %w", err)
+	}
+	if !found {
+		return nil, models.ErrNotFound
+	}
+	return &ticket, nil
+}
+
+func (s *WaitingRoomService) AdmitWaiting(ctx context.Context) error {
+	rooms, err := s.queue.Active(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list waiting rooms: %w", err)
+	}
+
+	now := time.Now()
+	for _, room := range rooms {
+		admitted, err := s.queue.Admit(ctx, room.ConcertID, s.admitBatch(room.AdmitRate), now.Add(s.admissionTTL), now)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to admit from waiting room", "concert_id", room.ConcertID, "error", err)
+			continue
+		}
+		if admitted > 0 {
+			s.logger.DebugContext(ctx, "admitted from waiting room", "concert_id", room.ConcertID, "count", admitted)
+		}
+	}
+
+	return nil
+}
+
+func (s *WaitingRoomService) admitBatch(admitRate int) int {
+	return max(1, int((int64(admitRate)*int64(s.admitInterval)+int64(time.Minute)-1)/int64(time.Minute)))
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+const (
+	testAdmitInterval = 5 * time.Second
+	testAdmissionTTL  = 10 * time.Minute
+)
+
+func TestWaitingRoomService_Open(t *testing.T) {
+	concertID := uuid.New()
+
+	type mockBehavior func(concertRepo *mocks.MockConcertRepository, queue *mocks.MockWaitingRoomRepository)
+
+	tests := []struct {
+		name         string
+		admitRate    int
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name:      "success",
+			admitRate: 600,
+			mockBehavior: func(concertRepo *mocks.MockConcertRepository, queue *mocks.MockWaitingRoomRepository) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID}, nil)
+				queue.EXPECT().
+					Open(gomock.Any(), models.WaitingRoom{ConcertID: concertID, AdmitRate: 600}).
+					Return(nil)
+			},
+		},
+		{
+			name:         "invalid admit rate",
+			admitRate:    0,
+			mockBehavior: func(_ *mocks.MockConcertRepository, _ *mocks.MockWaitingRoomRepository) {},
+			wantErr:      true,
+			wantErrType:  models.ErrInvalidWaitingRoom,
+		},
+		{
+			name:      "concert not found",
+			admitRate: 600,
+			mockBehavior: func(concertRepo *mocks.MockConcertRepository, _ *mocks.MockWaitingRoomRepository) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			queue := mocks.NewMockWaitingRoomRepository(ctrl)
+			tt.mockBehavior(concertRepo, queue)
+
+			s := NewWaitingRoomService(testLogger(), concertRepo, queue, testAdmitInterval, testAdmissionTTL)
+
+			got, err := s.Open(context.Background(), concertID, tt.admitRate)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.admitRate, got.AdmitRate)
+		})
+	}
+}
+
+func TestWaitingRoomService_Join(t *testing.T) {
+	concertID := uuid.New()
+	userID := uuid.New()
+
+	type mockBehavior func(queue *mocks.MockWaitingRoomRepository)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+		checkResult  func(t *testing.T, ticket *models.QueueTicket)
+	}{
+		{
+			name: "success",
+			mockBehavior: func(queue *mocks.MockWaitingRoomRepository) {
+				queue.EXPECT().
+					Join(gomock.Any(), concertID, userID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(
+						_ context.Context,
+						concertID, _ uuid.UUID,
+						token string,
+						_ time.Time,
+					) (models.QueueTicket, bool, error) {
+						return models.QueueTicket{ConcertID: concertID, Token: token, Position: 42}, true, nil
+					})
+			},
+			checkResult: func(t *testing.T, ticket *models.QueueTicket) {
+				t.Helper()
+				assert.NotEmpty(t, ticket.Token)
+				assert.Equal(t, int64(42), ticket.Position)
+				assert.False(t, ticket.Admitted)
+			},
+		},
+		{
+			name: "waiting room not active",
+			mockBehavior: func(queue *mocks.MockWaitingRoomRepository) {
+				queue.EXPECT().
+					Join(gomock.Any(), concertID, userID, gomock.Any(), gomock.Any()).
+					Return(models.QueueTicket{}, false, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrQueueNotActive,
+		},
+		{
+			name: "redis error",
+			mockBehavior: func(queue *mocks.MockWaitingRoomRepository) {
+				queue.EXPECT().
+					Join(gomock.Any(), concertID, userID, gomock.Any(), gomock.Any()).
+					Return(models.QueueTicket{}, false, assert.AnError)
+			},
+			wantErr:     true,
+			wantErrType: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			queue := mocks.NewMockWaitingRoomRepository(ctrl)
+			tt.mockBehavior(queue)
+
+			s := NewWaitingRoomService(
+				testLogger(),
+				mocks.NewMockConcertRepository(ctrl),
+				queue,
+				testAdmitInterval,
+				testAdmissionTTL,
+			)
+
+			got, err := s.Join(context.Background(), userID, concertID)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.checkResult != nil {
+				tt.checkResult(t, got)
+			}
+		})
+	}
+}
+
+func TestWaitingRoomService_Status(t *testing.T) {
+	concertID := uuid.New()
+	userID := uuid.New()
+
+	type mockBehavior func(queue *mocks.MockWaitingRoomRepository)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "admitted",
+			mockBehavior: func(queue *mocks.MockWaitingRoomRepository) {
+				expiresAt := time.Now().Add(testAdmissionTTL)
+				queue.EXPECT().
+					Status(gomock.Any(), concertID, userID, gomock.Any()).
+					Return(models.QueueTicket{ConcertID: concertID, Admitted: true, ExpiresAt: &expiresAt}, true, nil)
+			},
+		},
+		{
+			name: "ticket not found",
+			mockBehavior: func(queue *mocks.MockWaitingRoomRepository) {
+				queue.EXPECT().
+					Status(gomock.Any(), concertID, userID, gomock.Any()).
+					Return(models.QueueTicket{}, false, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			queue := mocks.NewMockWaitingRoomRepository(ctrl)
+			tt.mockBehavior(queue)
+
+			s := NewWaitingRoomService(
+				testLogger(),
+				mocks.NewMockConcertRepository(ctrl),
+				queue,
+				testAdmitInterval,
+				testAdmissionTTL,
+			)
+
+			got, err := s.Status(context.Background(), userID, concertID)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.Admitted)
+		})
+	}
+}
+
+func TestWaitingRoomService_AdmitWaiting(t *testing.T) {
+	first := uuid.New()
+	second := uuid.New()
+
+	type mockBehavior func(queue *mocks.MockWaitingRoomRepository)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "admits per interval share of rate",
+			mockBehavior: func(queue *mocks.MockWaitingRoomRepository) {
+				queue.EXPECT().
+					Active(gomock.Any()).
+					Return([]models.WaitingRoom{
+						{ConcertID: first, AdmitRate: 600},
+						{ConcertID: second, AdmitRate: 1},
+					}, nil)
+				queue.EXPECT().
+					Admit(gomock.Any(), first, 50, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ int, expiresAt, now time.Time) (int, error) {
+						assert.Equal(t, testAdmissionTTL, expiresAt.Sub(now))
+						return 50, nil
+					})
+				queue.EXPECT().
+					Admit(gomock.Any(), second, 1, gomock.Any(), gomock.Any()).
+					Return(0, nil)
+			},
+		},
+		{
+			name: "failed room does not stop others",
+			mockBehavior: func(queue *mocks.MockWaitingRoomRepository) {
+				queue.EXPECT().
+					Active(gomock.Any()).
+					Return([]models.WaitingRoom{
+						{ConcertID: first, AdmitRate: 120},
+						{ConcertID: second, AdmitRate: 120},
+					}, nil)
+				queue.EXPECT().
+					Admit(gomock.Any(), first, 10, gomock.Any(), gomock.Any()).
+					Return(0, assert.AnError)
+				queue.EXPECT().
+					Admit(gomock.Any(), second, 10, gomock.Any(), gomock.Any()).
+					Return(10, nil)
+			},
+		},
+		{
+			name: "list error",
+			mockBehavior: func(queue *mocks.MockWaitingRoomRepository) {
+				queue.EXPECT().
+					Active(gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			queue := mocks.NewMockWaitingRoomRepository(ctrl)
+			tt.mockBehavior(queue)
+
+			s := NewWaitingRoomService(
+				testLogger(),
+				mocks.NewMockConcertRepository(ctrl),
+				queue,
+				testAdmitInterval,
+				testAdmissionTTL,
+			)
+
+			err := s.AdmitWaiting(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/repository"
+)
+
+type WaitlistService struct {
+	logger       *slog.Logger
+	concertRepo  repository.ConcertRepository
+	waitlistRepo repository.WaitlistRepository
+}
+
+func NewWaitlistService(
+	logger *slog.Logger,
+	concertRepo repository.ConcertRepository,
+	waitlistRepo repository.WaitlistRepository,
+) *WaitlistService {
+	return &WaitlistService{
+		logger:       logger,
+		concertRepo:  concertRepo,
+		waitlistRepo: waitlistRepo,
+	}
+}
+
+func (s *WaitlistService) Join(ctx context.Context, userID, concertID uuid.UUID) (*models.WaitlistEntry, error) {
+	concert, err := s.concertRepo.GetByID(ctx, concertID)
+	if err != nil {
+		return nil, err
+	}
+
+	if concert.Status != models.ConcertStatusSoldOut {
+		return nil, fmt.Errorf("%w: concert is %s", models.ErrWaitlistNotOpen, concert.Status)
+	}
+
+	entry := models.WaitlistEntry{
+		ConcertID: concertID,
+		UserID:    userID,
+		Status:    models.WaitlistStatusWaiting,
+	}
+	if err := s.waitlistRepo.Create(ctx, &entry); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "user joined waitlist", "concert_id", concertID, "user_id", userID)
+
+	return s.waitlistRepo.GetActive(ctx, concertID, userID)
+}
+
+func (s *WaitlistService) Get(ctx context.Context, userID, concertID uuid.UUID) (*models.WaitlistEntry, error) {
+	return s.waitlistRepo.GetActive(ctx, concertID, userID)
+}
+
+func (s *WaitlistService) Leave(ctx context.Context, userID, concertID uuid.UUID) error {
+	if err := s.waitlistRepo.Leave(ctx, concertID, userID); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "user left waitlist", "concert_id", concertID, "user_id", userID)
+	return nil
+}
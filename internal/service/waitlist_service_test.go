@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/yohnnn/booking_service/internal/models"
+	"github.com/yohnnn/booking_service/internal/service/mocks"
+)
+
+func TestWaitlistService_Join(t *testing.T) {
+	userID := uuid.New()
+	concertID := uuid.New()
+
+	type mockBehavior func(
+		concertRepo *mocks.MockConcertRepository,
+		waitlistRepo *mocks.MockWaitlistRepository,
+	)
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+		wantErrType  error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID, Status: models.ConcertStatusSoldOut}, nil)
+				waitlistRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, e *models.WaitlistEntry) error {
+						assert.Equal(t, userID, e.UserID)
+						assert.Equal(t, models.WaitlistStatusWaiting, e.Status)
+						return nil
+					})
+				waitlistRepo.EXPECT().
+					GetActive(gomock.Any(), concertID, userID).
+					Return(&models.WaitlistEntry{
+						ConcertID: concertID,
+						UserID:    userID,
+						Status:    models.WaitlistStatusWaiting,
+						Position:  3,
+					}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "concert not sold out",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockWaitlistRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID, Status: models.ConcertStatusOnSale}, nil)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrWaitlistNotOpen,
+		},
+		{
+			name: "already on waitlist",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				waitlistRepo *mocks.MockWaitlistRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(&models.Concert{ID: concertID, Status: models.ConcertStatusSoldOut}, nil)
+				waitlistRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(models.ErrAlreadyExists)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrAlreadyExists,
+		},
+		{
+			name: "concert not found",
+			mockBehavior: func(
+				concertRepo *mocks.MockConcertRepository,
+				_ *mocks.MockWaitlistRepository,
+			) {
+				concertRepo.EXPECT().
+					GetByID(gomock.Any(), concertID).
+					Return(nil, models.ErrNotFound)
+			},
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			concertRepo := mocks.NewMockConcertRepository(ctrl)
+			waitlistRepo := mocks.NewMockWaitlistRepository(ctrl)
+			tt.mockBehavior(concertRepo, waitlistRepo)
+
+			s := NewWaitlistService(testLogger(), concertRepo, waitlistRepo)
+
+			got, err := s.Join(context.Background(), userID, concertID)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrType != nil {
+					assert.ErrorIs(t, err, tt.wantErrType)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), got.Position)
+		})
+	}
+}
+
+func TestWaitlistService_Leave(t *testing.T) {
+	userID := uuid.New()
+	concertID := uuid.New()
+
+	tests := []struct {
+		name        string
+		repoErr     error
+		wantErr     bool
+		wantErrType error
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+		},
+		{
+			name:        "not waiting",
+			repoErr:     models.ErrNotFound,
+			wantErr:     true,
+			wantErrType: models.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			waitlistRepo := mocks.NewMockWaitlistRepository(ctrl)
+			waitlistRepo.EXPECT().
+				Leave(gomock.Any(), concertID, userID).
+				Return(tt.repoErr)
+
+			s := NewWaitlistService(testLogger(), mocks.NewMockConcertRepository(ctrl), waitlistRepo)
+
+			err := s.Leave(context.Background(), userID, concertID)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantErrType)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type BatchFunc func(ctx context.Context, limit int) (int, error)
+
+type BatchWorker struct {
+	logger    *slog.Logger
+	process   BatchFunc
+	interval  time.Duration
+	batchSize int
+}
+
+func NewBatchWorker(
+	logger *slog.Logger,
+	name string,
+	process BatchFunc,
+	interval time.Duration,
+	batchSize int,
+) *BatchWorker {
+	return &BatchWorker{
+		logger:    logger.With("worker", name),
+		process:   process,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+func (w *BatchWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drain(ctx)
+		}
+	}
+}
+
+func (w *BatchWorker) drain(ctx context.Context) {
+	for {
+		processed, err := w.process(ctx, w.batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.ErrorContext(ctx, "batch failed", "error", err)
+			}
+			return
+		}
+
+		if processed > 0 {
+			w.logger.DebugContext(ctx, "batch processed", "count", processed)
+		}
+
+		if processed < w.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type TaskFunc func(ctx context.Context) error
+
+type PeriodicWorker struct {
+	logger   *slog.Logger
+	task     TaskFunc
+	interval time.Duration
+}
+
+func NewPeriodicWorker(logger *slog.Logger, name string, task TaskFunc, interval time.Duration) *PeriodicWorker {
+	return &PeriodicWorker{
+		logger:   logger.With("worker", name),
+		task:     task,
+		interval: interval,
+	}
+}
+
+func (w *PeriodicWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.task(ctx); err != nil && ctx.Err() == nil {
+				w.logger.ErrorContext(ctx, "task failed", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_concert_id_seat_number_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS bookings_concert_seat_active_idx
+    ON bookings (concert_id, seat_number)
+    WHERE status <> 'CANCELLED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS bookings_concert_seat_active_idx;
+
+ALTER TABLE bookings ADD CONSTRAINT bookings_concert_id_seat_number_key UNIQUE (concert_id, seat_number);
+-- +goose StatementEnd
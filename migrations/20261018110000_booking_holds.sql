@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS bookings_pending_expires_at_idx
+    ON bookings (expires_at)
+    WHERE status = 'PENDING';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS bookings_pending_expires_at_idx;
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS expires_at;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS concerts_date_id_idx ON concerts (date, id);
+CREATE INDEX IF NOT EXISTS concerts_price_id_idx ON concerts (price, id);
+CREATE INDEX IF NOT EXISTS concerts_name_id_idx ON concerts (name, id);
+CREATE INDEX IF NOT EXISTS concerts_place_lower_idx ON concerts (lower(place));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS concerts_place_lower_idx;
+DROP INDEX IF EXISTS concerts_name_id_idx;
+DROP INDEX IF EXISTS concerts_price_id_idx;
+DROP INDEX IF EXISTS concerts_date_id_idx;
+-- +goose StatementEnd
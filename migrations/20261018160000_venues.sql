@@ -0,0 +1,115 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS venues (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL UNIQUE,
+    address TEXT NOT NULL DEFAULT '',
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    capacity INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS venue_sections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    venue_id UUID NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    position INT NOT NULL,
+    UNIQUE (venue_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS venue_rows (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    section_id UUID NOT NULL REFERENCES venue_sections(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    position INT NOT NULL,
+    UNIQUE (section_id, label)
+);
+
+CREATE TABLE IF NOT EXISTS venue_seats (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    venue_id UUID NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    row_id UUID NOT NULL REFERENCES venue_rows(id) ON DELETE CASCADE,
+    number INT NOT NULL,
+    ordinal INT NOT NULL,
+    UNIQUE (row_id, number),
+    UNIQUE (venue_id, ordinal)
+);
+
+INSERT INTO venues (name, capacity)
+SELECT c.place, GREATEST(MAX(c.total_seats), COALESCE(MAX(b.seat_number), 0))
+FROM concerts c
+LEFT JOIN bookings b ON b.concert_id = c.id
+GROUP BY c.place;
+
+INSERT INTO venue_sections (venue_id, name, position)
+SELECT id, 'Main', 1
+FROM venues;
+
+INSERT INTO venue_rows (section_id, label, position)
+SELECT s.id, r::TEXT, r
+FROM venue_sections s
+JOIN venues v ON v.id = s.venue_id
+CROSS JOIN LATERAL generate_series(1, CEIL(v.capacity / 50.0)::INT) AS r;
+
+INSERT INTO venue_seats (venue_id, row_id, number, ordinal)
+SELECT s.venue_id, r.id, n, (r.position - 1) * 50 + n
+FROM venue_rows r
+JOIN venue_sections s ON s.id = r.section_id
+JOIN venues v ON v.id = s.venue_id
+CROSS JOIN LATERAL generate_series(1, LEAST(50, v.capacity - (r.position - 1) * 50)) AS n;
+
+ALTER TABLE concerts ADD COLUMN venue_id UUID REFERENCES venues(id);
+
+UPDATE concerts c
+SET venue_id = v.id
+FROM venues v
+WHERE v.name = c.place;
+
+ALTER TABLE concerts ALTER COLUMN venue_id SET NOT NULL;
+
+DROP INDEX IF EXISTS concerts_place_lower_idx;
+ALTER TABLE concerts DROP COLUMN place;
+CREATE INDEX IF NOT EXISTS concerts_venue_idx ON concerts (venue_id);
+
+ALTER TABLE bookings ADD COLUMN seat_id UUID REFERENCES venue_seats(id);
+
+UPDATE bookings b
+SET seat_id = s.id
+FROM concerts c
+JOIN venue_seats s ON s.venue_id = c.venue_id
+WHERE c.id = b.concert_id AND s.ordinal = b.seat_number;
+
+ALTER TABLE bookings ALTER COLUMN seat_id SET NOT NULL;
+
+DROP INDEX IF EXISTS bookings_concert_seat_active_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS bookings_concert_seat_active_idx
+    ON bookings (concert_id, seat_id)
+    WHERE status <> 'CANCELLED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS bookings_concert_seat_active_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS bookings_concert_seat_active_idx
+    ON bookings (concert_id, seat_number)
+    WHERE status <> 'CANCELLED';
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS seat_id;
+
+ALTER TABLE concerts ADD COLUMN place TEXT;
+
+UPDATE concerts c
+SET place = v.name
+FROM venues v
+WHERE v.id = c.venue_id;
+
+ALTER TABLE concerts ALTER COLUMN place SET NOT NULL;
+DROP INDEX IF EXISTS concerts_venue_idx;
+ALTER TABLE concerts DROP COLUMN IF EXISTS venue_id;
+CREATE INDEX IF NOT EXISTS concerts_place_lower_idx ON concerts (lower(place));
+
+DROP TABLE IF EXISTS venue_seats;
+DROP TABLE IF EXISTS venue_rows;
+DROP TABLE IF EXISTS venue_sections;
+DROP TABLE IF EXISTS venues;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ticket_tiers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    concert_id UUID NOT NULL REFERENCES concerts(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    price DECIMAL(10, 2) NOT NULL,
+    quota INT NOT NULL,
+    available INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (concert_id, name),
+    CHECK (available >= 0 AND available <= quota)
+);
+
+CREATE TABLE IF NOT EXISTS ticket_tier_sections (
+    tier_id UUID NOT NULL REFERENCES ticket_tiers(id) ON DELETE CASCADE,
+    section_id UUID NOT NULL REFERENCES venue_sections(id) ON DELETE CASCADE,
+    concert_id UUID NOT NULL REFERENCES concerts(id) ON DELETE CASCADE,
+    PRIMARY KEY (tier_id, section_id),
+    UNIQUE (concert_id, section_id)
+);
+
+INSERT INTO ticket_tiers (concert_id, name, price, quota, available)
+SELECT id, 'Standard', price, total_seats, available_seats
+FROM concerts;
+
+INSERT INTO ticket_tier_sections (tier_id, section_id, concert_id)
+SELECT t.id, s.id, c.id
+FROM ticket_tiers t
+JOIN concerts c ON c.id = t.concert_id
+JOIN venue_sections s ON s.venue_id = c.venue_id;
+
+ALTER TABLE bookings ADD COLUMN tier_id UUID REFERENCES ticket_tiers(id);
+ALTER TABLE bookings ADD COLUMN price DECIMAL(10, 2);
+
+UPDATE bookings b
+SET tier_id = t.id, price = t.price
+FROM ticket_tiers t
+WHERE t.concert_id = b.concert_id;
+
+ALTER TABLE bookings ALTER COLUMN tier_id SET NOT NULL;
+ALTER TABLE bookings ALTER COLUMN price SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE bookings DROP COLUMN IF EXISTS price;
+ALTER TABLE bookings DROP COLUMN IF EXISTS tier_id;
+
+DROP TABLE IF EXISTS ticket_tier_sections;
+DROP TABLE IF EXISTS ticket_tiers;
+-- +goose StatementEnd
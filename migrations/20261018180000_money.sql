@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE concerts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE concerts ALTER COLUMN currency DROP DEFAULT;
+ALTER TABLE concerts ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT;
+
+ALTER TABLE ticket_tiers ADD COLUMN currency CHAR(3);
+
+UPDATE ticket_tiers t
+SET currency = c.currency
+FROM concerts c
+WHERE c.id = t.concert_id;
+
+ALTER TABLE ticket_tiers ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE ticket_tiers ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT;
+
+ALTER TABLE bookings ADD COLUMN currency CHAR(3);
+
+UPDATE bookings b
+SET currency = c.currency
+FROM concerts c
+WHERE c.id = b.concert_id;
+
+ALTER TABLE bookings ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE bookings ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE bookings ALTER COLUMN price TYPE DECIMAL(10, 2) USING price / 100.0;
+ALTER TABLE bookings DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE ticket_tiers ALTER COLUMN price TYPE DECIMAL(10, 2) USING price / 100.0;
+ALTER TABLE ticket_tiers DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE concerts ALTER COLUMN price TYPE DECIMAL(10, 2) USING price / 100.0;
+ALTER TABLE concerts DROP COLUMN IF EXISTS currency;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE concerts ADD COLUMN sale_starts_at TIMESTAMPTZ;
+ALTER TABLE concerts ADD COLUMN sale_ends_at TIMESTAMPTZ;
+ALTER TABLE concerts ADD CONSTRAINT concerts_sale_window_check
+    CHECK (sale_starts_at IS NULL OR sale_ends_at IS NULL OR sale_starts_at < sale_ends_at);
+
+CREATE TABLE IF NOT EXISTS presales (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    concert_id UUID NOT NULL REFERENCES concerts(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    access_code_hash TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (concert_id, name),
+    CHECK (starts_at < ends_at)
+);
+
+CREATE TABLE IF NOT EXISTS presale_allowlist (
+    presale_id UUID NOT NULL REFERENCES presales(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (presale_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS presale_allowlist;
+DROP TABLE IF EXISTS presales;
+ALTER TABLE concerts DROP CONSTRAINT IF EXISTS concerts_sale_window_check;
+ALTER TABLE concerts DROP COLUMN IF EXISTS sale_ends_at;
+ALTER TABLE concerts DROP COLUMN IF EXISTS sale_starts_at;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE concerts ADD COLUMN status TEXT NOT NULL DEFAULT 'ON_SALE'
+    CHECK (status IN ('DRAFT', 'ON_SALE', 'SOLD_OUT', 'CANCELLED', 'COMPLETED'));
+ALTER TABLE concerts ALTER COLUMN status SET DEFAULT 'DRAFT';
+
+UPDATE concerts SET status = 'COMPLETED' WHERE date <= NOW();
+UPDATE concerts SET status = 'SOLD_OUT' WHERE status = 'ON_SALE' AND available_seats = 0;
+
+CREATE INDEX IF NOT EXISTS idx_concerts_status_date ON concerts (status, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_concerts_status_date;
+ALTER TABLE concerts DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
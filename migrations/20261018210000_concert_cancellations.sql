@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS concert_cancellations (
+    concert_id UUID PRIMARY KEY REFERENCES concerts(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'COMPLETED')),
+    bookings_cancelled INT NOT NULL DEFAULT 0,
+    refunds_created INT NOT NULL DEFAULT 0,
+    users_notified INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS concert_cancellations_running_idx
+    ON concert_cancellations (created_at)
+    WHERE status = 'RUNNING';
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL UNIQUE REFERENCES bookings(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    concert_id UUID NOT NULL REFERENCES concerts(id),
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL,
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS refunds_user_id_idx ON refunds (user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS bookings_concert_active_user_idx
+    ON bookings (concert_id, user_id)
+    WHERE status <> 'CANCELLED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS bookings_concert_active_user_idx;
+DROP TABLE IF EXISTS refunds;
+DROP TABLE IF EXISTS concert_cancellations;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    concert_id UUID NOT NULL REFERENCES concerts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    status TEXT NOT NULL DEFAULT 'WAITING'
+        CHECK (status IN ('WAITING', 'OFFERED', 'CLAIMED', 'EXPIRED', 'LEFT')),
+    booking_id UUID REFERENCES bookings(id),
+    offer_expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS waitlist_entries_active_user_idx
+    ON waitlist_entries (concert_id, user_id)
+    WHERE status IN ('WAITING', 'OFFERED');
+
+CREATE INDEX IF NOT EXISTS waitlist_entries_waiting_idx
+    ON waitlist_entries (concert_id, created_at)
+    WHERE status = 'WAITING';
+
+CREATE INDEX IF NOT EXISTS waitlist_entries_booking_idx
+    ON waitlist_entries (booking_id)
+    WHERE booking_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS waitlist_entries;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE refunds
+    ADD COLUMN provider_ref TEXT,
+    ADD COLUMN failure_reason TEXT,
+    ADD COLUMN processed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS refunds_pending_idx
+    ON refunds (created_at)
+    WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS refunds_concert_id_idx ON refunds (concert_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS refunds_concert_id_idx;
+DROP INDEX IF EXISTS refunds_pending_idx;
+ALTER TABLE refunds
+    DROP COLUMN IF EXISTS processed_at,
+    DROP COLUMN IF EXISTS failure_reason,
+    DROP COLUMN IF EXISTS provider_ref;
+-- +goose StatementEnd
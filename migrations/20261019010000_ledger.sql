@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type TEXT NOT NULL CHECK (type IN ('SALE', 'PAYMENT', 'REFUND', 'REFUND_PAYOUT')),
+    concert_id UUID NOT NULL REFERENCES concerts(id),
+    booking_id UUID REFERENCES bookings(id),
+    reference_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES ledger_transactions(id),
+    concert_id UUID NOT NULL REFERENCES concerts(id),
+    account TEXT NOT NULL
+        CHECK (account IN ('CUSTOMER', 'PAYMENT_PROVIDER', 'ORGANIZER_REVENUE', 'PLATFORM_FEES', 'REFUNDS')),
+    direction TEXT NOT NULL CHECK (direction IN ('DEBIT', 'CREDIT')),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS ledger_entries_transaction_idx ON ledger_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS ledger_entries_concert_idx ON ledger_entries (concert_id, account);
+
+CREATE OR REPLACE FUNCTION ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger is append-only: % on % is not allowed', TG_OP, TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_transactions_append_only
+    BEFORE UPDATE OR DELETE ON ledger_transactions
+    FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+
+CREATE TRIGGER ledger_entries_append_only
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
+DROP FUNCTION IF EXISTS ledger_append_only();
+-- +goose StatementEnd
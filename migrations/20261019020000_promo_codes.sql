@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code TEXT NOT NULL UNIQUE,
+    concert_id UUID REFERENCES concerts(id) ON DELETE CASCADE,
+    discount_type TEXT NOT NULL CHECK (discount_type IN ('PERCENT', 'FIXED')),
+    value BIGINT NOT NULL CHECK (value > 0),
+    currency CHAR(3),
+    max_uses INT CHECK (max_uses > 0),
+    max_uses_per_user INT CHECK (max_uses_per_user > 0),
+    used_count INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (discount_type <> 'PERCENT' OR value < 100),
+    CHECK (discount_type <> 'FIXED' OR currency IS NOT NULL),
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at),
+    CHECK (max_uses IS NULL OR used_count <= max_uses)
+);
+
+CREATE TABLE IF NOT EXISTS promo_code_redemptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS promo_code_redemptions_user_idx ON promo_code_redemptions (promo_code_id, user_id);
+
+ALTER TABLE bookings ADD COLUMN promo_code_id UUID REFERENCES promo_codes(id);
+ALTER TABLE bookings ADD COLUMN discount BIGINT NOT NULL DEFAULT 0 CHECK (discount >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE bookings DROP COLUMN IF EXISTS discount;
+ALTER TABLE bookings DROP COLUMN IF EXISTS promo_code_id;
+DROP TABLE IF EXISTS promo_code_redemptions;
+DROP TABLE IF EXISTS promo_codes;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE bookings ADD COLUMN face_value BIGINT;
+ALTER TABLE bookings ADD COLUMN service_fee BIGINT NOT NULL DEFAULT 0 CHECK (service_fee >= 0);
+ALTER TABLE bookings ADD COLUMN vat BIGINT NOT NULL DEFAULT 0 CHECK (vat >= 0);
+
+UPDATE bookings SET face_value = price + discount;
+
+ALTER TABLE bookings ALTER COLUMN face_value SET NOT NULL;
+ALTER TABLE bookings ADD CONSTRAINT bookings_price_breakdown_check
+    CHECK (price = face_value - discount + service_fee + vat);
+
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_account_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('CUSTOMER', 'PAYMENT_PROVIDER', 'ORGANIZER_REVENUE', 'PLATFORM_FEES', 'VAT_PAYABLE', 'REFUNDS'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_account_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('CUSTOMER', 'PAYMENT_PROVIDER', 'ORGANIZER_REVENUE', 'PLATFORM_FEES', 'REFUNDS'));
+
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_price_breakdown_check;
+ALTER TABLE bookings DROP COLUMN IF EXISTS vat;
+ALTER TABLE bookings DROP COLUMN IF EXISTS service_fee;
+ALTER TABLE bookings DROP COLUMN IF EXISTS face_value;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE refunds ADD COLUMN claimed_at TIMESTAMPTZ;
+
+ALTER TABLE refunds DROP CONSTRAINT IF EXISTS refunds_status_check;
+ALTER TABLE refunds ADD CONSTRAINT refunds_status_check
+    CHECK (status IN ('PENDING', 'PROCESSING', 'SUCCEEDED', 'FAILED'));
+
+CREATE INDEX IF NOT EXISTS refunds_processing_idx
+    ON refunds (claimed_at)
+    WHERE status = 'PROCESSING';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS refunds_processing_idx;
+
+UPDATE refunds SET status = 'PENDING' WHERE status = 'PROCESSING';
+
+ALTER TABLE refunds DROP CONSTRAINT IF EXISTS refunds_status_check;
+ALTER TABLE refunds ADD CONSTRAINT refunds_status_check
+    CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED'));
+
+ALTER TABLE refunds DROP COLUMN IF EXISTS claimed_at;
+-- +goose StatementEnd
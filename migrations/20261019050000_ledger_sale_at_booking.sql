@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ledger_transactions DROP CONSTRAINT IF EXISTS ledger_transactions_type_check;
+ALTER TABLE ledger_transactions ADD CONSTRAINT ledger_transactions_type_check
+    CHECK (type IN ('SALE', 'SALE_REVERSAL', 'PAYMENT', 'REFUND', 'REFUND_PAYOUT'));
+
+WITH pending AS (
+    SELECT id, concert_id, price, face_value - discount AS revenue, service_fee, vat, currency
+    FROM bookings
+    WHERE status = 'PENDING' AND price > 0
+),
+sales AS (
+    INSERT INTO ledger_transactions (type, concert_id, booking_id)
+    SELECT 'SALE', concert_id, id
+    FROM pending
+    RETURNING id, booking_id
+)
+INSERT INTO ledger_entries (transaction_id, concert_id, account, direction, amount, currency)
+SELECT s.id, p.concert_id, e.account, e.direction, e.amount, p.currency
+FROM sales s
+JOIN pending p ON p.id = s.booking_id
+CROSS JOIN LATERAL (
+    VALUES
+        ('CUSTOMER', 'DEBIT', p.price),
+        ('ORGANIZER_REVENUE', 'CREDIT', p.revenue),
+        ('PLATFORM_FEES', 'CREDIT', p.service_fee),
+        ('VAT_PAYABLE', 'CREDIT', p.vat)
+) AS e(account, direction, amount)
+WHERE e.amount > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ledger_transactions DROP CONSTRAINT IF EXISTS ledger_transactions_type_check;
+ALTER TABLE ledger_transactions ADD CONSTRAINT ledger_transactions_type_check
+    CHECK (type IN ('SALE', 'PAYMENT', 'REFUND', 'REFUND_PAYOUT')) NOT VALID;
+-- +goose StatementEnd
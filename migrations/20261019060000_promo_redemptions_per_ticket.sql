@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE promo_code_redemptions ADD COLUMN booking_id UUID REFERENCES bookings(id) ON DELETE CASCADE;
+
+DELETE FROM promo_code_redemptions;
+
+INSERT INTO promo_code_redemptions (promo_code_id, user_id, booking_id, created_at)
+SELECT promo_code_id, user_id, id, created_at
+FROM bookings
+WHERE promo_code_id IS NOT NULL AND status IN ('PENDING', 'CONFIRMED');
+
+UPDATE promo_codes p
+SET used_count = LEAST(u.uses, COALESCE(p.max_uses, u.uses))
+FROM (
+    SELECT pc.id, COUNT(r.id) AS uses
+    FROM promo_codes pc
+    LEFT JOIN promo_code_redemptions r ON r.promo_code_id = pc.id
+    GROUP BY pc.id
+) u
+WHERE p.id = u.id;
+
+ALTER TABLE promo_code_redemptions ALTER COLUMN booking_id SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS promo_code_redemptions_booking_idx ON promo_code_redemptions (booking_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS promo_code_redemptions_booking_idx;
+ALTER TABLE promo_code_redemptions DROP COLUMN IF EXISTS booking_id;
+-- +goose StatementEnd